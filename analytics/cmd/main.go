@@ -1,6 +1,9 @@
 package main
 
 import (
+	"log"
+	"time"
+
 	"github.com/deltaswapio/deltaswap-explorer/analytics/cmd/metrics"
 	"github.com/deltaswapio/deltaswap-explorer/analytics/cmd/prices"
 	"github.com/deltaswapio/deltaswap-explorer/analytics/cmd/service"
@@ -50,6 +53,7 @@ func addBackfiller(root *cobra.Command) {
 		Use: "prices",
 	}
 	addPricesCommand(prices)
+	addPricesBackfillCommand(prices)
 	root.AddCommand(prices)
 }
 
@@ -118,7 +122,7 @@ func addVaaVolumeFromMongoCommand(parent *cobra.Command) {
 	vaaVolumeMongoCmd.Flags().StringVar(&output, "output", "", "path to output file")
 	vaaVolumeMongoCmd.MarkFlagRequired("output")
 	// prices flag
-	vaaVolumeMongoCmd.Flags().StringVar(&prices, "prices", "prices.csv", "path to prices file")
+	vaaVolumeMongoCmd.Flags().StringVar(&prices, "prices", "", "path to a prices file used when a price is not in the database")
 
 	//vaa-payload-parser-url flag
 	vaaVolumeMongoCmd.Flags().StringVar(&vaaPayloadParserURL, "vaa-payload-parser-url", "", "VAA payload parser URL")
@@ -137,20 +141,108 @@ func addVaaVolumeCommand(parent *cobra.Command) {
 
 	addVaaVolumeFromFileCommand(vaaVolumeCmd)
 	addVaaVolumeFromMongoCommand(vaaVolumeCmd)
+	addVaaVolumeRevalueCommand(vaaVolumeCmd)
 	parent.AddCommand(vaaVolumeCmd)
 }
 
+func addVaaVolumeRevalueCommand(parent *cobra.Command) {
+	var params metrics.RevalueParams
+	var start, stop string
+	revalueCmd := &cobra.Command{
+		Use:   "revalue",
+		Short: "Recompute the volume metrics of a token or a time range with the historical prices",
+		Run: func(_ *cobra.Command, _ []string) {
+			var err error
+			params.Start, err = time.Parse(time.RFC3339, start)
+			if err != nil {
+				log.Fatal("invalid start time", err)
+			}
+			params.Stop = time.Now()
+			if stop != "" {
+				params.Stop, err = time.Parse(time.RFC3339, stop)
+				if err != nil {
+					log.Fatal("invalid stop time", err)
+				}
+			}
+			if params.TokenAddress != "" && params.TokenChain == 0 {
+				log.Fatal("token-chain is required when token-address is set")
+			}
+			metrics.RunRevalue(&params)
+		},
+	}
+
+	//mongo flags
+	revalueCmd.Flags().StringVar(&params.MongoUri, "mongo-uri", "", "Mongo connection")
+	revalueCmd.MarkFlagRequired("mongo-uri")
+	revalueCmd.Flags().StringVar(&params.MongoDb, "mongo-database", "", "Mongo database")
+	revalueCmd.MarkFlagRequired("mongo-database")
+
+	//influx flags
+	revalueCmd.Flags().StringVar(&params.InfluxUrl, "influx-url", "", "InfluxDB URL")
+	revalueCmd.MarkFlagRequired("influx-url")
+	revalueCmd.Flags().StringVar(&params.InfluxToken, "influx-token", "", "InfluxDB token")
+	revalueCmd.MarkFlagRequired("influx-token")
+	revalueCmd.Flags().StringVar(&params.InfluxOrganization, "influx-organization", "", "InfluxDB organization")
+	revalueCmd.MarkFlagRequired("influx-organization")
+	revalueCmd.Flags().StringVar(&params.InfluxBucket, "influx-bucket", "deltaswapscan", "InfluxDB bucket of the vaa_volume_v2 measurement")
+
+	//vaa-payload-parser-url flag, the VAAs of the emitters without an in-process decoder are skipped when it is not set.
+	revalueCmd.Flags().StringVar(&params.VaaPayloadParserURL, "vaa-payload-parser-url", "", "VAA payload parser URL")
+	// prices flag
+	revalueCmd.Flags().StringVar(&params.PricesFile, "prices", "", "path to a prices file used when a price is not in the database")
+
+	// token flags
+	revalueCmd.Flags().Uint16Var(&params.TokenChain, "token-chain", 0, "chain id of the token to revalue")
+	revalueCmd.Flags().StringVar(&params.TokenAddress, "token-address", "", "address of the token to revalue (hex), every token is revalued when it is not set")
+
+	// time range flags
+	revalueCmd.Flags().StringVar(&start, "start", "", "start of the time range (RFC3339)")
+	revalueCmd.MarkFlagRequired("start")
+	revalueCmd.Flags().StringVar(&stop, "stop", "", "end of the time range (RFC3339), defaults to now")
+	revalueCmd.Flags().BoolVar(&params.SkipTasks, "skip-tasks", false, "do not run the downsampling tasks again")
+
+	parent.AddCommand(revalueCmd)
+}
+
 func addPricesCommand(root *cobra.Command) {
-	var output string
+	var output, coingeckoURL string
 	vaaCountCmd := &cobra.Command{
 		Use:   "history",
 		Short: "Generate notional price history for symbol",
 		Run: func(_ *cobra.Command, _ []string) {
-			prices.RunPrices(output)
+			prices.RunPrices(output, coingeckoURL)
 		},
 	}
 	// output flag
 	vaaCountCmd.Flags().StringVar(&output, "output", "", "path to output file")
 	vaaCountCmd.MarkFlagRequired("output")
+	// coingecko-url flag
+	vaaCountCmd.Flags().StringVar(&coingeckoURL, "coingecko-url", "https://api.coingecko.com/api/v3", "Coingecko API URL")
 	root.AddCommand(vaaCountCmd)
 }
+
+func addPricesBackfillCommand(root *cobra.Command) {
+	var mongoUri, mongoDb, coingeckoURL, from string
+	backfillCmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Store the daily price history of the known tokens in MongoDB",
+		Run: func(_ *cobra.Command, _ []string) {
+			fromTime, err := time.Parse("2006-01-02", from)
+			if err != nil {
+				log.Fatal("invalid from date", err)
+			}
+			prices.RunPricesBackfill(mongoUri, mongoDb, coingeckoURL, fromTime)
+		},
+	}
+
+	//mongo flags
+	backfillCmd.Flags().StringVar(&mongoUri, "mongo-uri", "", "Mongo connection")
+	backfillCmd.MarkFlagRequired("mongo-uri")
+	backfillCmd.Flags().StringVar(&mongoDb, "mongo-database", "", "Mongo database")
+	backfillCmd.MarkFlagRequired("mongo-database")
+	// coingecko-url flag
+	backfillCmd.Flags().StringVar(&coingeckoURL, "coingecko-url", "https://api.coingecko.com/api/v3", "Coingecko API URL")
+	// from flag
+	backfillCmd.Flags().StringVar(&from, "from", "2021-01-01", "first day of the prices of the tokens without stored prices (YYYY-MM-DD)")
+	root.AddCommand(backfillCmd)
+}
@@ -0,0 +1,179 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/analytics/cmd/token"
+	"github.com/deltaswapio/deltaswap-explorer/analytics/metric"
+	"github.com/deltaswapio/deltaswap-explorer/common/client/parser"
+	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	"github.com/deltaswapio/deltaswap-explorer/common/prices"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/common/tokens"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	influxDomain "github.com/influxdata/influxdb-client-go/v2/domain"
+	"go.uber.org/zap"
+)
+
+// RevalueParams contains the parameters of the revalue command.
+type RevalueParams struct {
+	MongoUri            string
+	MongoDb             string
+	InfluxUrl           string
+	InfluxToken         string
+	InfluxOrganization  string
+	InfluxBucket        string
+	VaaPayloadParserURL string
+	PricesFile          string
+	// TokenChain and TokenAddress select the transfers of a token, the transfers of every token are revalued when TokenAddress is empty.
+	TokenChain   uint16
+	TokenAddress string
+	Start        time.Time
+	Stop         time.Time
+	SkipTasks    bool
+}
+
+// RunRevalue recomputes the `vaa_volume_v2` points of a token or a time range with the historical prices
+// and runs again the downsampling tasks of the revalued range.
+//
+// The command is idempotent, when it fails it can be run again with the same parameters.
+func RunRevalue(params *RevalueParams) {
+
+	rootCtx := context.Background()
+
+	// build logger
+	logger := logger.New("deltaswap-explorer-analytics")
+
+	logger.Info("starting volume revalue ...")
+
+	filter := metric.RevalueFilter{Start: params.Start, Stop: params.Stop}
+	if params.TokenAddress != "" {
+		address, err := sdk.StringToAddress(params.TokenAddress)
+		if err != nil {
+			logger.Fatal("invalid token address", zap.String("tokenAddress", params.TokenAddress), zap.Error(err))
+		}
+		filter.TokenChain = sdk.ChainID(params.TokenChain)
+		filter.TokenAddress = &address
+	}
+
+	//setup DB connection
+	db, err := dbutil.Connect(rootCtx, logger, params.MongoUri, params.MongoDb, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	// the attested tokens of the token registry are revalued too.
+	tokenRegistry := tokens.NewRegistry(repository.NewTokenRepository(db.Database, logger), logger)
+	if err := tokenRegistry.Init(rootCtx); err != nil {
+		logger.Fatal("Failed to load token registry", zap.Error(err))
+	}
+	domain.SetTokenProvider(tokenRegistry)
+
+	// create a parse vaa function, the token metadata is only available for mainnet.
+	parseVaaFunc, err := parser.NewParseVaaFunc(domain.P2pMainNet, params.VaaPayloadParserURL, 10, logger)
+	if err != nil {
+		logger.Fatal("failed to create parse vaa function", zap.Error(err))
+	}
+	tokenResolver := token.NewTokenResolver(parseVaaFunc, logger)
+
+	// the historical prices are read from the database, the prices file is used as fallback.
+	sources := []prices.PriceSource{prices.NewMongoSource(db.Database)}
+	if params.PricesFile != "" {
+		staticSource, err := prices.NewStaticSource(params.PricesFile)
+		if err != nil {
+			logger.Fatal("loading historical prices", zap.Error(err))
+		}
+		sources = append(sources, staticSource)
+	}
+
+	influxCli := influxdb2.NewClient(params.InfluxUrl, params.InfluxToken)
+	defer influxCli.Close()
+	deletePoints := func(ctx context.Context, start, stop time.Time, predicate string) error {
+		return influxCli.DeleteAPI().DeleteWithName(ctx, params.InfluxOrganization, params.InfluxBucket, start, stop, predicate)
+	}
+	revaluer := metric.NewVolumeRevaluer(
+		influxCli.WriteAPIBlocking(params.InfluxOrganization, params.InfluxBucket),
+		deletePoints,
+		newRunTaskFunc(influxCli, params.InfluxOrganization),
+		prices.NewFallbackSource(sources...),
+		tokenResolver.GetTransferredTokenByVaa,
+		logger,
+	)
+
+	// delete the points, they are written again with the new prices.
+	logger.Info("deleting points",
+		zap.Time("start", filter.Start),
+		zap.Time("stop", filter.Stop),
+		zap.String("predicate", filter.DeletePredicate()))
+	if err := revaluer.Reset(rootCtx, &filter); err != nil {
+		logger.Fatal("failed to delete points", zap.Error(err))
+	}
+
+	vaaRepository := repository.NewVaaRepository(db.Database, logger)
+	startTime, endTime := filter.VaaRange()
+	page := int64(0)
+	total := 0
+	for {
+		vaaDocs, err := vaaRepository.FindPageByTimeRange(rootCtx, startTime, endTime, page, 1000, true)
+		if err != nil {
+			logger.Fatal("failed to get vaas, run the command again to finish the revalue", zap.Int64("page", page), zap.Error(err))
+		}
+		if len(vaaDocs) == 0 {
+			break
+		}
+
+		vaas := make([]*sdk.VAA, 0, len(vaaDocs))
+		for _, v := range vaaDocs {
+			vaa, err := sdk.Unmarshal(v.Vaa)
+			if err != nil {
+				logger.Warn("failed to unmarshal vaa", zap.String("id", v.ID), zap.Error(err))
+				continue
+			}
+			vaas = append(vaas, vaa)
+		}
+
+		count, err := revaluer.Revalue(rootCtx, &filter, vaas)
+		if err != nil {
+			logger.Fatal("failed to revalue points, run the command again to finish the revalue", zap.Int64("page", page), zap.Error(err))
+		}
+		total += count
+		logger.Info("revalued page", zap.Int64("page", page), zap.Int("vaas", len(vaas)), zap.Int("points", count))
+		page++
+	}
+	logger.Info("revalued points", zap.Int("count", total))
+
+	if !params.SkipTasks {
+		if err := revaluer.RerunTasks(rootCtx, &filter); err != nil {
+			logger.Fatal("failed to run tasks", zap.Error(err))
+		}
+	}
+
+	logger.Info("finished volume revalue")
+}
+
+// newRunTaskFunc creates a metric.RunTaskFunc that runs the influx tasks manually.
+// The scheduledFor time is used as the `now` option of the run.
+func newRunTaskFunc(influxCli influxdb2.Client, organization string) metric.RunTaskFunc {
+	return func(ctx context.Context, taskName string, scheduledFor time.Time) error {
+		tasks, err := influxCli.TasksAPI().FindTasks(ctx, &api.TaskFilter{Name: taskName, OrgName: organization})
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return fmt.Errorf("task not found: %s", taskName)
+		}
+		params := &influxDomain.PostTasksIDRunsAllParams{
+			TaskID: tasks[0].Id,
+			Body:   influxDomain.PostTasksIDRunsJSONRequestBody{ScheduledFor: &scheduledFor},
+		}
+		_, err = influxCli.APIClient().PostTasksIDRuns(ctx, params)
+		return err
+	}
+}
@@ -9,8 +9,8 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/analytics/cmd/token"
 	"github.com/deltaswapio/deltaswap-explorer/analytics/internal/metrics"
 	"github.com/deltaswapio/deltaswap-explorer/analytics/metric"
-	"github.com/deltaswapio/deltaswap-explorer/analytics/prices"
 	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/common/prices"
 	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
 	"github.com/shopspring/decimal"
@@ -19,16 +19,16 @@ import (
 type VaaConverter struct {
 	MissingTokens            map[sdk.Address]sdk.ChainID
 	MissingTokensCounter     map[sdk.Address]int
-	PriceCache               *prices.CoinPricesCache
+	PriceSource              prices.PriceSource
 	Metrics                  metrics.Metrics
 	GetTransferredTokenByVaa token.GetTransferredTokenByVaa
 }
 
-func NewVaaConverter(priceCache *prices.CoinPricesCache, GetTransferredTokenByVaa token.GetTransferredTokenByVaa) *VaaConverter {
+func NewVaaConverter(priceSource prices.PriceSource, GetTransferredTokenByVaa token.GetTransferredTokenByVaa) *VaaConverter {
 	return &VaaConverter{
 		MissingTokens:            make(map[sdk.Address]sdk.ChainID),
 		MissingTokensCounter:     make(map[sdk.Address]int),
-		PriceCache:               priceCache,
+		PriceSource:              priceSource,
 		Metrics:                  metrics.NewNoopMetrics(),
 		GetTransferredTokenByVaa: GetTransferredTokenByVaa,
 	}
@@ -64,8 +64,8 @@ func (c *VaaConverter) Convert(ctx context.Context, vaaBytes []byte) (string, er
 			Vaa: vaa,
 			TokenPriceFunc: func(_ string, timestamp time.Time) (decimal.Decimal, error) {
 
-				// fetch the historic price from cache
-				price, err := c.PriceCache.GetPriceByTime(tokenMetadata.CoingeckoID, timestamp)
+				// fetch the historic price from the price source
+				price, err := c.PriceSource.GetPriceByTime(ctx, tokenMetadata.CoingeckoID, timestamp)
 				if err != nil {
 					return decimal.NewFromInt(0), err
 				}
@@ -10,9 +10,10 @@ import (
 	"strings"
 
 	"github.com/deltaswapio/deltaswap-explorer/analytics/cmd/token"
-	"github.com/deltaswapio/deltaswap-explorer/analytics/prices"
 	"github.com/deltaswapio/deltaswap-explorer/common/client/parser"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	"github.com/deltaswapio/deltaswap-explorer/common/prices"
 	"go.uber.org/zap"
 )
 
@@ -30,14 +31,14 @@ func RunVaaVolumeFromFile(inputFile, outputFile, pricesFile, vaaPayloadParserURL
 
 	logger.Info("starting deltaswap-explorer-analytics ...")
 
-	// create a parserVAAAPIClient
-	parserVAAAPIClient, err := parser.NewParserVAAAPIClient(10, vaaPayloadParserURL, logger)
+	// create a parse vaa function, the token metadata is only available for mainnet.
+	parseVaaFunc, err := parser.NewParseVaaFunc(domain.P2pMainNet, vaaPayloadParserURL, 10, logger)
 	if err != nil {
-		logger.Fatal("failed to create parse vaa api client")
+		logger.Fatal("failed to create parse vaa function")
 	}
 
 	// create a token resolver
-	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, logger)
+	tokenResolver := token.NewTokenResolver(parseVaaFunc, logger)
 
 	// open input file
 	f, err := os.Open(inputFile)
@@ -63,9 +64,11 @@ func RunVaaVolumeFromFile(inputFile, outputFile, pricesFile, vaaPayloadParserURL
 
 	// init price cache!
 	logger.Info("loading historical prices...")
-	priceCache := prices.NewCoinPricesCache(pricesFile)
-	priceCache.InitCache()
-	converter := NewVaaConverter(priceCache, tokenResolver.GetTransferredTokenByVaa)
+	priceSource, err := prices.NewStaticSource(pricesFile)
+	if err != nil {
+		logger.Fatal("loading historical prices", zap.Error(err))
+	}
+	converter := NewVaaConverter(priceSource, tokenResolver.GetTransferredTokenByVaa)
 	lp := NewLineParser(converter)
 	logger.Info("loaded historical prices")
 
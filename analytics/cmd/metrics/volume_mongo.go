@@ -7,10 +7,11 @@ import (
 	"time"
 
 	"github.com/deltaswapio/deltaswap-explorer/analytics/cmd/token"
-	"github.com/deltaswapio/deltaswap-explorer/analytics/prices"
 	"github.com/deltaswapio/deltaswap-explorer/common/client/parser"
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	"github.com/deltaswapio/deltaswap-explorer/common/prices"
 	"github.com/deltaswapio/deltaswap-explorer/common/repository"
 	"go.uber.org/zap"
 )
@@ -35,14 +36,14 @@ func RunVaaVolumeFromMongo(mongoUri, mongoDb, outputFile, pricesFile, vaaPayload
 	// create a new VAA repository
 	vaaRepository := repository.NewVaaRepository(db.Database, logger)
 
-	// create a parserVAAAPIClient
-	parserVAAAPIClient, err := parser.NewParserVAAAPIClient(10, vaaPayloadParserURL, logger)
+	// create a parse vaa function, the token metadata is only available for mainnet.
+	parseVaaFunc, err := parser.NewParseVaaFunc(domain.P2pMainNet, vaaPayloadParserURL, 10, logger)
 	if err != nil {
-		logger.Fatal("failed to create parse vaa api client")
+		logger.Fatal("failed to create parse vaa function")
 	}
 
 	// create a token resolver
-	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, logger)
+	tokenResolver := token.NewTokenResolver(parseVaaFunc, logger)
 
 	// create missing tokens file
 	missingTokensFile := "missing_tokens.csv"
@@ -59,12 +60,18 @@ func RunVaaVolumeFromMongo(mongoUri, mongoDb, outputFile, pricesFile, vaaPayload
 	}
 	defer fout.Close()
 
-	// init price cache!
-	logger.Info("loading historical prices...")
-	priceCache := prices.NewCoinPricesCache(pricesFile)
-	priceCache.InitCache()
-	converter := NewVaaConverter(priceCache, tokenResolver.GetTransferredTokenByVaa)
-	logger.Info("loaded historical prices")
+	// the historical prices are read from the database, the prices file is used as fallback.
+	sources := []prices.PriceSource{prices.NewMongoSource(db.Database)}
+	if pricesFile != "" {
+		logger.Info("loading historical prices...")
+		staticSource, err := prices.NewStaticSource(pricesFile)
+		if err != nil {
+			logger.Fatal("loading historical prices", zap.Error(err))
+		}
+		sources = append(sources, staticSource)
+		logger.Info("loaded historical prices")
+	}
+	converter := NewVaaConverter(prices.NewFallbackSource(sources...), tokenResolver.GetTransferredTokenByVaa)
 
 	endTime := time.Now()
 	startTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
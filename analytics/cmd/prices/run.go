@@ -1,27 +1,36 @@
 package prices
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"time"
 
-	"github.com/deltaswapio/deltaswap-explorer/analytics/coingecko"
+	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
 	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	"github.com/deltaswapio/deltaswap-explorer/common/prices"
 	"go.uber.org/zap"
 )
 
+// coingeckoRequestDelay is the time waited between coingecko requests to stay below the rate limit.
+const coingeckoRequestDelay = 5 * time.Second
+
 // go througth the symbol list provided by wormhole
 // and fetch the history from coingecko
 // and save it to a file
-func RunPrices(output string) {
+func RunPrices(output, coingeckoURL string) {
+
+	ctx := context.Background()
 
 	// build logger
 	logger := logger.New("deltaswap-explorer-analytics")
 
 	logger.Info("starting deltaswap-explorer-analytics ...")
 
-	cg := coingecko.NewCoinGeckoAPI("")
+	cg := prices.NewCoingeckoSource(coingeckoURL)
 
 	pricesOutput, err := os.Create(output)
 	if err != nil {
@@ -29,6 +38,7 @@ func RunPrices(output string) {
 	}
 	defer pricesOutput.Close()
 
+	from := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
 	tokens := domain.GetAllTokens()
 	logger.Info("found tokens", zap.Int("count", len(tokens)))
 	for index, token := range tokens {
@@ -37,19 +47,83 @@ func RunPrices(output string) {
 			zap.Stringer("symbol", token.Symbol),
 			zap.Int("index", index+1), zap.Int("count", len(tokens)))
 
-		r, err := cg.GetSymbolDailyPrice(token.CoingeckoID)
+		dailyPrices, err := cg.GetDailyPrices(ctx, token.CoingeckoID, from, time.Now())
 		if err != nil {
 			fmt.Println(err)
 			continue
 		}
-		for _, p := range r.Prices {
-			pricesOutput.WriteString(fmt.Sprintf("%d,%s,%s,%s,%s\n", token.TokenChain, token.CoingeckoID, token.Symbol, p[0], p[1]))
+		for _, p := range dailyPrices {
+			pricesOutput.WriteString(fmt.Sprintf("%d,%s,%s,%d,%s\n", token.TokenChain, token.CoingeckoID, token.Symbol, p.Day.UnixMilli(), p.Price))
 		}
 
-		time.Sleep(5 * time.Second) // 10 requests per second
+		time.Sleep(coingeckoRequestDelay)
 
 	}
 
 	logger.Info("finished deltaswap-explorer-analytics")
 
 }
+
+// RunPricesBackfill fetches the daily prices of the known tokens from coingecko and stores them in the database.
+//
+// The prices of each token are fetched from the day after its last stored price, or from the given
+// start day when the token has no prices yet, so the command can be run periodically.
+func RunPricesBackfill(mongoUri, mongoDb, coingeckoURL string, from time.Time) {
+
+	ctx := context.Background()
+
+	// build logger
+	logger := logger.New("deltaswap-explorer-analytics")
+
+	logger.Info("starting prices backfill ...")
+
+	//setup DB connection
+	db, err := dbutil.Connect(ctx, logger, mongoUri, mongoDb, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	store := prices.NewMongoSource(db.Database)
+	cg := prices.NewCoingeckoSource(coingeckoURL)
+
+	coingeckoIDs := domain.GetAllCoingeckoIDs()
+	sort.Strings(coingeckoIDs)
+	logger.Info("found coingecko ids", zap.Int("count", len(coingeckoIDs)))
+
+	now := time.Now()
+	for index, coingeckoID := range coingeckoIDs {
+
+		start := from
+		latest, err := store.FindLatestDay(ctx, coingeckoID)
+		switch {
+		case err == nil:
+			// the price of the latest day is fetched again, it may have been stored before the day ended.
+			start = latest
+		case !errors.Is(err, prices.ErrPriceNotFound):
+			logger.Error("failed to find latest price day", zap.String("coingeckoID", coingeckoID), zap.Error(err))
+			continue
+		}
+
+		logger.Info("processing coingecko id",
+			zap.String("coingeckoID", coingeckoID),
+			zap.Time("from", start),
+			zap.Int("index", index+1), zap.Int("count", len(coingeckoIDs)))
+
+		dailyPrices, err := cg.GetDailyPrices(ctx, coingeckoID, start, now)
+		if err != nil {
+			logger.Error("failed to get daily prices", zap.String("coingeckoID", coingeckoID), zap.Error(err))
+			time.Sleep(coingeckoRequestDelay)
+			continue
+		}
+		if err := store.Save(ctx, dailyPrices); err != nil {
+			logger.Error("failed to save daily prices", zap.String("coingeckoID", coingeckoID), zap.Error(err))
+		} else {
+			logger.Info("saved daily prices", zap.String("coingeckoID", coingeckoID), zap.Int("count", len(dailyPrices)))
+		}
+
+		time.Sleep(coingeckoRequestDelay)
+	}
+
+	logger.Info("finished prices backfill")
+}
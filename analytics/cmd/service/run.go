@@ -25,8 +25,11 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/common/client/parser"
 	sqs_client "github.com/deltaswapio/deltaswap-explorer/common/client/sqs"
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	health "github.com/deltaswapio/deltaswap-explorer/common/health"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/common/tokens"
 	"github.com/go-redis/redis/v8"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -65,6 +68,15 @@ func Run() {
 		logger.Fatal("failed to connect MongoDB", zap.Error(err))
 	}
 
+	// load the token registry.
+	logger.Info("loading token registry...")
+	tokenRegistry := tokens.NewRegistry(repository.NewTokenRepository(db.Database, logger), logger)
+	if err := tokenRegistry.Init(rootCtx); err != nil {
+		logger.Fatal("failed to load token registry", zap.Error(err))
+	}
+	go tokenRegistry.Watch(rootCtx)
+	domain.SetTokenProvider(tokenRegistry)
+
 	// create influxdb client.
 	logger.Info("initializing InfluxDB client...")
 	influxCli := newInfluxClient(config.InfluxUrl, config.InfluxToken)
@@ -87,15 +99,15 @@ func Run() {
 	// create prometheus client
 	metrics := metrics.NewPrometheusMetrics(config.Environment)
 
-	// create a parserVAAAPIClient
-	parserVAAAPIClient, err := parser.NewParserVAAAPIClient(config.VaaPayloadParserTimeout,
-		config.VaaPayloadParserURL, logger)
+	// create a parse vaa function, the token bridge payloads are decoded in-process.
+	parseVaaFunc, err := parser.NewParseVaaFunc(config.P2pNetwork, config.VaaPayloadParserURL,
+		config.VaaPayloadParserTimeout, logger)
 	if err != nil {
-		logger.Fatal("failed to create parse vaa api client")
+		logger.Fatal("failed to create parse vaa function")
 	}
 
 	// create a token resolver
-	tokenResolver := token.NewTokenResolver(parserVAAAPIClient, logger)
+	tokenResolver := token.NewTokenResolver(parseVaaFunc, logger)
 
 	// create a metrics instance
 	logger.Info("initializing metrics instance...")
@@ -111,6 +123,9 @@ func Run() {
 	consumer := consumer.New(vaaConsumeFunc, metric.Push, logger, config.P2pNetwork)
 	consumer.Start(rootCtx)
 
+	// create the phylax observation measurements.
+	go metric.RunObservationMeasurements(rootCtx)
+
 	// create and start server.
 	logger.Info("initializing infrastructure server...")
 
@@ -44,14 +44,17 @@ func (t *TransferredToken) Clone() *TransferredToken {
 type GetTransferredTokenByVaa func(context.Context, *sdk.VAA) (*TransferredToken, error)
 
 type TokenResolver struct {
-	client parser.ParserVAAAPIClient
-	logger *zap.Logger
+	parseVaaFunc parser.ParseVaaFunc
+	logger       *zap.Logger
 }
 
-func NewTokenResolver(client parser.ParserVAAAPIClient, logger *zap.Logger) *TokenResolver {
+// NewTokenResolver creates a TokenResolver.
+// parseVaaFunc is usually created with parser.NewParseVaaFunc, so the token bridge payloads
+// are decoded in-process and the payload parser service is only called for the other emitters.
+func NewTokenResolver(parseVaaFunc parser.ParseVaaFunc, logger *zap.Logger) *TokenResolver {
 	return &TokenResolver{
-		client: client,
-		logger: logger,
+		parseVaaFunc: parseVaaFunc,
+		logger:       logger,
 	}
 }
 
@@ -62,22 +65,8 @@ func (r *TokenResolver) GetTransferredTokenByVaa(ctx context.Context, vaa *sdk.V
 		return nil, nil
 	}
 
-	// Decode the VAA payload
-	payload, err := sdk.DecodeTransferPayloadHdr(vaa.Payload)
-	if err == nil && payload.OriginChain != sdk.ChainIDUnset {
-		return &TransferredToken{
-			AppId:        domain.AppIdPortalTokenBridge,
-			FromChain:    vaa.EmitterChain,
-			ToChain:      payload.TargetChain,
-			TokenAddress: payload.OriginAddress,
-			TokenChain:   payload.OriginChain,
-			Amount:       payload.Amount,
-		}, nil
-
-	}
-
 	// Parse the VAA with standarized properties
-	result, err := r.client.ParseVaaWithStandarizedProperties(vaa)
+	result, err := r.parseVaaFunc(vaa)
 	if err != nil {
 		r.logger.Error("Parsing vaa with standarized properties",
 			zap.String("vaaId", vaa.MessageID()),
@@ -30,7 +30,9 @@ const (
 type Metric struct {
 	db *mongo.Database
 	// transferPrices contains the notional price for each token bridge transfer.
-	transferPrices           *mongo.Collection
+	transferPrices *mongo.Collection
+	// unpricedTokens contains the tokens of the transfers whose volume couldn't be computed.
+	unpricedTokens           *mongo.Collection
 	influxCli                influxdb2.Client
 	apiBucketInfinite        api.WriteAPIBlocking
 	apiBucket30Days          api.WriteAPIBlocking
@@ -64,6 +66,7 @@ func New(
 	m := Metric{
 		db:                       db,
 		transferPrices:           db.Collection("transferPrices"),
+		unpricedTokens:           db.Collection("unpricedTokens"),
 		influxCli:                influxCli,
 		apiBucketInfinite:        apiBucketInfinite,
 		apiBucket24Hours:         apiBucket24Hours,
@@ -242,6 +245,18 @@ func (m *Metric) volumeMeasurement(ctx context.Context, vaa *sdk.VAA, token *tok
 	)
 	m.metrics.IncSuccessfulMeasurement(VaaVolumeMeasurement)
 
+	// Keep track of the tokens without price, so their volume can be backfilled.
+	if isUnpriced(point) {
+		err = upsertUnpricedToken(ctx, vaa, m.unpricedTokens, token)
+		if err != nil {
+			m.logger.Error("failed to upsert unpriced token",
+				zap.String("vaaId", vaa.MessageID()),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -330,7 +345,7 @@ func MakePointForVaaVolume(params *MakePointForVaaVolumeParams) (*write.Point, e
 		AddTag("token_chain", fmt.Sprintf("%d", params.TransferredToken.TokenChain)).
 		// Measurement version
 		AddTag("version", "v2").
-		SetTime(params.Vaa.Timestamp)
+		SetTime(generateUniqueTimestamp(params.Vaa))
 
 	// Get the token metadata
 	//
@@ -339,14 +354,16 @@ func MakePointForVaaVolume(params *MakePointForVaaVolumeParams) (*write.Point, e
 	if !ok {
 		params.Metrics.IncMissingToken(params.TransferredToken.TokenChain.String(), params.TransferredToken.TokenAddress.String())
 		// We don't have metadata for this token, so we can't compute the volume-related fields
-		// (i.e.: amount, notional, volume, symbol, etc.)
+		// (i.e.: notional, volume, symbol, etc.)
 		//
-		// InfluxDB will reject data points that don't have any fields, so we need to
-		// add a dummy field.
+		// The amount is the one in the VAA, the token bridge normalizes it to at most 8 decimals.
 		//
-		// Moreover, many flux queries depend on the existence of the `volume` field,
+		// Many flux queries depend on the existence of the `volume` field,
 		// and would break if we had measurements without it.
-		point.AddField("volume", uint64(0))
+		point.
+			AddField("amount", params.TransferredToken.Amount.Uint64()).
+			AddField("volume", uint64(0)).
+			AddField("priced", false)
 		return point, nil
 	}
 	params.Metrics.IncFoundToken(params.TransferredToken.TokenChain.String(), params.TransferredToken.TokenAddress.String())
@@ -375,7 +392,13 @@ func MakePointForVaaVolume(params *MakePointForVaaVolumeParams) (*write.Point, e
 				zap.Error(err),
 			)
 		}
-		return nil, nil
+		// Without a price the volume can't be computed, the point is flagged so the volume can be backfilled.
+		point.
+			AddField("symbol", tokenMeta.Symbol.String()).
+			AddField("amount", amount.Uint64()).
+			AddField("volume", uint64(0)).
+			AddField("priced", false)
+		return point, nil
 	}
 	params.Metrics.IncFoundNotional(tokenMeta.Symbol.String())
 
@@ -401,11 +424,25 @@ func MakePointForVaaVolume(params *MakePointForVaaVolumeParams) (*write.Point, e
 		AddField("notional", notionalBigInt.Uint64()).
 		// Volume in USD, integer, 8 decimals of precision
 		AddField("volume", volume.Uint64()).
-		SetTime(generateUniqueTimestamp(params.Vaa))
+		// The points without a price have this field set to false
+		AddField("priced", true)
 
 	return point, nil
 }
 
+// isUnpriced returns true if the volume point was generated without a token price.
+//
+// The `priced` flag is a field and not a tag, so that the revalued points overwrite
+// the unpriced points in the same series.
+func isUnpriced(point *write.Point) bool {
+	for _, field := range point.FieldList() {
+		if field.Key == "priced" {
+			return field.Value == false
+		}
+	}
+	return false
+}
+
 // generateUniqueTimestamp generates a unique timestamp for each VAA.
 //
 // Most VAA timestamps only have millisecond resolution, so it is possible that two VAAs
@@ -106,3 +106,67 @@ func upsertTransferPrices(
 
 	return nil
 }
+
+// Reasons for a token transfer to be unpriced.
+const (
+	UnpricedReasonUnknownToken = "unknown_token"
+	UnpricedReasonMissingPrice = "missing_price"
+)
+
+// UnpricedTokenDoc models a document in the `unpricedTokens` collection.
+//
+// The collection contains the tokens whose transfers generated a volume point without a price,
+// so their volume can be backfilled once a price is available.
+type UnpricedTokenDoc struct {
+	// ID is the token ID, in the format `tokenChain/tokenAddress`.
+	ID           string      `bson:"_id"`
+	TokenChain   sdk.ChainID `bson:"tokenChain"`
+	TokenAddress string      `bson:"tokenAddress"`
+	// Symbol and CoingeckoID are only set for tokens with metadata.
+	Symbol      string `bson:"symbol,omitempty"`
+	CoingeckoID string `bson:"coingeckoId,omitempty"`
+	// Reason is UnpricedReasonUnknownToken or UnpricedReasonMissingPrice.
+	Reason string `bson:"reason"`
+	// VaaIDs are the IDs of the unpriced transfers processed.
+	// A set is used instead of a counter, so a VAA redelivered is only counted once.
+	VaaIDs []string `bson:"vaaIds"`
+	// FirstVaaTimestamp and LastVaaTimestamp bound the timestamps of the unpriced transfers.
+	FirstVaaTimestamp time.Time `bson:"firstVaaTimestamp"`
+	LastVaaTimestamp  time.Time `bson:"lastVaaTimestamp"`
+	UpdatedAt         time.Time `bson:"updatedAt"`
+}
+
+func upsertUnpricedToken(
+	ctx context.Context,
+	vaa *sdk.VAA,
+	unpricedTokens *mongo.Collection,
+	transferredToken *token.TransferredToken,
+) error {
+
+	id := fmt.Sprintf("%d/%s", transferredToken.TokenChain, transferredToken.TokenAddress.String())
+	set := bson.M{
+		"tokenChain":   transferredToken.TokenChain,
+		"tokenAddress": transferredToken.TokenAddress.String(),
+		"reason":       UnpricedReasonUnknownToken,
+		"updatedAt":    time.Now(),
+	}
+	tokenMeta, ok := domain.GetTokenByAddress(transferredToken.TokenChain, transferredToken.TokenAddress.String())
+	if ok {
+		set["symbol"] = tokenMeta.Symbol.String()
+		set["coingeckoId"] = tokenMeta.CoingeckoID
+		set["reason"] = UnpricedReasonMissingPrice
+	}
+
+	update := bson.M{
+		"$set":      set,
+		"$addToSet": bson.M{"vaaIds": vaa.MessageID()},
+		"$min":      bson.M{"firstVaaTimestamp": vaa.Timestamp},
+		"$max":      bson.M{"lastVaaTimestamp": vaa.Timestamp},
+	}
+	_, err := unpricedTokens.UpdateByID(ctx, id, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to update unpriced tokens collection: %w", err)
+	}
+
+	return nil
+}
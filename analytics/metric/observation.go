@@ -0,0 +1,256 @@
+package metric
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	PhylaxObservationDelayMeasurement  = "phylax_observation_delay"
+	PhylaxMissedObservationMeasurement = "phylax_missed_observation"
+)
+
+const (
+	// observationMeasurementInterval is the period of the observation measurements.
+	observationMeasurementInterval = 5 * time.Minute
+	// observationSettleDelay is the time waited after a message reaches the quorum before measuring its observations,
+	// so the phylaxs that observe the message after the quorum are not counted as missed.
+	observationSettleDelay = 10 * time.Minute
+	// observationMaxCatchUp is how far back the measurements are resumed after a restart. The hourly tasks
+	// re-aggregate the same period, so the points written late are included in their hours.
+	observationMaxCatchUp = 24 * time.Hour
+	// observationCursorID is the ID of the document that stores the end of the last interval measured.
+	observationCursorID = "observationMeasurements"
+)
+
+// metricCursorDoc models a document in the `metricCursors` collection.
+type metricCursorDoc struct {
+	ID        string    `bson:"_id"`
+	To        time.Time `bson:"to"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+// observationQuorumDoc models a document in the `observationQuorums` collection.
+type observationQuorumDoc struct {
+	ID             string      `bson:"_id"`
+	EmitterChain   sdk.ChainID `bson:"emitterChain"`
+	EmitterAddr    string      `bson:"emitterAddr"`
+	Sequence       string      `bson:"sequence"`
+	QuorumAt       time.Time   `bson:"quorumAt"`
+	MissingSigners []string    `bson:"missingSigners"`
+}
+
+// observationDoc models the fields of a document in the `observations` collection used by the observation measurements.
+type observationDoc struct {
+	PhylaxAddr string    `bson:"phylaxAddr"`
+	IndexedAt  time.Time `bson:"indexedAt"`
+}
+
+// RunObservationMeasurements periodically creates the observation measurements for the messages
+// that reached the quorum, until the context is cancelled.
+//
+// The end of the last interval measured is persisted, so the measurements are resumed from it after a restart.
+func (m *Metric) RunObservationMeasurements(ctx context.Context) {
+
+	from := m.loadObservationCursor(ctx)
+
+	ticker := time.NewTicker(observationMeasurementInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			to := time.Now().Add(-observationSettleDelay)
+			if err := m.observationMeasurements(ctx, from, to); err != nil {
+				m.logger.Error("failed to create observation measurements",
+					zap.Time("from", from),
+					zap.Time("to", to),
+					zap.Error(err),
+				)
+				continue
+			}
+			from = to
+			m.saveObservationCursor(ctx, to)
+		}
+	}
+}
+
+// loadObservationCursor returns the start of the next interval to measure.
+// It is the end of the last interval measured, but no older than observationMaxCatchUp.
+func (m *Metric) loadObservationCursor(ctx context.Context) time.Time {
+
+	now := time.Now()
+	from := now.Add(-observationSettleDelay - observationMeasurementInterval)
+
+	var cursor metricCursorDoc
+	err := m.db.Collection("metricCursors").FindOne(ctx, bson.M{"_id": observationCursorID}).Decode(&cursor)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			m.logger.Error("failed to load observation measurements cursor", zap.Error(err))
+		}
+		return from
+	}
+
+	oldest := now.Add(-observationMaxCatchUp)
+	if cursor.To.Before(oldest) {
+		m.logger.Warn("observation measurements cursor is too old, the older messages are not measured",
+			zap.Time("cursor", cursor.To),
+			zap.Time("from", oldest),
+		)
+		return oldest
+	}
+	return cursor.To
+}
+
+// saveObservationCursor persists the end of the last interval measured.
+// When it fails, the interval is measured again after a restart.
+func (m *Metric) saveObservationCursor(ctx context.Context, to time.Time) {
+
+	update := bson.M{"$set": metricCursorDoc{ID: observationCursorID, To: to, UpdatedAt: time.Now()}}
+	opts := options.Update().SetUpsert(true)
+	_, err := m.db.Collection("metricCursors").UpdateByID(ctx, observationCursorID, update, opts)
+	if err != nil {
+		m.logger.Error("failed to save observation measurements cursor", zap.Time("to", to), zap.Error(err))
+	}
+}
+
+// observationMeasurements creates the points of the `phylax_observation_delay` and `phylax_missed_observation`
+// measurements for the messages that reached the quorum in the [from, to) interval.
+func (m *Metric) observationMeasurements(ctx context.Context, from, to time.Time) error {
+
+	filter := bson.M{"quorumAt": bson.M{"$gte": from, "$lt": to}}
+	cur, err := m.db.Collection("observationQuorums").Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	var quorums []observationQuorumDoc
+	if err := cur.All(ctx, &quorums); err != nil {
+		return err
+	}
+
+	for i := range quorums {
+		points, err := m.makePointsForObservationQuorum(ctx, &quorums[i])
+		if err != nil {
+			return err
+		}
+		for _, point := range points {
+			if err := m.apiBucket30Days.WritePoint(ctx, point); err != nil {
+				m.logger.Error("failed to write metric",
+					zap.String("measurement", point.Name()),
+					zap.String("vaaId", quorums[i].ID),
+					zap.Error(err),
+				)
+				m.metrics.IncFailedMeasurement(point.Name())
+				return err
+			}
+			m.metrics.IncSuccessfulMeasurement(point.Name())
+		}
+	}
+
+	return nil
+}
+
+// makePointsForObservationQuorum reads the VAA and the observations of a message and generates its observation points.
+func (m *Metric) makePointsForObservationQuorum(ctx context.Context, q *observationQuorumDoc) ([]*write.Point, error) {
+
+	var vaaDoc struct {
+		Vaa []byte `bson:"vaas"`
+	}
+	err := m.db.Collection("vaas").FindOne(ctx, bson.M{"_id": q.ID}).Decode(&vaaDoc)
+	if err != nil {
+		// the VAA may not be stored yet, the message is skipped.
+		m.logger.Debug("failed to find vaa for observation measurements", zap.String("vaaId", q.ID), zap.Error(err))
+		return nil, nil
+	}
+	vaa, err := sdk.Unmarshal(vaaDoc.Vaa)
+	if err != nil {
+		m.logger.Warn("failed to unmarshal vaa for observation measurements", zap.String("vaaId", q.ID), zap.Error(err))
+		return nil, nil
+	}
+
+	filter := bson.M{
+		"emitterChain": q.EmitterChain,
+		"emitterAddr":  q.EmitterAddr,
+		"sequence":     q.Sequence,
+	}
+	opts := options.Find().SetProjection(bson.M{"phylaxAddr": 1, "indexedAt": 1})
+	cur, err := m.db.Collection("observations").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var observations []observationDoc
+	if err := cur.All(ctx, &observations); err != nil {
+		return nil, err
+	}
+
+	// a phylax may send more than one observation for a message, only the first one is measured.
+	observedAt := make(map[string]time.Time, len(observations))
+	for _, o := range observations {
+		if t, ok := observedAt[o.PhylaxAddr]; !ok || o.IndexedAt.Before(t) {
+			observedAt[o.PhylaxAddr] = o.IndexedAt
+		}
+	}
+
+	return MakePointsForObservations(vaa, q.QuorumAt, observedAt, q.MissingSigners), nil
+}
+
+// MakePointsForObservations generates a `phylax_observation_delay` point for each phylax that observed the VAA
+// and a `phylax_missed_observation` point for each phylax of the phylax set that never observed it.
+//
+// The delay is measured in milliseconds from the VAA timestamp to the time the observation was received.
+// The points are timestamped with the time the message reached the quorum, because they are written a fixed
+// delay after it, so the hourly tasks find them even when the quorum is reached long after the VAA timestamp.
+func MakePointsForObservations(vaa *sdk.VAA, quorumAt time.Time, observedAt map[string]time.Time, missingSigners []string) []*write.Point {
+
+	// Do not generate this metric for PythNet VAAs
+	if vaa.EmitterChain == sdk.ChainIDPythNet {
+		return nil
+	}
+
+	chainID := strconv.Itoa(int(vaa.EmitterChain))
+	// the sequence offset keeps the points of the messages with the same quorum time apart.
+	timestamp := quorumAt.Add(time.Duration(vaa.Sequence % 1_000_000))
+
+	points := make([]*write.Point, 0, len(observedAt)+len(missingSigners))
+	for phylaxAddr, t := range observedAt {
+		delay := t.Sub(vaa.Timestamp).Milliseconds()
+		if delay < 0 {
+			delay = 0
+		}
+		point := influxdb2.
+			NewPointWithMeasurement(PhylaxObservationDelayMeasurement).
+			AddTag("chain_id", chainID).
+			AddTag("phylax_addr", normalizePhylaxAddr(phylaxAddr)).
+			AddField("delay", delay).
+			SetTime(timestamp)
+		points = append(points, point)
+	}
+	for _, phylaxAddr := range missingSigners {
+		point := influxdb2.
+			NewPointWithMeasurement(PhylaxMissedObservationMeasurement).
+			AddTag("chain_id", chainID).
+			AddTag("phylax_addr", normalizePhylaxAddr(phylaxAddr)).
+			AddField("count", 1).
+			SetTime(timestamp)
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// normalizePhylaxAddr converts a phylax address to 40 lowercase hex digits,
+// the format used by the API for the phylax addresses.
+func normalizePhylaxAddr(addr string) string {
+	return strings.ToLower(strings.TrimPrefix(addr, "0x"))
+}
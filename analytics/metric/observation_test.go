@@ -0,0 +1,57 @@
+package metric
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+func field(point *write.Point, key string) interface{} {
+	for _, f := range point.FieldList() {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+func TestMakePointsForObservations(t *testing.T) {
+	vaaTime := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	// the quorum is reached in the next hour.
+	quorumAt := vaaTime.Add(90 * time.Minute)
+	vaa := &sdk.VAA{EmitterChain: sdk.ChainIDEthereum, Sequence: 7, Timestamp: vaaTime}
+	observedAt := map[string]time.Time{
+		"0x58CC3AE5C097B213CE3C81979E1B9F9570746AA5": quorumAt,
+	}
+
+	points := MakePointsForObservations(vaa, quorumAt, observedAt, []string{"0xff6cb952589bde862c25ef4392132fb9d4a42157"})
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(points))
+	}
+	for _, point := range points {
+		if !point.Time().Equal(quorumAt.Add(7 * time.Nanosecond)) {
+			t.Errorf("unexpected %s point time %s", point.Name(), point.Time())
+		}
+	}
+
+	delay := points[0]
+	if delay.Name() != PhylaxObservationDelayMeasurement {
+		t.Fatalf("unexpected measurement %s", delay.Name())
+	}
+	if v := field(delay, "delay"); v != (90 * time.Minute).Milliseconds() {
+		t.Errorf("unexpected delay %v", v)
+	}
+	for _, tag := range delay.TagList() {
+		if tag.Key == "phylax_addr" && tag.Value != "58cc3ae5c097b213ce3c81979e1b9f9570746aa5" {
+			t.Errorf("unexpected phylax address %s", tag.Value)
+		}
+	}
+
+	// the PythNet VAAs are not measured.
+	vaa.EmitterChain = sdk.ChainIDPythNet
+	if points := MakePointsForObservations(vaa, quorumAt, observedAt, nil); len(points) != 0 {
+		t.Errorf("expected no points for pythnet, got %d", len(points))
+	}
+}
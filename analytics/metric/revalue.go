@@ -0,0 +1,230 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/analytics/cmd/token"
+	"github.com/deltaswapio/deltaswap-explorer/analytics/internal/metrics"
+	"github.com/deltaswapio/deltaswap-explorer/common/client/parser"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/common/prices"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// maxTimestampOffset is the maximum offset added by generateUniqueTimestamp to the VAA timestamp.
+const maxTimestampOffset = time.Millisecond
+
+// PointWriter writes points to a bucket, it is implemented by api.WriteAPIBlocking.
+type PointWriter interface {
+	WritePoint(ctx context.Context, point ...*write.Point) error
+}
+
+// DeletePointsFunc deletes the points of a bucket in the [start, stop] range that match the predicate.
+type DeletePointsFunc func(ctx context.Context, start, stop time.Time, predicate string) error
+
+// RunTaskFunc runs a task as if it was scheduled at the given time.
+type RunTaskFunc func(ctx context.Context, taskName string, scheduledFor time.Time) error
+
+// RevalueFilter selects the `vaa_volume_v2` points to recompute.
+type RevalueFilter struct {
+	// Start and Stop define the [Start, Stop) range of the points.
+	Start time.Time
+	Stop  time.Time
+	// TokenChain and TokenAddress select the transfers of a token.
+	// When TokenAddress is nil, the transfers of every token are selected.
+	TokenChain   sdk.ChainID
+	TokenAddress *sdk.Address
+}
+
+// VaaRange returns the time range of the VAAs that generate the points selected by the filter.
+//
+// The point timestamps are up to maxTimestampOffset after the VAA timestamps, so the range starts earlier.
+func (f *RevalueFilter) VaaRange() (time.Time, time.Time) {
+	return f.Start.Add(-maxTimestampOffset), f.Stop
+}
+
+// DeletePredicate returns the influx delete predicate of the points selected by the filter.
+func (f *RevalueFilter) DeletePredicate() string {
+	predicate := fmt.Sprintf(`_measurement="%s"`, VaaVolumeMeasurement)
+	if f.TokenAddress != nil {
+		predicate += fmt.Sprintf(` AND token_chain="%d" AND token_address="%s"`, f.TokenChain, f.TokenAddress.String())
+	}
+	return predicate
+}
+
+func (f *RevalueFilter) matches(point *write.Point, transferredToken *token.TransferredToken) bool {
+	if point.Time().Before(f.Start) || !point.Time().Before(f.Stop) {
+		return false
+	}
+	if f.TokenAddress == nil {
+		return true
+	}
+	return transferredToken.TokenChain == f.TokenChain && transferredToken.TokenAddress == *f.TokenAddress
+}
+
+// volumeTask is a downsampling task that reads the `vaa_volume_v2` measurement.
+type volumeTask struct {
+	name string
+	// window is the time range aggregated by each run, the runs of every window of the revalued range are repeated.
+	// A zero window means the task aggregates up to the execution time, only the current run is repeated.
+	window time.Duration
+}
+
+// volumeTasks are the downsampling tasks defined in the scripts directory that read the `vaa_volume_v2` measurement.
+var volumeTasks = []volumeTask{
+	{name: "asset volume with 24-hour granularity", window: 24 * time.Hour},
+	{name: "total tx for all time every 24-hours"},
+	{name: "chain activity for 7 days with 3-hour granularity"},
+	{name: "chain activity for 15 days with 3-hour granularity"},
+	{name: "chain activity for 30 days with 3-hour granularity"},
+	{name: "chain activity for 90 days with 3-hour granularity"},
+	{name: "chain activity for 1 year with 3-hour granularity"},
+	{name: "chain activity for all time with 3-hour granularity"},
+}
+
+// VolumeRevaluer recomputes the `vaa_volume_v2` points with the historical prices,
+// e.g. when the price of a token was missing or wrong when the points were created.
+//
+// The points have the same timestamps when they are recomputed, so revaluing is idempotent:
+// the selected points are deleted and written again with the new values.
+type VolumeRevaluer struct {
+	writer                   PointWriter
+	deletePoints             DeletePointsFunc
+	runTask                  RunTaskFunc
+	priceSource              prices.PriceSource
+	getTransferredTokenByVaa token.GetTransferredTokenByVaa
+	metrics                  metrics.Metrics
+	logger                   *zap.Logger
+}
+
+// NewVolumeRevaluer creates a new VolumeRevaluer.
+func NewVolumeRevaluer(
+	writer PointWriter,
+	deletePoints DeletePointsFunc,
+	runTask RunTaskFunc,
+	priceSource prices.PriceSource,
+	getTransferredTokenByVaa token.GetTransferredTokenByVaa,
+	logger *zap.Logger,
+) *VolumeRevaluer {
+	return &VolumeRevaluer{
+		writer:                   writer,
+		deletePoints:             deletePoints,
+		runTask:                  runTask,
+		priceSource:              priceSource,
+		getTransferredTokenByVaa: getTransferredTokenByVaa,
+		metrics:                  metrics.NewNoopMetrics(),
+		logger:                   logger,
+	}
+}
+
+// Reset deletes the points selected by the filter, they must be written again with Revalue.
+func (r *VolumeRevaluer) Reset(ctx context.Context, filter *RevalueFilter) error {
+	// the influx delete range is inclusive.
+	return r.deletePoints(ctx, filter.Start, filter.Stop.Add(-time.Nanosecond), filter.DeletePredicate())
+}
+
+// Revalue recomputes the points of the VAAs selected by the filter and writes them.
+// It returns the number of points written.
+func (r *VolumeRevaluer) Revalue(ctx context.Context, filter *RevalueFilter, vaas []*sdk.VAA) (int, error) {
+	points := make([]*write.Point, 0, len(vaas))
+	var unpriced int
+	for _, vaa := range vaas {
+		point, err := r.makePoint(ctx, filter, vaa)
+		if err != nil {
+			return 0, err
+		}
+		if point != nil {
+			points = append(points, point)
+			if isUnpriced(point) {
+				unpriced++
+			}
+		}
+	}
+	if len(points) == 0 {
+		return 0, nil
+	}
+	if err := r.writer.WritePoint(ctx, points...); err != nil {
+		return 0, err
+	}
+	if unpriced > 0 {
+		r.logger.Warn("Revalued points still without price", zap.Int("count", unpriced), zap.Int("total", len(points)))
+	}
+	return len(points), nil
+}
+
+// makePoint recomputes the point of a VAA, it returns nil when the point is not selected by the filter.
+func (r *VolumeRevaluer) makePoint(ctx context.Context, filter *RevalueFilter, vaa *sdk.VAA) (*write.Point, error) {
+	transferredToken, err := r.getTransferredTokenByVaa(ctx, vaa)
+	if err == token.ErrUnknownToken || errors.Is(err, parser.ErrNotFound) || errors.Is(err, parser.ErrUnproceesableEntity) {
+		// the VAA is not a token transfer, it never had a volume point.
+		return nil, nil
+	}
+	// the other errors are not skipped, the point was deleted by Reset and must be written again.
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain transferred token for vaa %s: %w", vaa.MessageID(), err)
+	}
+
+	p := MakePointForVaaVolumeParams{
+		Logger: r.logger,
+		Vaa:    vaa,
+		TokenPriceFunc: func(_ string, timestamp time.Time) (decimal.Decimal, error) {
+			tokenMeta, ok := domain.GetTokenByAddress(transferredToken.TokenChain, transferredToken.TokenAddress.String())
+			if !ok {
+				return decimal.Zero, prices.ErrPriceNotFound
+			}
+			return r.priceSource.GetPriceByTime(ctx, tokenMeta.CoingeckoID, timestamp)
+		},
+		Metrics:          r.metrics,
+		TransferredToken: transferredToken.Clone(),
+	}
+	point, err := MakePointForVaaVolume(&p)
+	if err != nil {
+		return nil, err
+	}
+	if point == nil || !filter.matches(point, transferredToken) {
+		return nil, nil
+	}
+	return point, nil
+}
+
+// RerunTasks runs again the downsampling tasks that read the `vaa_volume_v2` measurement,
+// so the downsampled measurements include the revalued points.
+func (r *VolumeRevaluer) RerunTasks(ctx context.Context, filter *RevalueFilter) error {
+	now := time.Now()
+	for _, task := range volumeTasks {
+		for _, scheduledFor := range taskSchedules(task, filter, now) {
+			r.logger.Info("running task",
+				zap.String("task", task.name),
+				zap.Time("scheduledFor", scheduledFor),
+			)
+			if err := r.runTask(ctx, task.name, scheduledFor); err != nil {
+				return fmt.Errorf("failed to run task %s: %w", task.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// taskSchedules returns the execution times of the runs of a task that aggregate the points selected by the filter.
+//
+// A run of a windowed task executed at t aggregates the points of the [t - window, t) range,
+// the runs that would execute after now are skipped because the task will run on schedule.
+func taskSchedules(task volumeTask, filter *RevalueFilter, now time.Time) []time.Time {
+	if task.window == 0 {
+		return []time.Time{now}
+	}
+	var schedules []time.Time
+	for t := filter.Start.Truncate(task.window).Add(task.window); !t.After(now); t = t.Add(task.window) {
+		schedules = append(schedules, t)
+		if !t.Before(filter.Stop) {
+			break
+		}
+	}
+	return schedules
+}
@@ -0,0 +1,154 @@
+package metric
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/analytics/cmd/token"
+	"github.com/deltaswapio/deltaswap-explorer/common/prices"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// wrapped SOL, its metadata is in the mainnet token list.
+const solAddress = "069b8857feab8184fb687f634618c035dac439dc1aeb3b5598a0f00000000001"
+
+type memoryWriter struct {
+	points []*write.Point
+}
+
+func (w *memoryWriter) WritePoint(_ context.Context, points ...*write.Point) error {
+	w.points = append(w.points, points...)
+	return nil
+}
+
+type fixedPriceSource struct {
+	price decimal.Decimal
+}
+
+func (s *fixedPriceSource) GetPrices(_ context.Context, ids []string) (map[string]decimal.Decimal, error) {
+	result := make(map[string]decimal.Decimal, len(ids))
+	for _, id := range ids {
+		result[id] = s.price
+	}
+	return result, nil
+}
+
+func (s *fixedPriceSource) GetPriceByTime(_ context.Context, _ string, _ time.Time) (decimal.Decimal, error) {
+	return s.price, nil
+}
+
+func TestVolumeRevaluer_Revalue(t *testing.T) {
+	sol, err := sdk.StringToAddress(solAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := sdk.StringToAddress("000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	stop := start.Add(24 * time.Hour)
+	vaas := []*sdk.VAA{
+		{EmitterChain: sdk.ChainIDSolana, Sequence: 1, Timestamp: start.Add(time.Hour)},
+		{EmitterChain: sdk.ChainIDSolana, Sequence: 2, Timestamp: start.Add(2 * time.Hour)},
+		// outside the range, the point is not written.
+		{EmitterChain: sdk.ChainIDSolana, Sequence: 3, Timestamp: stop},
+	}
+	tokens := map[uint64]*token.TransferredToken{
+		1: {AppId: "PORTAL_TOKEN_BRIDGE", TokenChain: sdk.ChainIDSolana, TokenAddress: sol, ToChain: sdk.ChainIDEthereum, Amount: big.NewInt(100_000_000)},
+		2: {AppId: "PORTAL_TOKEN_BRIDGE", TokenChain: sdk.ChainIDEthereum, TokenAddress: other, ToChain: sdk.ChainIDSolana, Amount: big.NewInt(100_000_000)},
+		3: {AppId: "PORTAL_TOKEN_BRIDGE", TokenChain: sdk.ChainIDSolana, TokenAddress: sol, ToChain: sdk.ChainIDEthereum, Amount: big.NewInt(100_000_000)},
+	}
+	getTransferredToken := func(_ context.Context, vaa *sdk.VAA) (*token.TransferredToken, error) {
+		return tokens[vaa.Sequence].Clone(), nil
+	}
+
+	var deletedStart, deletedStop time.Time
+	var deletedPredicate string
+	deletePoints := func(_ context.Context, start, stop time.Time, predicate string) error {
+		deletedStart, deletedStop, deletedPredicate = start, stop, predicate
+		return nil
+	}
+
+	writer := &memoryWriter{}
+	var priceSource prices.PriceSource = &fixedPriceSource{price: decimal.NewFromInt(20)}
+	revaluer := NewVolumeRevaluer(writer, deletePoints, nil, priceSource, getTransferredToken, zap.NewNop())
+
+	filter := &RevalueFilter{Start: start, Stop: stop, TokenChain: sdk.ChainIDSolana, TokenAddress: &sol}
+	if err := revaluer.Reset(context.Background(), filter); err != nil {
+		t.Fatal(err)
+	}
+	if !deletedStart.Equal(start) || !deletedStop.Equal(stop.Add(-time.Nanosecond)) {
+		t.Errorf("unexpected delete range [%s, %s]", deletedStart, deletedStop)
+	}
+	expectedPredicate := `_measurement="vaa_volume_v2" AND token_chain="1" AND token_address="` + solAddress + `"`
+	if deletedPredicate != expectedPredicate {
+		t.Errorf("unexpected delete predicate %s", deletedPredicate)
+	}
+
+	count, err := revaluer.Revalue(context.Background(), filter, vaas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || len(writer.points) != 1 {
+		t.Fatalf("expected 1 point, got %d", len(writer.points))
+	}
+	point := writer.points[0]
+	if !point.Time().Equal(generateUniqueTimestamp(vaas[0])) {
+		t.Errorf("unexpected point time %s", point.Time())
+	}
+	if field(point, "priced") != true || isUnpriced(point) {
+		t.Errorf("expected a priced point")
+	}
+	if v := field(point, "volume"); v != uint64(2_000_000_000) {
+		t.Errorf("unexpected volume %v", v)
+	}
+
+	// revaluing again writes the same point.
+	if _, err := revaluer.Revalue(context.Background(), filter, vaas); err != nil {
+		t.Fatal(err)
+	}
+	if len(writer.points) != 2 || !writer.points[1].Time().Equal(point.Time()) {
+		t.Errorf("expected the same point to be written again")
+	}
+}
+
+func TestTaskSchedules(t *testing.T) {
+	filter := &RevalueFilter{
+		Start: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+		Stop:  time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	schedules := taskSchedules(volumeTask{name: "daily", window: 24 * time.Hour}, filter, now)
+	expected := []time.Time{
+		time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	if len(schedules) != len(expected) {
+		t.Fatalf("expected %d schedules, got %v", len(expected), schedules)
+	}
+	for i := range expected {
+		if !schedules[i].Equal(expected[i]) {
+			t.Errorf("expected schedule %s, got %s", expected[i], schedules[i])
+		}
+	}
+
+	// the tasks without window are only run now.
+	schedules = taskSchedules(volumeTask{name: "all time"}, filter, now)
+	if len(schedules) != 1 || !schedules[0].Equal(now) {
+		t.Errorf("unexpected schedules %v", schedules)
+	}
+
+	// the runs after now are skipped.
+	schedules = taskSchedules(volumeTask{name: "daily", window: 24 * time.Hour}, filter, time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC))
+	if len(schedules) != 1 {
+		t.Errorf("unexpected schedules %v", schedules)
+	}
+}
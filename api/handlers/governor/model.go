@@ -205,3 +205,70 @@ type EnqueuedVaaItem struct {
 	NotionalValue  mongo.Uint64 `bson:"notionalvalue" json:"notionalValue"`
 	TxHash         string       `bson:"txhash" json:"txHash"`
 }
+
+// Governor event types, recorded by fly when the enqueued VAAs of a phylax change.
+const (
+	GovernorEventEnqueued = "enqueued"
+	GovernorEventReleased = "released"
+	GovernorEventDropped  = "dropped"
+)
+
+// GovernorEvent is a change of the VAAs enqueued by the governor of a phylax.
+type GovernorEvent struct {
+	PhylaxAddr    string       `bson:"phylaxAddr" json:"phylaxAddress"`
+	NodeName      string       `bson:"nodeName" json:"nodeName"`
+	Type          string       `bson:"type" json:"type"`
+	ReleaseTime   int64        `bson:"releaseTime" json:"releaseTime"`
+	NotionalValue mongo.Uint64 `bson:"notionalValue" json:"notionalValue"`
+	TxHash        string       `bson:"txHash" json:"txHash"`
+	Timestamp     time.Time    `bson:"timestamp" json:"timestamp"`
+}
+
+// GovernorVaaTimeline contains the governor history of a VAA.
+type GovernorVaaTimeline struct {
+	VaaID      string     `json:"vaaId"`
+	Status     string     `json:"status"`
+	EnqueuedAt *time.Time `json:"enqueuedAt"`
+	ReleasedAt *time.Time `json:"releasedAt,omitempty"`
+	DroppedAt  *time.Time `json:"droppedAt,omitempty"`
+	// ProjectedReleaseTime is only set while the VAA is enqueued.
+	ProjectedReleaseTime *time.Time       `json:"projectedReleaseTime,omitempty"`
+	Events               []*GovernorEvent `json:"events"`
+}
+
+// EnqueuedVaaProjection is a VAA currently enqueued with its projected release time.
+type EnqueuedVaaProjection struct {
+	EmitterChain         vaa.ChainID  `json:"emitterChain"`
+	EmitterAddress       string       `json:"emitterAddress"`
+	Sequence             string       `json:"sequence"`
+	NotionalValue        mongo.Uint64 `json:"notionalValue"`
+	TxHash               string       `json:"txHash"`
+	EnqueuedBy           int          `json:"enqueuedBy"`
+	ProjectedReleaseTime time.Time    `json:"projectedReleaseTime"`
+}
+
+// EnqueuedVaaRelease is a VAA enqueued by the governor of a phylax with its release time.
+type EnqueuedVaaRelease struct {
+	PhylaxAddr     string       `bson:"_id"`
+	EmitterChain   vaa.ChainID  `bson:"chainid"`
+	EmitterAddress string       `bson:"emitteraddress"`
+	Sequence       string       `bson:"sequence"`
+	ReleaseTime    int64        `bson:"releasetime"`
+	NotionalValue  mongo.Uint64 `bson:"notionalvalue"`
+	TxHash         string       `bson:"txhash"`
+}
+
+// NotionalHistoryPoint is the remaining available notional of a chain at a point in time.
+type NotionalHistoryPoint struct {
+	Timestamp         time.Time `json:"timestamp"`
+	AvailableNotional uint64    `json:"availableNotional"`
+}
+
+// NotionalChange is the last remaining available notional reported by a phylax in an hour.
+type NotionalChange struct {
+	ID struct {
+		PhylaxAddr string    `bson:"phylaxAddr"`
+		Hour       time.Time `bson:"hour"`
+	} `bson:"_id"`
+	Notional mongo.Uint64 `bson:"notional"`
+}
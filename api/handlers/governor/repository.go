@@ -25,8 +25,10 @@ type Repository struct {
 	db          *mongo.Database
 	logger      *zap.Logger
 	collections struct {
-		governorConfig *mongo.Collection
-		governorStatus *mongo.Collection
+		governorConfig          *mongo.Collection
+		governorStatus          *mongo.Collection
+		governorEvents          *mongo.Collection
+		governorNotionalHistory *mongo.Collection
 	}
 }
 
@@ -35,11 +37,15 @@ func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
 		logger: logger.With(zap.String("module", "GovernorRepository")),
 		collections: struct {
-			governorConfig *mongo.Collection
-			governorStatus *mongo.Collection
+			governorConfig          *mongo.Collection
+			governorStatus          *mongo.Collection
+			governorEvents          *mongo.Collection
+			governorNotionalHistory *mongo.Collection
 		}{
-			governorConfig: db.Collection("governorConfig"),
-			governorStatus: db.Collection("governorStatus"),
+			governorConfig:          db.Collection("governorConfig"),
+			governorStatus:          db.Collection("governorStatus"),
+			governorEvents:          db.Collection("governorEvents"),
+			governorNotionalHistory: db.Collection("governorNotionalHistory"),
 		},
 	}
 }
@@ -1725,3 +1731,151 @@ func (r *Repository) IsVaaEnqueued(
 
 	return true, nil
 }
+
+// FindGovernorEvents get the governor events of a VAA sorted by timestamp.
+func (r *Repository) FindGovernorEvents(ctx context.Context, vaaID string) ([]*GovernorEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}})
+	cur, err := r.collections.governorEvents.Find(ctx, bson.M{"vaaId": vaaID}, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Find command to get governor events",
+			zap.Error(err), zap.String("vaaId", vaaID), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+
+	var events []*GovernorEvent
+	err = cur.All(ctx, &events)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []*GovernorEvent",
+			zap.Error(err), zap.String("vaaId", vaaID), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return events, nil
+}
+
+// FindEnqueuedVaaReleases get the VAAs enqueued by each phylax with their release time.
+// When emitter is not nil, only the VAAs of the chain, emitter and sequence are returned.
+func (r *Repository) FindEnqueuedVaaReleases(
+	ctx context.Context,
+	chainID vaa.ChainID,
+	emitter *types.Address,
+	sequence string,
+) ([]*EnqueuedVaaRelease, error) {
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$parsedStatus.chains"}},
+		{{Key: "$unwind", Value: "$parsedStatus.chains.emitters"}},
+		{{Key: "$unwind", Value: "$parsedStatus.chains.emitters.enqueuedvaas"}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "chainid", Value: "$parsedStatus.chains.chainid"},
+			{Key: "emitteraddress", Value: "$parsedStatus.chains.emitters.emitteraddress"},
+			{Key: "sequence", Value: "$parsedStatus.chains.emitters.enqueuedvaas.sequence"},
+			{Key: "releasetime", Value: "$parsedStatus.chains.emitters.enqueuedvaas.releasetime"},
+			{Key: "notionalvalue", Value: "$parsedStatus.chains.emitters.enqueuedvaas.notionalvalue"},
+			{Key: "txhash", Value: "$parsedStatus.chains.emitters.enqueuedvaas.txhash"},
+		}}},
+	}
+	if emitter != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{
+			{Key: "chainid", Value: chainID},
+			{Key: "emitteraddress", Value: fmt.Sprintf("0x%s", emitter.Hex())},
+			{Key: "sequence", Value: sequence},
+		}}})
+	}
+
+	cur, err := r.collections.governorStatus.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to execute Aggregate command to get enqueued vaa releases",
+			zap.Error(err),
+			zap.String("requestID", requestID),
+		)
+		return nil, errors.WithStack(err)
+	}
+
+	var releases []*EnqueuedVaaRelease
+	err = cur.All(ctx, &releases)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode cursor into []*EnqueuedVaaRelease",
+			zap.Error(err),
+			zap.String("requestID", requestID),
+		)
+		return nil, errors.WithStack(err)
+	}
+	return releases, nil
+}
+
+// CountGovernorStatus get the number of phylaxs that reported their governor status.
+func (r *Repository) CountGovernorStatus(ctx context.Context) (int, error) {
+	n, err := r.collections.governorStatus.CountDocuments(ctx, bson.D{})
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to count governor status",
+			zap.Error(err),
+			zap.String("requestID", requestID),
+		)
+		return 0, errors.WithStack(err)
+	}
+	return int(n), nil
+}
+
+// FindNotionalChanges get the last remaining available notional of a chain reported by each phylax
+// for each hour between from and to. The last value reported before from is returned in the hour from.
+func (r *Repository) FindNotionalChanges(ctx context.Context, chainID vaa.ChainID, from, to time.Time) ([]*NotionalChange, error) {
+
+	initial := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "chainId", Value: chainID},
+			{Key: "timestamp", Value: bson.M{"$lt": from}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "phylaxAddr", Value: "$phylaxAddr"},
+				{Key: "hour", Value: from},
+			}},
+			{Key: "notional", Value: bson.M{"$last": "$remainingAvailableNotional"}},
+		}}},
+	}
+
+	hourly := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "chainId", Value: chainID},
+			{Key: "timestamp", Value: bson.M{"$gte": from, "$lt": to}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "phylaxAddr", Value: "$phylaxAddr"},
+				{Key: "hour", Value: bson.M{"$dateTrunc": bson.M{"date": "$timestamp", "unit": "hour"}}},
+			}},
+			{Key: "notional", Value: bson.M{"$last": "$remainingAvailableNotional"}},
+		}}},
+	}
+
+	var changes []*NotionalChange
+	for _, pipeline := range []mongo.Pipeline{initial, hourly} {
+		cur, err := r.collections.governorNotionalHistory.Aggregate(ctx, pipeline)
+		if err != nil {
+			requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+			r.logger.Error("failed to execute Aggregate command to get notional history",
+				zap.Error(err),
+				zap.String("requestID", requestID),
+			)
+			return nil, errors.WithStack(err)
+		}
+		var rows []*NotionalChange
+		if err := cur.All(ctx, &rows); err != nil {
+			requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+			r.logger.Error("failed to decode cursor into []*NotionalChange",
+				zap.Error(err),
+				zap.String("requestID", requestID),
+			)
+			return nil, errors.WithStack(err)
+		}
+		changes = append(changes, rows...)
+	}
+	return changes, nil
+}
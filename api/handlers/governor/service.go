@@ -4,6 +4,8 @@ package governor
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
 	"github.com/deltaswapio/deltaswap-explorer/api/internal/pagination"
@@ -208,3 +210,201 @@ func (s *Service) IsVaaEnqueued(ctx context.Context, chainID vaa.ChainID, emitte
 	isEnqueued, err := s.repo.IsVaaEnqueued(ctx, chainID, emitter, seq)
 	return isEnqueued, err
 }
+
+// GetVaaTimeline get the governor history of a VAA.
+// The projected release time is only set when the VAA is still enqueued.
+func (s *Service) GetVaaTimeline(ctx context.Context, chainID vaa.ChainID, emitter *types.Address, seq string) (*GovernorVaaTimeline, error) {
+	vaaID := fmt.Sprintf("%d/%s/%s", chainID, emitter.Hex(), seq)
+	events, err := s.repo.FindGovernorEvents(ctx, vaaID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	timeline := buildVaaTimeline(vaaID, events)
+	if timeline.Status != GovernorEventEnqueued {
+		return timeline, nil
+	}
+
+	releases, err := s.repo.FindEnqueuedVaaReleases(ctx, chainID, emitter, seq)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repo.CountGovernorStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(releases) > 0 {
+		releaseTimes := make([]int64, 0, len(releases))
+		for _, r := range releases {
+			releaseTimes = append(releaseTimes, r.ReleaseTime)
+		}
+		projected := projectReleaseTime(releaseTimes, total)
+		timeline.ProjectedReleaseTime = &projected
+	}
+	return timeline, nil
+}
+
+// GetReleaseProjections get the VAAs currently enqueued with their projected release time, sorted by it.
+func (s *Service) GetReleaseProjections(ctx context.Context) ([]*EnqueuedVaaProjection, error) {
+	releases, err := s.repo.FindEnqueuedVaaReleases(ctx, 0, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repo.CountGovernorStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type vaaReleases struct {
+		vaa          *EnqueuedVaaRelease
+		releaseTimes []int64
+	}
+	byVaa := make(map[string]*vaaReleases)
+	for _, r := range releases {
+		key := fmt.Sprintf("%d/%s/%s", r.EmitterChain, r.EmitterAddress, r.Sequence)
+		v, ok := byVaa[key]
+		if !ok {
+			v = &vaaReleases{vaa: r}
+			byVaa[key] = v
+		}
+		v.releaseTimes = append(v.releaseTimes, r.ReleaseTime)
+	}
+
+	projections := make([]*EnqueuedVaaProjection, 0, len(byVaa))
+	for _, v := range byVaa {
+		projections = append(projections, &EnqueuedVaaProjection{
+			EmitterChain:         v.vaa.EmitterChain,
+			EmitterAddress:       v.vaa.EmitterAddress,
+			Sequence:             v.vaa.Sequence,
+			NotionalValue:        v.vaa.NotionalValue,
+			TxHash:               v.vaa.TxHash,
+			EnqueuedBy:           len(v.releaseTimes),
+			ProjectedReleaseTime: projectReleaseTime(v.releaseTimes, total),
+		})
+	}
+	sort.Slice(projections, func(i, j int) bool {
+		return projections[i].ProjectedReleaseTime.Before(projections[j].ProjectedReleaseTime)
+	})
+	return projections, nil
+}
+
+// GetNotionalHistory get the hourly available notional of a chain between from and to.
+func (s *Service) GetNotionalHistory(ctx context.Context, chainID vaa.ChainID, from, to time.Time) ([]*NotionalHistoryPoint, error) {
+	from = from.Truncate(time.Hour)
+	changes, err := s.repo.FindNotionalChanges(ctx, chainID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return buildNotionalHistory(changes, from, to), nil
+}
+
+// buildVaaTimeline summarizes the governor events of a VAA.
+//
+// The VAA is released when a quorum of phylaxs released it, and it is enqueued while
+// any phylax keeps it enqueued. Otherwise, it was dropped.
+func buildVaaTimeline(vaaID string, events []*GovernorEvent) *GovernorVaaTimeline {
+	timeline := &GovernorVaaTimeline{VaaID: vaaID, Events: events}
+
+	lastByPhylax := make(map[string]string)
+	var released []time.Time
+	var droppedAt *time.Time
+	for _, e := range events {
+		e := e
+		lastByPhylax[e.PhylaxAddr] = e.Type
+		switch e.Type {
+		case GovernorEventEnqueued:
+			if timeline.EnqueuedAt == nil {
+				timeline.EnqueuedAt = &e.Timestamp
+			}
+		case GovernorEventReleased:
+			released = append(released, e.Timestamp)
+		case GovernorEventDropped:
+			droppedAt = &e.Timestamp
+		}
+	}
+
+	quorum := minPhylaxNum
+	if len(lastByPhylax) < quorum {
+		quorum = len(lastByPhylax)
+	}
+	enqueued := false
+	for _, t := range lastByPhylax {
+		if t == GovernorEventEnqueued {
+			enqueued = true
+		}
+	}
+
+	switch {
+	case len(released) >= quorum:
+		timeline.Status = GovernorEventReleased
+		timeline.ReleasedAt = &released[quorum-1]
+	case enqueued:
+		timeline.Status = GovernorEventEnqueued
+	case len(released) > 0:
+		timeline.Status = GovernorEventReleased
+		timeline.ReleasedAt = &released[len(released)-1]
+	default:
+		timeline.Status = GovernorEventDropped
+		timeline.DroppedAt = droppedAt
+	}
+	return timeline
+}
+
+// projectReleaseTime returns when a quorum of phylaxs will have released a VAA.
+//
+// releaseTimes are the release times of the phylaxs that keep the VAA enqueued, and total is
+// the number of phylaxs that report their governor status. The other phylaxs already released it.
+func projectReleaseTime(releaseTimes []int64, total int) time.Time {
+	quorum := minPhylaxNum
+	if total < quorum {
+		quorum = total
+	}
+	needed := quorum - (total - len(releaseTimes))
+	if needed < 1 {
+		needed = 1
+	}
+	if needed > len(releaseTimes) {
+		needed = len(releaseTimes)
+	}
+
+	sorted := append([]int64(nil), releaseTimes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return time.Unix(sorted[needed-1], 0).UTC()
+}
+
+// buildNotionalHistory returns the available notional for each hour between from and to.
+// Each phylax keeps its last reported value until it reports a new one, and the available notional
+// of an hour is the value reached by a quorum of phylaxs, as in GetAvailableNotional.
+func buildNotionalHistory(changes []*NotionalChange, from, to time.Time) []*NotionalHistoryPoint {
+	byHour := make(map[time.Time][]*NotionalChange)
+	for _, c := range changes {
+		h := c.ID.Hour.UTC()
+		byHour[h] = append(byHour[h], c)
+	}
+
+	points := make([]*NotionalHistoryPoint, 0)
+	current := make(map[string]uint64)
+	for h := from.UTC(); h.Before(to); h = h.Add(time.Hour) {
+		for _, c := range byHour[h] {
+			current[c.ID.PhylaxAddr] = uint64(c.Notional)
+		}
+		if len(current) == 0 {
+			continue
+		}
+
+		values := make([]uint64, 0, len(current))
+		for _, v := range current {
+			values = append(values, v)
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i] > values[j] })
+		idx := minPhylaxNum - 1
+		if idx >= len(values) {
+			idx = len(values) - 1
+		}
+		points = append(points, &NotionalHistoryPoint{Timestamp: h, AvailableNotional: values[idx]})
+	}
+	return points
+}
@@ -0,0 +1,94 @@
+package governor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/api/internal/mongo"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGovernorEvents(eventType string, t time.Time, phylaxs int) []*GovernorEvent {
+	events := make([]*GovernorEvent, 0, phylaxs)
+	for i := 0; i < phylaxs; i++ {
+		events = append(events, &GovernorEvent{
+			PhylaxAddr: fmt.Sprintf("phylax-%d", i),
+			Type:       eventType,
+			Timestamp:  t.Add(time.Duration(i) * time.Second),
+		})
+	}
+	return events
+}
+
+func TestBuildVaaTimeline(t *testing.T) {
+	t0 := time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(24 * time.Hour)
+
+	t.Run("enqueued", func(t *testing.T) {
+		events := newGovernorEvents(GovernorEventEnqueued, t0, 19)
+		events = append(events, newGovernorEvents(GovernorEventReleased, t1, 5)...)
+		timeline := buildVaaTimeline("id", events)
+		assert.Equal(t, GovernorEventEnqueued, timeline.Status)
+		assert.Equal(t, t0, *timeline.EnqueuedAt)
+		assert.Nil(t, timeline.ReleasedAt)
+	})
+
+	t.Run("released by a quorum", func(t *testing.T) {
+		events := newGovernorEvents(GovernorEventEnqueued, t0, 19)
+		events = append(events, newGovernorEvents(GovernorEventReleased, t1, 13)...)
+		timeline := buildVaaTimeline("id", events)
+		assert.Equal(t, GovernorEventReleased, timeline.Status)
+		assert.Equal(t, t1.Add(12*time.Second), *timeline.ReleasedAt)
+	})
+
+	t.Run("dropped", func(t *testing.T) {
+		events := newGovernorEvents(GovernorEventEnqueued, t0, 3)
+		events = append(events, newGovernorEvents(GovernorEventDropped, t1, 3)...)
+		timeline := buildVaaTimeline("id", events)
+		assert.Equal(t, GovernorEventDropped, timeline.Status)
+		assert.Equal(t, t1.Add(2*time.Second), *timeline.DroppedAt)
+	})
+}
+
+func TestProjectReleaseTime(t *testing.T) {
+	releaseTimes := make([]int64, 0, 19)
+	for i := int64(19); i > 0; i-- {
+		releaseTimes = append(releaseTimes, 1000+i)
+	}
+
+	// every phylax keeps the VAA enqueued, so the 13th release time is needed.
+	assert.Equal(t, time.Unix(1013, 0).UTC(), projectReleaseTime(releaseTimes, 19))
+
+	// 4 phylaxs already released the VAA, so 9 more releases are needed.
+	assert.Equal(t, time.Unix(1009, 0).UTC(), projectReleaseTime(releaseTimes[4:], 19))
+
+	// a quorum already released the VAA.
+	assert.Equal(t, time.Unix(1001, 0).UTC(), projectReleaseTime(releaseTimes[15:], 19))
+}
+
+func newNotionalChange(phylaxAddr string, hour time.Time, notional uint64) *NotionalChange {
+	c := &NotionalChange{Notional: mongo.Uint64(notional)}
+	c.ID.PhylaxAddr = phylaxAddr
+	c.ID.Hour = hour
+	return c
+}
+
+func TestBuildNotionalHistory(t *testing.T) {
+	from := time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+
+	var changes []*NotionalChange
+	for i := 0; i < 13; i++ {
+		changes = append(changes, newNotionalChange(fmt.Sprintf("phylax-%d", i), from, uint64(100+i)))
+	}
+	// a phylax reports a lower value in the second hour, the others keep their values.
+	changes = append(changes, newNotionalChange("phylax-12", from.Add(time.Hour), 50))
+
+	history := buildNotionalHistory(changes, from, to)
+	assert.Equal(t, []*NotionalHistoryPoint{
+		{Timestamp: from, AvailableNotional: 100},
+		{Timestamp: from.Add(time.Hour), AvailableNotional: 50},
+		{Timestamp: from.Add(2 * time.Hour), AvailableNotional: 50},
+	}, history)
+}
@@ -1,6 +1,11 @@
 package heartbeats
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
 
 // HeartbeatDoc represent an heartbeat document.
 type HeartbeatDoc struct {
@@ -24,3 +29,144 @@ type HeartbeatNetwork struct {
 	ContractAddress string `bson:"contractaddress" json:"contractAddress"`
 	ErrorCount      int64  `bson:"errorcount" json:"errorCount"`
 }
+
+// HistoryTimeSpan is the window of the heartbeat history queries.
+type HistoryTimeSpan string
+
+const (
+	HistoryTimeSpan1Day   HistoryTimeSpan = "1d"
+	HistoryTimeSpan7Days  HistoryTimeSpan = "7d"
+	HistoryTimeSpan30Days HistoryTimeSpan = "30d"
+)
+
+// ParseHistoryTimeSpan parses a string and returns a HistoryTimeSpan.
+func ParseHistoryTimeSpan(s string) (HistoryTimeSpan, error) {
+	switch t := HistoryTimeSpan(s); t {
+	case HistoryTimeSpan1Day, HistoryTimeSpan7Days, HistoryTimeSpan30Days:
+		return t, nil
+	default:
+		return "", fmt.Errorf("invalid time span: %s", s)
+	}
+}
+
+// Duration returns the length of the time span.
+func (t HistoryTimeSpan) Duration() time.Duration {
+	switch t {
+	case HistoryTimeSpan7Days:
+		return 7 * 24 * time.Hour
+	case HistoryTimeSpan30Days:
+		return 30 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// lagInterval returns the interval used to compare the block heights of the phylaxs.
+// Longer time spans use longer intervals to bound the number of rows returned by the database.
+func (t HistoryTimeSpan) lagInterval() Interval {
+	switch t {
+	case HistoryTimeSpan7Days:
+		return Interval{unit: "hour", binSize: 1}
+	case HistoryTimeSpan30Days:
+		return Interval{unit: "hour", binSize: 6}
+	default:
+		return Interval{unit: "minute", binSize: 15}
+	}
+}
+
+// Interval is a bucket of the heartbeat history, expressed as $dateTrunc arguments.
+type Interval struct {
+	unit    string
+	binSize int
+}
+
+// Duration returns the length of the interval.
+func (i Interval) Duration() time.Duration {
+	if i.unit == "hour" {
+		return time.Duration(i.binSize) * time.Hour
+	}
+	return time.Duration(i.binSize) * time.Minute
+}
+
+// uptimeInterval is the interval used to compute the uptime. A phylax is up during
+// an interval if at least one of its heartbeats was sampled in the interval.
+var uptimeInterval = Interval{unit: "minute", binSize: 5}
+
+// PhylaxUptime definition.
+type PhylaxUptime struct {
+	PhylaxAddr string  `json:"phylaxAddress"`
+	NodeName   string  `json:"nodeName"`
+	Uptime     float64 `json:"uptime"`
+}
+
+// PhylaxBoot is a boot of a phylax node, found by a change of the boot timestamp of its heartbeats.
+type PhylaxBoot struct {
+	BootTime    time.Time `bson:"-" json:"bootTime"`
+	Version     string    `bson:"version" json:"version"`
+	FirstSeenAt time.Time `bson:"firstSeenAt" json:"firstSeenAt"`
+	LastSeenAt  time.Time `bson:"lastSeenAt" json:"lastSeenAt"`
+}
+
+// VersionRollout contains the phylaxs that ran a version.
+type VersionRollout struct {
+	Version     string           `json:"version"`
+	FirstSeenAt time.Time        `json:"firstSeenAt"`
+	Phylaxs     []*PhylaxVersion `json:"phylaxs"`
+}
+
+// PhylaxVersion contains when a phylax ran a version.
+type PhylaxVersion struct {
+	PhylaxAddr  string    `json:"phylaxAddress"`
+	NodeName    string    `json:"nodeName"`
+	FirstSeenAt time.Time `json:"firstSeenAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+}
+
+// PhylaxHeightLag contains the block height lag of a phylax for each chain.
+type PhylaxHeightLag struct {
+	PhylaxAddr string            `json:"phylaxAddress"`
+	NodeName   string            `json:"nodeName"`
+	Chains     []*ChainHeightLag `json:"chains"`
+}
+
+// ChainHeightLag is the number of blocks a phylax is behind the median height reported by the network.
+type ChainHeightLag struct {
+	ChainID sdk.ChainID `json:"chainId"`
+	AvgLag  float64     `json:"avgLag"`
+	MaxLag  int64       `json:"maxLag"`
+}
+
+// UptimeDoc is the number of intervals with at least one heartbeat sampled for a phylax.
+type UptimeDoc struct {
+	PhylaxAddr string `bson:"_id"`
+	NodeName   string `bson:"nodeName"`
+	Intervals  int64  `bson:"intervals"`
+}
+
+// BootDoc is a boot of a phylax node as returned by the database.
+type BootDoc struct {
+	BootTimestamp int64 `bson:"_id"`
+	PhylaxBoot    `bson:",inline"`
+}
+
+// VersionDoc contains when a phylax ran a version as returned by the database.
+type VersionDoc struct {
+	ID struct {
+		PhylaxAddr string `bson:"phylaxAddr"`
+		Version    string `bson:"version"`
+	} `bson:"_id"`
+	NodeName    string    `bson:"nodeName"`
+	FirstSeenAt time.Time `bson:"firstSeenAt"`
+	LastSeenAt  time.Time `bson:"lastSeenAt"`
+}
+
+// HeightDoc is the highest block height of a chain reported by a phylax in an interval.
+type HeightDoc struct {
+	ID struct {
+		Interval   time.Time   `bson:"interval"`
+		ChainID    sdk.ChainID `bson:"chainId"`
+		PhylaxAddr string      `bson:"phylaxAddr"`
+	} `bson:"_id"`
+	NodeName string `bson:"nodeName"`
+	Height   int64  `bson:"height"`
+}
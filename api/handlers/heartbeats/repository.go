@@ -3,6 +3,7 @@ package heartbeats
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
@@ -15,15 +16,22 @@ type Repository struct {
 	db          *mongo.Database
 	logger      *zap.Logger
 	collections struct {
-		heartbeats *mongo.Collection
+		heartbeats       *mongo.Collection
+		heartbeatHistory *mongo.Collection
 	}
 }
 
 // NewRepository create a new Repository.
 func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
-		logger:      logger.With(zap.String("module", "HeartbeatsRepository")),
-		collections: struct{ heartbeats *mongo.Collection }{heartbeats: db.Collection("heartbeats")},
+		logger: logger.With(zap.String("module", "HeartbeatsRepository")),
+		collections: struct {
+			heartbeats       *mongo.Collection
+			heartbeatHistory *mongo.Collection
+		}{
+			heartbeats:       db.Collection("heartbeats"),
+			heartbeatHistory: db.Collection("heartbeatHistory"),
+		},
 	}
 }
 
@@ -47,3 +55,114 @@ func (r *Repository) FindByIDs(ctx context.Context, ids []string) ([]*HeartbeatD
 	}
 	return heartbeats, err
 }
+
+// dateTrunc returns the expression that truncates the timestamp of a heartbeat sample to an interval.
+func dateTrunc(i Interval) bson.M {
+	return bson.M{"$dateTrunc": bson.M{"date": "$timestamp", "unit": i.unit, "binSize": i.binSize}}
+}
+
+// CountUptimeIntervals counts, for each phylax, the intervals since a time with at least one heartbeat sampled.
+func (r *Repository) CountUptimeIntervals(ctx context.Context, from time.Time, i Interval) ([]*UptimeDoc, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"timestamp": bson.M{"$gte": from}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      bson.M{"phylaxAddr": "$phylaxAddr", "interval": dateTrunc(i)},
+			"nodeName": bson.M{"$last": "$nodeName"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       "$_id.phylaxAddr",
+			"nodeName":  bson.M{"$last": "$nodeName"},
+			"intervals": bson.M{"$sum": 1},
+		}}},
+	}
+
+	var docs []*UptimeDoc
+	if err := r.aggregateHistory(ctx, pipeline, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// FindBoots get the boots of a phylax node since a time, sorted by the first heartbeat sampled.
+func (r *Repository) FindBoots(ctx context.Context, phylaxAddr string, from time.Time) ([]*BootDoc, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"phylaxAddr": phylaxAddr, "timestamp": bson.M{"$gte": from}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$bootTimestamp",
+			"version":     bson.M{"$first": "$version"},
+			"firstSeenAt": bson.M{"$first": "$timestamp"},
+			"lastSeenAt":  bson.M{"$last": "$timestamp"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "firstSeenAt", Value: 1}}}},
+	}
+
+	var docs []*BootDoc
+	if err := r.aggregateHistory(ctx, pipeline, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// FindVersions get the versions run by each phylax since a time, sorted by the first heartbeat sampled.
+func (r *Repository) FindVersions(ctx context.Context, from time.Time) ([]*VersionDoc, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"timestamp": bson.M{"$gte": from}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         bson.M{"phylaxAddr": "$phylaxAddr", "version": "$version"},
+			"nodeName":    bson.M{"$last": "$nodeName"},
+			"firstSeenAt": bson.M{"$first": "$timestamp"},
+			"lastSeenAt":  bson.M{"$last": "$timestamp"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "firstSeenAt", Value: 1}}}},
+	}
+
+	var docs []*VersionDoc
+	if err := r.aggregateHistory(ctx, pipeline, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// FindHeights get the highest block height reported by each phylax for each chain and interval since a time.
+// The chains with no height reported (zero) are skipped.
+func (r *Repository) FindHeights(ctx context.Context, from time.Time, i Interval) ([]*HeightDoc, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"timestamp": bson.M{"$gte": from}}}},
+		{{Key: "$unwind", Value: "$networks"}},
+		{{Key: "$match", Value: bson.M{"networks.height": bson.M{"$gt": 0}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"interval":   dateTrunc(i),
+				"chainId":    "$networks.chainId",
+				"phylaxAddr": "$phylaxAddr",
+			},
+			"nodeName": bson.M{"$last": "$nodeName"},
+			"height":   bson.M{"$max": "$networks.height"},
+		}}},
+	}
+
+	var docs []*HeightDoc
+	if err := r.aggregateHistory(ctx, pipeline, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (r *Repository) aggregateHistory(ctx context.Context, pipeline mongo.Pipeline, results interface{}) error {
+	cur, err := r.collections.heartbeatHistory.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to execute Aggregate command to get heartbeat history",
+			zap.Error(err), zap.String("requestID", requestID))
+		return errors.WithStack(err)
+	}
+	if err := cur.All(ctx, results); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor of heartbeat history", zap.Error(err),
+			zap.String("requestID", requestID))
+		return errors.WithStack(err)
+	}
+	return nil
+}
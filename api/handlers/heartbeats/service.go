@@ -2,7 +2,12 @@ package heartbeats
 
 import (
 	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
 	"go.uber.org/zap"
 )
 
@@ -21,3 +26,164 @@ func NewService(dao *Repository, logger *zap.Logger) *Service {
 func (s *Service) GetHeartbeatsByIds(ctx context.Context, heartbeatsIDs []string) ([]*HeartbeatDoc, error) {
 	return s.repo.FindByIDs(ctx, heartbeatsIDs)
 }
+
+// GetUptime get the percentage of time each phylax was up during the time span.
+// The phylaxs without heartbeats in the time span are returned with zero uptime.
+func (s *Service) GetUptime(ctx context.Context, timeSpan HistoryTimeSpan, phylaxAddrs []string) ([]*PhylaxUptime, error) {
+	docs, err := s.repo.CountUptimeIntervals(ctx, time.Now().Add(-timeSpan.Duration()), uptimeInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	total := math.Ceil(float64(timeSpan.Duration()) / float64(uptimeInterval.Duration()))
+	uptimes := make(map[string]*PhylaxUptime, len(docs))
+	for _, doc := range docs {
+		uptimes[doc.PhylaxAddr] = &PhylaxUptime{
+			PhylaxAddr: doc.PhylaxAddr,
+			NodeName:   doc.NodeName,
+			Uptime:     math.Min(100, float64(doc.Intervals)*100/total),
+		}
+	}
+	for _, addr := range phylaxAddrs {
+		addr = normalizePhylaxAddr(addr)
+		if _, ok := uptimes[addr]; !ok {
+			uptimes[addr] = &PhylaxUptime{PhylaxAddr: addr}
+		}
+	}
+
+	result := make([]*PhylaxUptime, 0, len(uptimes))
+	for _, u := range uptimes {
+		result = append(result, u)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PhylaxAddr < result[j].PhylaxAddr })
+	return result, nil
+}
+
+// GetBoots get the boots of a phylax node seen during the time span.
+func (s *Service) GetBoots(ctx context.Context, phylaxAddr string, timeSpan HistoryTimeSpan) ([]*PhylaxBoot, error) {
+	docs, err := s.repo.FindBoots(ctx, normalizePhylaxAddr(phylaxAddr), time.Now().Add(-timeSpan.Duration()))
+	if err != nil {
+		return nil, err
+	}
+	boots := make([]*PhylaxBoot, 0, len(docs))
+	for _, doc := range docs {
+		boot := doc.PhylaxBoot
+		boot.BootTime = time.Unix(0, doc.BootTimestamp).UTC()
+		boots = append(boots, &boot)
+	}
+	return boots, nil
+}
+
+// GetVersionRollouts get the versions run by the phylaxs during the time span, sorted by the first time each version was seen.
+func (s *Service) GetVersionRollouts(ctx context.Context, timeSpan HistoryTimeSpan) ([]*VersionRollout, error) {
+	docs, err := s.repo.FindVersions(ctx, time.Now().Add(-timeSpan.Duration()))
+	if err != nil {
+		return nil, err
+	}
+
+	// docs are sorted by firstSeenAt, so the first doc of a version is the first time it was seen.
+	rollouts := make([]*VersionRollout, 0)
+	byVersion := make(map[string]*VersionRollout)
+	for _, doc := range docs {
+		rollout, ok := byVersion[doc.ID.Version]
+		if !ok {
+			rollout = &VersionRollout{Version: doc.ID.Version, FirstSeenAt: doc.FirstSeenAt}
+			byVersion[doc.ID.Version] = rollout
+			rollouts = append(rollouts, rollout)
+		}
+		rollout.Phylaxs = append(rollout.Phylaxs, &PhylaxVersion{
+			PhylaxAddr:  doc.ID.PhylaxAddr,
+			NodeName:    doc.NodeName,
+			FirstSeenAt: doc.FirstSeenAt,
+			LastSeenAt:  doc.LastSeenAt,
+		})
+	}
+	return rollouts, nil
+}
+
+// GetHeightLags get the block height lag of each phylax against the median height of the network for each chain during the time span.
+func (s *Service) GetHeightLags(ctx context.Context, timeSpan HistoryTimeSpan) ([]*PhylaxHeightLag, error) {
+	docs, err := s.repo.FindHeights(ctx, time.Now().Add(-timeSpan.Duration()), timeSpan.lagInterval())
+	if err != nil {
+		return nil, err
+	}
+	return computeHeightLags(docs), nil
+}
+
+// computeHeightLags compares the height reported by each phylax with the median height
+// reported by all the phylaxs for the same chain and interval.
+func computeHeightLags(docs []*HeightDoc) []*PhylaxHeightLag {
+	type intervalKey struct {
+		interval time.Time
+		chainID  sdk.ChainID
+	}
+	heights := make(map[intervalKey][]int64)
+	for _, doc := range docs {
+		k := intervalKey{doc.ID.Interval, doc.ID.ChainID}
+		heights[k] = append(heights[k], doc.Height)
+	}
+	medians := make(map[intervalKey]int64, len(heights))
+	for k, h := range heights {
+		medians[k] = median(h)
+	}
+
+	type chainLag struct {
+		sum, max, count int64
+	}
+	lags := make(map[string]map[sdk.ChainID]*chainLag)
+	nodeNames := make(map[string]string)
+	for _, doc := range docs {
+		lag := medians[intervalKey{doc.ID.Interval, doc.ID.ChainID}] - doc.Height
+		if lag < 0 {
+			lag = 0
+		}
+		chains, ok := lags[doc.ID.PhylaxAddr]
+		if !ok {
+			chains = make(map[sdk.ChainID]*chainLag)
+			lags[doc.ID.PhylaxAddr] = chains
+		}
+		c, ok := chains[doc.ID.ChainID]
+		if !ok {
+			c = &chainLag{}
+			chains[doc.ID.ChainID] = c
+		}
+		c.sum += lag
+		c.count++
+		if lag > c.max {
+			c.max = lag
+		}
+		nodeNames[doc.ID.PhylaxAddr] = doc.NodeName
+	}
+
+	result := make([]*PhylaxHeightLag, 0, len(lags))
+	for addr, chains := range lags {
+		phylaxLag := &PhylaxHeightLag{
+			PhylaxAddr: addr,
+			NodeName:   nodeNames[addr],
+			Chains:     make([]*ChainHeightLag, 0, len(chains)),
+		}
+		for chainID, c := range chains {
+			phylaxLag.Chains = append(phylaxLag.Chains, &ChainHeightLag{
+				ChainID: chainID,
+				AvgLag:  float64(c.sum) / float64(c.count),
+				MaxLag:  c.max,
+			})
+		}
+		sort.Slice(phylaxLag.Chains, func(i, j int) bool { return phylaxLag.Chains[i].ChainID < phylaxLag.Chains[j].ChainID })
+		result = append(result, phylaxLag)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PhylaxAddr < result[j].PhylaxAddr })
+	return result
+}
+
+// median returns the median of the heights, the lower one when the number of heights is even.
+func median(heights []int64) int64 {
+	sorted := append([]int64(nil), heights...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[(len(sorted)-1)/2]
+}
+
+// normalizePhylaxAddr converts a phylax address to the format of the heartbeat history: 40 lowercase hex digits.
+func normalizePhylaxAddr(addr string) string {
+	return strings.ToLower(strings.TrimPrefix(addr, "0x"))
+}
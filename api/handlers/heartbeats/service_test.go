@@ -0,0 +1,72 @@
+package heartbeats
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHeightDoc(interval time.Time, chainID sdk.ChainID, phylaxAddr string, height int64) *HeightDoc {
+	doc := &HeightDoc{NodeName: "node-" + phylaxAddr, Height: height}
+	doc.ID.Interval = interval
+	doc.ID.ChainID = chainID
+	doc.ID.PhylaxAddr = phylaxAddr
+	return doc
+}
+
+func TestComputeHeightLags(t *testing.T) {
+	t0 := time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(15 * time.Minute)
+
+	docs := []*HeightDoc{
+		newHeightDoc(t0, sdk.ChainIDEthereum, "a", 100),
+		newHeightDoc(t0, sdk.ChainIDEthereum, "b", 100),
+		newHeightDoc(t0, sdk.ChainIDEthereum, "c", 90),
+		newHeightDoc(t1, sdk.ChainIDEthereum, "a", 110),
+		newHeightDoc(t1, sdk.ChainIDEthereum, "b", 106),
+		newHeightDoc(t1, sdk.ChainIDEthereum, "c", 108),
+		newHeightDoc(t0, sdk.ChainIDSolana, "a", 1000),
+		newHeightDoc(t0, sdk.ChainIDSolana, "b", 1004),
+	}
+
+	lags := computeHeightLags(docs)
+	assert.Len(t, lags, 3)
+
+	// a is ahead of the median in every interval.
+	assert.Equal(t, "a", lags[0].PhylaxAddr)
+	assert.Equal(t, "node-a", lags[0].NodeName)
+	assert.Equal(t, []*ChainHeightLag{
+		{ChainID: sdk.ChainIDSolana, AvgLag: 0, MaxLag: 0},
+		{ChainID: sdk.ChainIDEthereum, AvgLag: 0, MaxLag: 0},
+	}, lags[0].Chains)
+
+	// b is 2 blocks behind the median in the second interval.
+	assert.Equal(t, []*ChainHeightLag{
+		{ChainID: sdk.ChainIDSolana, AvgLag: 0, MaxLag: 0},
+		{ChainID: sdk.ChainIDEthereum, AvgLag: 1, MaxLag: 2},
+	}, lags[1].Chains)
+
+	// c is 10 blocks behind the median in the first interval.
+	assert.Equal(t, []*ChainHeightLag{
+		{ChainID: sdk.ChainIDEthereum, AvgLag: 5, MaxLag: 10},
+	}, lags[2].Chains)
+}
+
+func TestParseHistoryTimeSpan(t *testing.T) {
+	for _, s := range []string{"1d", "7d", "30d"} {
+		ts, err := ParseHistoryTimeSpan(s)
+		assert.Nil(t, err)
+		assert.Equal(t, HistoryTimeSpan(s), ts)
+	}
+	_, err := ParseHistoryTimeSpan("1w")
+	assert.NotNil(t, err)
+
+	assert.Equal(t, 7*24*time.Hour, HistoryTimeSpan7Days.Duration())
+	assert.Equal(t, time.Hour, HistoryTimeSpan7Days.lagInterval().Duration())
+}
+
+func TestNormalizePhylaxAddr(t *testing.T) {
+	assert.Equal(t, "58cc3ae5c097b213ce3c81979e1b9f9570746aa5", normalizePhylaxAddr("0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5"))
+}
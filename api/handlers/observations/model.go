@@ -39,3 +39,37 @@ func (o *ObservationDoc) MarshalJSON() ([]byte, error) {
 		Alias:    (*Alias)(o),
 	})
 }
+
+// ObservationQuorumDoc represent the progress of the phylax signatures of a VAA towards the quorum.
+type ObservationQuorumDoc struct {
+	ID              string      `bson:"_id" json:"id"`
+	EmitterChain    vaa.ChainID `bson:"emitterChain" json:"emitterChain"`
+	EmitterAddr     string      `bson:"emitterAddr" json:"emitterAddr"`
+	Sequence        string      `bson:"sequence" json:"sequence"`
+	PhylaxSetIndex  uint32      `bson:"phylaxSetIndex" json:"phylaxSetIndex"`
+	Quorum          int         `bson:"quorum" json:"quorum"`
+	SignerCount     int         `bson:"signerCount" json:"signerCount"`
+	Signers         []string    `bson:"signers" json:"signers"`
+	MissingSigners  []string    `bson:"missingSigners" json:"missingSigners"`
+	FirstObservedAt *time.Time  `bson:"firstObservedAt" json:"firstObservedAt"`
+	QuorumAt        *time.Time  `bson:"quorumAt" json:"quorumAt,omitempty"`
+}
+
+// MarshalJSON interface implementation for ObservationQuorumDoc.
+func (q *ObservationQuorumDoc) MarshalJSON() ([]byte, error) {
+	sequence, err := strconv.ParseUint(q.Sequence, 10, 64)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	type Alias ObservationQuorumDoc
+	return json.Marshal(&struct {
+		Sequence      uint64 `json:"sequence"`
+		QuorumReached bool   `json:"quorumReached"`
+		*Alias
+	}{
+		Sequence:      sequence,
+		QuorumReached: q.QuorumAt != nil,
+		Alias:         (*Alias)(q),
+	})
+}
@@ -20,15 +20,22 @@ type Repository struct {
 	db          *mongo.Database
 	logger      *zap.Logger
 	collections struct {
-		observations *mongo.Collection
+		observations       *mongo.Collection
+		observationQuorums *mongo.Collection
 	}
 }
 
 // NewRepository create a new Repository.
 func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
-		logger:      logger.With(zap.String("module", "ObservationsRepository")),
-		collections: struct{ observations *mongo.Collection }{observations: db.Collection("observations")},
+		logger: logger.With(zap.String("module", "ObservationsRepository")),
+		collections: struct {
+			observations       *mongo.Collection
+			observationQuorums *mongo.Collection
+		}{
+			observations:       db.Collection("observations"),
+			observationQuorums: db.Collection("observationQuorums"),
+		},
 	}
 }
 
@@ -81,6 +88,22 @@ func (r *Repository) FindOne(ctx context.Context, q *ObservationQuery) (*Observa
 	return &obs, err
 }
 
+// FindQuorum get the quorum progress of a VAA by its message ID (chain/emitter/sequence).
+func (r *Repository) FindQuorum(ctx context.Context, messageID string) (*ObservationQuorumDoc, error) {
+	var quorum ObservationQuorumDoc
+	err := r.collections.observationQuorums.FindOne(ctx, bson.M{"_id": messageID}).Decode(&quorum)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errs.ErrNotFound
+		}
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute FindOne command to get observation quorum",
+			zap.Error(err), zap.String("messageID", messageID), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return &quorum, nil
+}
+
 // ObservationQuery respresent a query for the observation mongodb document.
 type ObservationQuery struct {
 	pagination.Pagination
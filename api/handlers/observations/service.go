@@ -3,6 +3,7 @@ package observations
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/deltaswapio/deltaswap-explorer/api/internal/pagination"
 	"github.com/deltaswapio/deltaswap-explorer/api/types"
@@ -85,3 +86,15 @@ func (s *Service) FindOne(
 
 	return s.repo.FindOne(ctx, query)
 }
+
+// FindQuorumByVAA get the quorum progress of the phylax signatures for a VAA (chainID, emitter address and sequence number).
+func (s *Service) FindQuorumByVAA(
+	ctx context.Context,
+	chain vaa.ChainID,
+	emitter *types.Address,
+	seq string,
+) (*ObservationQuorumDoc, error) {
+
+	messageID := fmt.Sprintf("%d/%s/%s", chain, emitter.Hex(), seq)
+	return s.repo.FindQuorum(ctx, messageID)
+}
@@ -0,0 +1,41 @@
+package phylax
+
+import (
+	"context"
+	"errors"
+
+	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// Service definition.
+type Service struct {
+	repo   *repository.PhylaxSetRepository
+	logger *zap.Logger
+}
+
+// NewService create a new Service.
+func NewService(repo *repository.PhylaxSetRepository, logger *zap.Logger) *Service {
+	return &Service{repo: repo, logger: logger.With(zap.String("module", "PhylaxService"))}
+}
+
+// GetPhylaxSets get all the phylax sets sorted by index.
+func (s *Service) GetPhylaxSets(ctx context.Context) ([]*repository.PhylaxSetDoc, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// GetCurrentPhylaxSet get the current phylax set.
+// It returns errs.ErrNotFound if the phylax sets were not stored yet.
+func (s *Service) GetCurrentPhylaxSet(ctx context.Context) (*repository.PhylaxSetDoc, error) {
+	phylaxSet, err := s.repo.FindLatest(ctx)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errs.ErrNotFound
+		}
+		s.logger.Error("failed to get current phylax set", zap.Error(err))
+		return nil, errs.ErrInternalError
+	}
+	return phylaxSet, nil
+}
@@ -0,0 +1,74 @@
+// Package phylaxstats handle the request of the phylax observation statistics computed by analytics.
+package phylaxstats
+
+import (
+	"fmt"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// TimeSpan is the window of the phylax observation statistics.
+type TimeSpan string
+
+const (
+	TimeSpan24Hours TimeSpan = "24h"
+	TimeSpan7Days   TimeSpan = "7d"
+	TimeSpan30Days  TimeSpan = "30d"
+)
+
+// ParseTimeSpan parses a string and returns a TimeSpan.
+func ParseTimeSpan(s string) (TimeSpan, error) {
+	switch t := TimeSpan(s); t {
+	case TimeSpan24Hours, TimeSpan7Days, TimeSpan30Days:
+		return t, nil
+	default:
+		return "", fmt.Errorf("invalid time span: %s", s)
+	}
+}
+
+// PhylaxLatency is the observation delay of a phylax, relative to the VAA timestamp.
+type PhylaxLatency struct {
+	PhylaxAddr    string          `json:"phylaxAddr"`
+	MedianDelayMs float64         `json:"medianDelayMs"`
+	Observations  int64           `json:"observations"`
+	Chains        []*ChainLatency `json:"chains"`
+}
+
+// ChainLatency is the observation delay of a phylax for the VAAs of a chain.
+type ChainLatency struct {
+	ChainID       sdk.ChainID `json:"chainId"`
+	MedianDelayMs float64     `json:"medianDelayMs"`
+	Observations  int64       `json:"observations"`
+}
+
+// PhylaxMissRate is the percentage of the messages a phylax never observed.
+type PhylaxMissRate struct {
+	PhylaxAddr string           `json:"phylaxAddr"`
+	Observed   int64            `json:"observed"`
+	Missed     int64            `json:"missed"`
+	MissRate   float64          `json:"missRate"`
+	Chains     []*ChainMissRate `json:"chains"`
+}
+
+// ChainMissRate is the percentage of the messages of a chain a phylax never observed.
+type ChainMissRate struct {
+	ChainID  sdk.ChainID `json:"chainId"`
+	Observed int64       `json:"observed"`
+	Missed   int64       `json:"missed"`
+	MissRate float64     `json:"missRate"`
+}
+
+// DelayRow is a median delay returned by InfluxDB.
+// ChainID is empty when the median is computed for all the chains.
+type DelayRow struct {
+	PhylaxAddr string  `mapstructure:"phylax_addr"`
+	ChainID    string  `mapstructure:"chain_id"`
+	Median     float64 `mapstructure:"_value"`
+}
+
+// CountRow is a number of observations of a phylax for a chain returned by InfluxDB.
+type CountRow struct {
+	PhylaxAddr string `mapstructure:"phylax_addr"`
+	ChainID    string `mapstructure:"chain_id"`
+	Count      int64  `mapstructure:"_value"`
+}
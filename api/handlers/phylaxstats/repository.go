@@ -0,0 +1,127 @@
+package phylaxstats
+
+import (
+	"context"
+	"fmt"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const queryTemplateMedianDelayByChain = `
+from(bucket: "%s")
+  |> range(start: -%s)
+  |> filter(fn: (r) => r._measurement == "phylax_observation_delay_1h" and r._field == "median")
+  |> group(columns: ["phylax_addr", "chain_id"])
+  |> median()
+`
+
+const queryTemplateMedianDelay = `
+from(bucket: "%s")
+  |> range(start: -%s)
+  |> filter(fn: (r) => r._measurement == "phylax_observation_delay_1h" and r._field == "median")
+  |> group(columns: ["phylax_addr"])
+  |> median()
+`
+
+const queryTemplateCountByChain = `
+from(bucket: "%s")
+  |> range(start: -%s)
+  |> filter(fn: (r) => r._measurement == "%s" and r._field == "count")
+  |> group(columns: ["phylax_addr", "chain_id"])
+  |> sum()
+`
+
+const (
+	delayMeasurement  = "phylax_observation_delay_1h"
+	missedMeasurement = "phylax_missed_observation_1h"
+)
+
+// Repository definition.
+type Repository struct {
+	queryAPI              api.QueryAPI
+	bucket30DaysRetention string
+	logger                *zap.Logger
+}
+
+// NewRepository create a new Repository.
+func NewRepository(client influxdb2.Client, org string, bucket30DaysRetention string, logger *zap.Logger) *Repository {
+	return &Repository{
+		queryAPI:              client.QueryAPI(org),
+		bucket30DaysRetention: bucket30DaysRetention,
+		logger:                logger.With(zap.String("module", "PhylaxStatsRepository")),
+	}
+}
+
+// FindMedianDelaysByChain get the median observation delay of each phylax for each chain during the time span.
+func (r *Repository) FindMedianDelaysByChain(ctx context.Context, timeSpan TimeSpan) ([]DelayRow, error) {
+	return r.findMedianDelays(ctx, queryTemplateMedianDelayByChain, timeSpan)
+}
+
+// FindMedianDelays get the median observation delay of each phylax for all the chains during the time span.
+func (r *Repository) FindMedianDelays(ctx context.Context, timeSpan TimeSpan) ([]DelayRow, error) {
+	return r.findMedianDelays(ctx, queryTemplateMedianDelay, timeSpan)
+}
+
+func (r *Repository) findMedianDelays(ctx context.Context, queryTemplate string, timeSpan TimeSpan) ([]DelayRow, error) {
+	query := fmt.Sprintf(queryTemplate, r.bucket30DaysRetention, timeSpan)
+	var rows []DelayRow
+	err := r.query(ctx, query, func(values map[string]interface{}) error {
+		var row DelayRow
+		if err := mapstructure.Decode(values, &row); err != nil {
+			return err
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	return rows, err
+}
+
+// CountObservedByChain get the number of messages observed by each phylax for each chain during the time span.
+func (r *Repository) CountObservedByChain(ctx context.Context, timeSpan TimeSpan) ([]CountRow, error) {
+	return r.countByChain(ctx, delayMeasurement, timeSpan)
+}
+
+// CountMissedByChain get the number of messages missed by each phylax for each chain during the time span.
+func (r *Repository) CountMissedByChain(ctx context.Context, timeSpan TimeSpan) ([]CountRow, error) {
+	return r.countByChain(ctx, missedMeasurement, timeSpan)
+}
+
+func (r *Repository) countByChain(ctx context.Context, measurement string, timeSpan TimeSpan) ([]CountRow, error) {
+	query := fmt.Sprintf(queryTemplateCountByChain, r.bucket30DaysRetention, timeSpan, measurement)
+	var rows []CountRow
+	err := r.query(ctx, query, func(values map[string]interface{}) error {
+		var row CountRow
+		if err := mapstructure.Decode(values, &row); err != nil {
+			return err
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	return rows, err
+}
+
+// query submits a query to InfluxDB and calls fn for each record of the result.
+func (r *Repository) query(ctx context.Context, query string, fn func(values map[string]interface{}) error) error {
+	result, err := r.queryAPI.Query(ctx, query)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to query phylax observation stats", zap.Error(err), zap.String("requestID", requestID))
+		return errors.WithStack(err)
+	}
+	defer result.Close()
+	for result.Next() {
+		if err := fn(result.Record().Values()); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if result.Err() != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to read phylax observation stats", zap.Error(result.Err()), zap.String("requestID", requestID))
+		return errors.WithStack(result.Err())
+	}
+	return nil
+}
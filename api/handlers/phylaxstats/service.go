@@ -0,0 +1,191 @@
+package phylaxstats
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Service definition.
+type Service struct {
+	repo   *Repository
+	logger *zap.Logger
+}
+
+// NewService create a new Service.
+func NewService(repo *Repository, logger *zap.Logger) *Service {
+	return &Service{repo: repo, logger: logger.With(zap.String("module", "PhylaxStatsService"))}
+}
+
+// GetLatencyRanking get the phylaxs sorted by median observation delay, the slowest first.
+func (s *Service) GetLatencyRanking(ctx context.Context, timeSpan TimeSpan) ([]*PhylaxLatency, error) {
+	overall, err := s.repo.FindMedianDelays(ctx, timeSpan)
+	if err != nil {
+		return nil, err
+	}
+	byChain, err := s.repo.FindMedianDelaysByChain(ctx, timeSpan)
+	if err != nil {
+		return nil, err
+	}
+	observed, err := s.repo.CountObservedByChain(ctx, timeSpan)
+	if err != nil {
+		return nil, err
+	}
+	return rankLatencies(overall, byChain, observed), nil
+}
+
+// GetMissRateRanking get the phylaxs sorted by the percentage of messages they never observed, the highest first.
+func (s *Service) GetMissRateRanking(ctx context.Context, timeSpan TimeSpan) ([]*PhylaxMissRate, error) {
+	observed, err := s.repo.CountObservedByChain(ctx, timeSpan)
+	if err != nil {
+		return nil, err
+	}
+	missed, err := s.repo.CountMissedByChain(ctx, timeSpan)
+	if err != nil {
+		return nil, err
+	}
+	return rankMissRates(observed, missed), nil
+}
+
+// rankLatencies builds the latency of each phylax from the medians returned by InfluxDB.
+// The rows with an invalid chain are ignored.
+func rankLatencies(overall, byChain []DelayRow, observed []CountRow) []*PhylaxLatency {
+	latencies := make(map[string]*PhylaxLatency, len(overall))
+	get := func(phylaxAddr string) *PhylaxLatency {
+		l, ok := latencies[phylaxAddr]
+		if !ok {
+			l = &PhylaxLatency{PhylaxAddr: phylaxAddr, Chains: []*ChainLatency{}}
+			latencies[phylaxAddr] = l
+		}
+		return l
+	}
+
+	for _, row := range overall {
+		get(row.PhylaxAddr).MedianDelayMs = row.Median
+	}
+
+	counts := make(map[string]map[sdk.ChainID]int64)
+	for _, row := range observed {
+		chainID, ok := parseChainID(row.ChainID)
+		if !ok {
+			continue
+		}
+		if _, ok := counts[row.PhylaxAddr]; !ok {
+			counts[row.PhylaxAddr] = make(map[sdk.ChainID]int64)
+		}
+		counts[row.PhylaxAddr][chainID] += row.Count
+	}
+
+	for _, row := range byChain {
+		chainID, ok := parseChainID(row.ChainID)
+		if !ok {
+			continue
+		}
+		l := get(row.PhylaxAddr)
+		count := counts[row.PhylaxAddr][chainID]
+		l.Observations += count
+		l.Chains = append(l.Chains, &ChainLatency{
+			ChainID:       chainID,
+			MedianDelayMs: row.Median,
+			Observations:  count,
+		})
+	}
+
+	result := make([]*PhylaxLatency, 0, len(latencies))
+	for _, l := range latencies {
+		sort.Slice(l.Chains, func(i, j int) bool { return l.Chains[i].MedianDelayMs > l.Chains[j].MedianDelayMs })
+		result = append(result, l)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].MedianDelayMs != result[j].MedianDelayMs {
+			return result[i].MedianDelayMs > result[j].MedianDelayMs
+		}
+		return result[i].PhylaxAddr < result[j].PhylaxAddr
+	})
+	return result
+}
+
+// rankMissRates builds the miss rate of each phylax from the observed and missed counts returned by InfluxDB.
+// The rows with an invalid chain are ignored.
+func rankMissRates(observed, missed []CountRow) []*PhylaxMissRate {
+	type key struct {
+		phylaxAddr string
+		chainID    sdk.ChainID
+	}
+	chains := make(map[key]*ChainMissRate)
+	add := func(row CountRow, isMissed bool) {
+		chainID, ok := parseChainID(row.ChainID)
+		if !ok {
+			return
+		}
+		k := key{phylaxAddr: row.PhylaxAddr, chainID: chainID}
+		c, ok := chains[k]
+		if !ok {
+			c = &ChainMissRate{ChainID: chainID}
+			chains[k] = c
+		}
+		if isMissed {
+			c.Missed += row.Count
+		} else {
+			c.Observed += row.Count
+		}
+	}
+	for _, row := range observed {
+		add(row, false)
+	}
+	for _, row := range missed {
+		add(row, true)
+	}
+
+	rates := make(map[string]*PhylaxMissRate)
+	for k, c := range chains {
+		c.MissRate = missRate(c.Observed, c.Missed)
+		r, ok := rates[k.phylaxAddr]
+		if !ok {
+			r = &PhylaxMissRate{PhylaxAddr: k.phylaxAddr}
+			rates[k.phylaxAddr] = r
+		}
+		r.Observed += c.Observed
+		r.Missed += c.Missed
+		r.Chains = append(r.Chains, c)
+	}
+
+	result := make([]*PhylaxMissRate, 0, len(rates))
+	for _, r := range rates {
+		r.MissRate = missRate(r.Observed, r.Missed)
+		sort.Slice(r.Chains, func(i, j int) bool {
+			if r.Chains[i].MissRate != r.Chains[j].MissRate {
+				return r.Chains[i].MissRate > r.Chains[j].MissRate
+			}
+			return r.Chains[i].ChainID < r.Chains[j].ChainID
+		})
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].MissRate != result[j].MissRate {
+			return result[i].MissRate > result[j].MissRate
+		}
+		return result[i].PhylaxAddr < result[j].PhylaxAddr
+	})
+	return result
+}
+
+// missRate returns the percentage of missed messages.
+func missRate(observed, missed int64) float64 {
+	total := observed + missed
+	if total == 0 {
+		return 0
+	}
+	return float64(missed) * 100 / float64(total)
+}
+
+func parseChainID(s string) (sdk.ChainID, bool) {
+	chainID, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return sdk.ChainIDUnset, false
+	}
+	return sdk.ChainID(chainID), true
+}
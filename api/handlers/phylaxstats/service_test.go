@@ -0,0 +1,58 @@
+package phylaxstats
+
+import (
+	"testing"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankLatencies(t *testing.T) {
+	overall := []DelayRow{
+		{PhylaxAddr: "a", Median: 1000},
+		{PhylaxAddr: "b", Median: 3000},
+	}
+	byChain := []DelayRow{
+		{PhylaxAddr: "a", ChainID: "2", Median: 1000},
+		{PhylaxAddr: "b", ChainID: "2", Median: 1200},
+		{PhylaxAddr: "b", ChainID: "4", Median: 5000},
+		{PhylaxAddr: "b", ChainID: "invalid", Median: 9000},
+	}
+	observed := []CountRow{
+		{PhylaxAddr: "a", ChainID: "2", Count: 10},
+		{PhylaxAddr: "b", ChainID: "2", Count: 8},
+		{PhylaxAddr: "b", ChainID: "4", Count: 2},
+	}
+
+	ranking := rankLatencies(overall, byChain, observed)
+	assert.Len(t, ranking, 2)
+	assert.Equal(t, "b", ranking[0].PhylaxAddr)
+	assert.Equal(t, float64(3000), ranking[0].MedianDelayMs)
+	assert.Equal(t, int64(10), ranking[0].Observations)
+	assert.Len(t, ranking[0].Chains, 2)
+	assert.Equal(t, sdk.ChainIDBSC, ranking[0].Chains[0].ChainID)
+	assert.Equal(t, int64(2), ranking[0].Chains[0].Observations)
+	assert.Equal(t, "a", ranking[1].PhylaxAddr)
+}
+
+func TestRankMissRates(t *testing.T) {
+	observed := []CountRow{
+		{PhylaxAddr: "a", ChainID: "2", Count: 10},
+		{PhylaxAddr: "b", ChainID: "2", Count: 6},
+		{PhylaxAddr: "b", ChainID: "4", Count: 10},
+	}
+	missed := []CountRow{
+		{PhylaxAddr: "b", ChainID: "2", Count: 4},
+	}
+
+	ranking := rankMissRates(observed, missed)
+	assert.Len(t, ranking, 2)
+	assert.Equal(t, "b", ranking[0].PhylaxAddr)
+	assert.Equal(t, int64(16), ranking[0].Observed)
+	assert.Equal(t, int64(4), ranking[0].Missed)
+	assert.Equal(t, float64(20), ranking[0].MissRate)
+	assert.Equal(t, sdk.ChainIDEthereum, ranking[0].Chains[0].ChainID)
+	assert.Equal(t, float64(40), ranking[0].Chains[0].MissRate)
+	assert.Equal(t, "a", ranking[1].PhylaxAddr)
+	assert.Equal(t, float64(0), ranking[1].MissRate)
+}
@@ -0,0 +1,126 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// subscriberBufferSize is the number of VAAs buffered for each subscriber.
+// Subscribers that can't keep up are disconnected.
+const subscriberBufferSize = 256
+
+// Subscription receives the signed VAAs published by fly.
+type Subscription struct {
+	C    chan *Vaa
+	once sync.Once
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() { close(s.C) })
+}
+
+// Hub fans out the signed VAAs published by fly in the redis VAA channel to the subscribers.
+type Hub struct {
+	client      *redis.Client
+	channel     string
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	logger      *zap.Logger
+}
+
+// NewHub creates a new hub for the redis VAA channel.
+func NewHub(client *redis.Client, prefix, channel string, logger *zap.Logger) *Hub {
+	if prefix != "" {
+		channel = fmt.Sprintf("%s:%s", prefix, channel)
+	}
+	return &Hub{
+		client:      client,
+		channel:     channel,
+		subscribers: make(map[*Subscription]struct{}),
+		logger:      logger.With(zap.String("module", "StreamHub")),
+	}
+}
+
+// Start subscribes to the redis VAA channel until the context is cancelled.
+func (h *Hub) Start(ctx context.Context) {
+	pubSub := h.client.Subscribe(ctx, h.channel)
+	go func() {
+		defer pubSub.Close()
+		ch := pubSub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				h.closeAll()
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					h.closeAll()
+					return
+				}
+				h.dispatch(msg.Payload)
+			}
+		}
+	}()
+}
+
+// Subscribe adds a new subscriber.
+func (h *Hub) Subscribe() *Subscription {
+	s := &Subscription{C: make(chan *Vaa, subscriberBufferSize)}
+	h.mu.Lock()
+	h.subscribers[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+// Unsubscribe removes a subscriber.
+func (h *Hub) Unsubscribe(s *Subscription) {
+	h.mu.Lock()
+	delete(h.subscribers, s)
+	h.mu.Unlock()
+	s.close()
+}
+
+func (h *Hub) dispatch(payload string) {
+	var notification domain.NotificationEvent
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		h.logger.Error("Error decoding notification event", zap.Error(err))
+		return
+	}
+	if notification.Type != domain.SignedVaaType {
+		return
+	}
+	signedVaa, err := domain.GetEventPayload[domain.SignedVaa](&notification)
+	if err != nil {
+		h.logger.Error("Error decoding signedVAA from notification event", zap.String("trackId", notification.TrackID), zap.Error(err))
+		return
+	}
+
+	v := NewVaa(&signedVaa)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subscribers {
+		select {
+		case s.C <- v:
+		default:
+			// slow subscriber, drop it so it reconnects and resumes from its last sequence.
+			h.logger.Warn("Dropping slow subscriber")
+			delete(h.subscribers, s)
+			s.close()
+		}
+	}
+}
+
+func (h *Hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subscribers {
+		delete(h.subscribers, s)
+		s.close()
+	}
+}
@@ -0,0 +1,53 @@
+package stream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// ParsedVaaDoc is the parsed payload of a VAA.
+type ParsedVaaDoc struct {
+	ID            string      `bson:"_id" json:"-"`
+	AppIDs        []string    `bson:"appIds" json:"appIds"`
+	ParsedPayload interface{} `bson:"parsedPayload" json:"parsedPayload"`
+}
+
+// Vaa is a signed VAA sent to the subscribers.
+// The same Vaa is shared by all the subscribers, so its payload is parsed at most once.
+type Vaa struct {
+	*domain.SignedVaa
+	parseOnce     sync.Once
+	appIDs        []string
+	parsedPayload interface{}
+}
+
+// NewVaa creates a Vaa from a signed VAA.
+func NewVaa(v *domain.SignedVaa) *Vaa {
+	return &Vaa{SignedVaa: v}
+}
+
+// Filter defines the VAAs sent to a subscriber.
+type Filter struct {
+	ChainID       *sdk.ChainID
+	Emitter       string
+	AppID         string
+	ParsedPayload bool
+}
+
+// VaaMessage is the message sent to the subscribers for each new VAA.
+type VaaMessage struct {
+	ID             string      `json:"id"`
+	Version        int         `json:"version"`
+	EmitterChain   sdk.ChainID `json:"emitterChain"`
+	EmitterAddr    string      `json:"emitterAddr"`
+	Sequence       uint64      `json:"sequence"`
+	PhylaxSetIndex uint32      `json:"phylaxSetIndex"`
+	Vaa            []byte      `json:"vaa"`
+	Timestamp      time.Time   `json:"timestamp"`
+	TxHash         string      `json:"txHash,omitempty"`
+	AppIDs         []string    `json:"appIds,omitempty"`
+	ParsedPayload  interface{} `json:"parsedPayload,omitempty"`
+}
@@ -0,0 +1,142 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/vaa"
+	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository definition.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		vaas      *mongo.Collection
+		parsedVaa *mongo.Collection
+	}
+}
+
+// NewRepository create a new Repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	return &Repository{db: db,
+		logger: logger.With(zap.String("module", "StreamRepository")),
+		collections: struct {
+			vaas      *mongo.Collection
+			parsedVaa *mongo.Collection
+		}{
+			vaas:      db.Collection("vaas"),
+			parsedVaa: db.Collection("parsedVaa"),
+		},
+	}
+}
+
+// FindParsedVaa returns the parsed VAA with the given id.
+func (r *Repository) FindParsedVaa(ctx context.Context, id string) (*ParsedVaaDoc, error) {
+	var doc ParsedVaaDoc
+	err := r.collections.parsedVaa.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errs.ErrNotFound
+		}
+		r.logger.Error("failed execute FindOne command to get parsedVaa", zap.Error(err), zap.String("id", id))
+		return nil, errors.WithStack(err)
+	}
+	return &doc, nil
+}
+
+// FindAfterSequence finds the VAAs of an emitter with a sequence greater than the given one and calls fn
+// with each page of VAAs sorted by sequence, until all the VAAs are found or fn returns an error.
+func (r *Repository) FindAfterSequence(ctx context.Context, chainID sdk.ChainID, emitter string, sequence uint64, pageSize int64,
+	fn func([]*domain.SignedVaa) error) error {
+
+	// the sequence is stored as a string, so the VAAs are searched by the timestamp of the last VAA received.
+	filter := bson.M{"emitterChain": chainID, "emitterAddr": emitter}
+	var last vaa.VaaDoc
+	id := fmt.Sprintf("%d/%s/%d", chainID, emitter, sequence)
+	err := r.collections.vaas.FindOne(ctx, bson.M{"_id": id}).Decode(&last)
+	if err == nil && last.Timestamp != nil {
+		filter["timestamp"] = bson.M{"$gte": *last.Timestamp}
+	} else if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		r.logger.Error("failed execute FindOne command to get vaa", zap.Error(err), zap.String("id", id))
+		return errors.WithStack(err)
+	}
+
+	// the _id breaks the ties between the VAAs with the same timestamp, so the pages don't overlap.
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(pageSize)
+	for {
+		cur, err := r.collections.vaas.Find(ctx, filter, opts)
+		if err != nil {
+			r.logger.Error("failed execute Find command to get vaas", zap.Error(err), zap.String("id", id))
+			return errors.WithStack(err)
+		}
+		var docs []*vaa.VaaDoc
+		if err := cur.All(ctx, &docs); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if page := toSignedVaas(docs, sequence); len(page) > 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+
+		if int64(len(docs)) < pageSize {
+			return nil
+		}
+		lastDoc := docs[len(docs)-1]
+		if lastDoc.Timestamp == nil {
+			return nil
+		}
+		delete(filter, "timestamp")
+		filter["$or"] = bson.A{
+			bson.M{"timestamp": bson.M{"$gt": *lastDoc.Timestamp}},
+			bson.M{"timestamp": *lastDoc.Timestamp, "_id": bson.M{"$gt": lastDoc.ID}},
+		}
+	}
+}
+
+// toSignedVaas converts the VAAs with a sequence greater than the given one, sorted by sequence.
+func toSignedVaas(docs []*vaa.VaaDoc, sequence uint64) []*domain.SignedVaa {
+	result := make([]*domain.SignedVaa, 0, len(docs))
+	for _, d := range docs {
+		seq, err := strconv.ParseUint(d.Sequence, 10, 64)
+		if err != nil || seq <= sequence {
+			continue
+		}
+		var timestamp time.Time
+		if d.Timestamp != nil {
+			timestamp = *d.Timestamp
+		}
+		var txHash string
+		if d.TxHash != nil {
+			txHash = *d.TxHash
+		}
+		result = append(result, &domain.SignedVaa{
+			ID:             d.ID,
+			EmitterChain:   uint16(d.EmitterChain),
+			EmitterAddr:    d.EmitterAddr,
+			Sequence:       seq,
+			PhylaxSetIndex: d.PhylaxSetIndex,
+			Timestamp:      timestamp,
+			Vaa:            d.Vaa,
+			TxHash:         txHash,
+			Version:        int(d.Version),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Sequence < result[j].Sequence })
+	return result
+}
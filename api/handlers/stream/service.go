@@ -0,0 +1,145 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
+	vaaPayloadParser "github.com/deltaswapio/deltaswap-explorer/common/client/parser"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	// resumePageSize is the number of VAAs read at once to resume a subscription.
+	resumePageSize = 1000
+	// parseTimeout is the maximum time to find the parsed payload of a VAA.
+	parseTimeout = 10 * time.Second
+)
+
+// vaaRepository is the storage of the VAAs used by the service.
+type vaaRepository interface {
+	FindParsedVaa(ctx context.Context, id string) (*ParsedVaaDoc, error)
+	FindAfterSequence(ctx context.Context, chainID sdk.ChainID, emitter string, sequence uint64, pageSize int64,
+		fn func([]*domain.SignedVaa) error) error
+}
+
+// Service definition.
+type Service struct {
+	hub            *Hub
+	repo           vaaRepository
+	resumePageSize int64
+	parseVaaFunc   vaaPayloadParser.ParseVaaFunc
+	logger         *zap.Logger
+}
+
+// NewService creates a new stream Service.
+func NewService(hub *Hub, repo *Repository, parseVaaFunc vaaPayloadParser.ParseVaaFunc, logger *zap.Logger) *Service {
+	return &Service{
+		hub:            hub,
+		repo:           repo,
+		resumePageSize: resumePageSize,
+		parseVaaFunc:   parseVaaFunc,
+		logger:         logger.With(zap.String("module", "StreamService")),
+	}
+}
+
+// Subscribe subscribes to the new VAAs.
+func (s *Service) Subscribe() *Subscription {
+	return s.hub.Subscribe()
+}
+
+// Unsubscribe removes the subscription.
+func (s *Service) Unsubscribe(sub *Subscription) {
+	s.hub.Unsubscribe(sub)
+}
+
+// Resume calls fn with every VAA of the emitter after the sequence, sorted by sequence, to resume a subscription.
+// It stops at the first error returned by fn.
+func (s *Service) Resume(ctx context.Context, chainID sdk.ChainID, emitter string, sequence uint64, fn func(*Vaa) error) error {
+	return s.repo.FindAfterSequence(ctx, chainID, emitter, sequence, s.resumePageSize, func(vaas []*domain.SignedVaa) error {
+		for _, v := range vaas {
+			if err := fn(NewVaa(v)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ToMessage applies the filter to a VAA and returns the message for the subscriber,
+// or nil if the VAA doesn't match the filter.
+func (s *Service) ToMessage(v *Vaa, f *Filter) *VaaMessage {
+	if f.ChainID != nil && sdk.ChainID(v.EmitterChain) != *f.ChainID {
+		return nil
+	}
+	if f.Emitter != "" && v.EmitterAddr != f.Emitter {
+		return nil
+	}
+
+	msg := VaaMessage{
+		ID:             v.ID,
+		Version:        v.Version,
+		EmitterChain:   sdk.ChainID(v.EmitterChain),
+		EmitterAddr:    v.EmitterAddr,
+		Sequence:       v.Sequence,
+		PhylaxSetIndex: v.PhylaxSetIndex,
+		Vaa:            v.Vaa,
+		Timestamp:      v.Timestamp,
+		TxHash:         v.TxHash,
+	}
+	if f.AppID == "" && !f.ParsedPayload {
+		return &msg
+	}
+
+	v.parseOnce.Do(func() {
+		v.appIDs, v.parsedPayload = s.parse(v.SignedVaa)
+	})
+	msg.AppIDs, msg.ParsedPayload = v.appIDs, v.parsedPayload
+	if f.AppID != "" && !containsAppID(msg.AppIDs, f.AppID) {
+		return nil
+	}
+	if !f.ParsedPayload {
+		msg.ParsedPayload = nil
+	}
+	return &msg
+}
+
+// parse returns the app ids and the parsed payload of a VAA. The parsedVaa collection is used when
+// the parser already processed the VAA, otherwise the VAA is parsed in-process.
+//
+// The result is shared by all the subscribers, so it doesn't depend on the context of a subscriber.
+func (s *Service) parse(v *domain.SignedVaa) ([]string, interface{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), parseTimeout)
+	defer cancel()
+
+	doc, err := s.repo.FindParsedVaa(ctx, v.ID)
+	if err == nil {
+		return doc.AppIDs, doc.ParsedPayload
+	}
+	if !errors.Is(err, errs.ErrNotFound) {
+		return nil, nil
+	}
+
+	vaa, err := sdk.Unmarshal(v.Vaa)
+	if err != nil {
+		s.logger.Error("error unmarshal vaa to parse", zap.String("id", v.ID), zap.Error(err))
+		return nil, nil
+	}
+	parsed, err := s.parseVaaFunc(vaa)
+	if err != nil {
+		return nil, nil
+	}
+	return parsed.StandardizedProperties.AppIds, parsed.ParsedPayload
+}
+
+func containsAppID(appIDs []string, appID string) bool {
+	for _, id := range appIDs {
+		if id == appID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
+	vaaPayloadParser "github.com/deltaswapio/deltaswap-explorer/common/client/parser"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testEmitter = "000000000000000000000000796dff6d74f3e27060b71255fe517bfb23c93eed"
+
+// fakeRepository pages the VAAs in memory.
+type fakeRepository struct {
+	vaas           []*domain.SignedVaa
+	parsed         map[string]*ParsedVaaDoc
+	findParsedVaas int
+}
+
+func (r *fakeRepository) FindParsedVaa(_ context.Context, id string) (*ParsedVaaDoc, error) {
+	r.findParsedVaas++
+	doc, ok := r.parsed[id]
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	return doc, nil
+}
+
+func (r *fakeRepository) FindAfterSequence(_ context.Context, chainID sdk.ChainID, emitter string, sequence uint64, pageSize int64,
+	fn func([]*domain.SignedVaa) error) error {
+	var page []*domain.SignedVaa
+	for _, v := range r.vaas {
+		if sdk.ChainID(v.EmitterChain) != chainID || v.EmitterAddr != emitter || v.Sequence <= sequence {
+			continue
+		}
+		page = append(page, v)
+		if int64(len(page)) == pageSize {
+			if err := fn(page); err != nil {
+				return err
+			}
+			page = nil
+		}
+	}
+	if len(page) > 0 {
+		return fn(page)
+	}
+	return nil
+}
+
+func newTestVaa(sequence uint64) *domain.SignedVaa {
+	return &domain.SignedVaa{
+		ID:           fmt.Sprintf("%d/%s/%d", sdk.ChainIDCelo, testEmitter, sequence),
+		EmitterChain: uint16(sdk.ChainIDCelo),
+		EmitterAddr:  testEmitter,
+		Sequence:     sequence,
+	}
+}
+
+func newTestService(repo *fakeRepository) *Service {
+	return &Service{
+		repo:           repo,
+		resumePageSize: 2,
+		parseVaaFunc: func(*sdk.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+			return nil, errors.New("unexpected parse")
+		},
+		logger: zap.NewNop(),
+	}
+}
+
+func TestResume(t *testing.T) {
+	repo := &fakeRepository{}
+	for seq := uint64(1); seq <= 7; seq++ {
+		repo.vaas = append(repo.vaas, newTestVaa(seq))
+	}
+	srv := newTestService(repo)
+
+	// all the VAAs after the sequence are sent, not only the first page.
+	var sequences []uint64
+	err := srv.Resume(context.Background(), sdk.ChainIDCelo, testEmitter, 2, func(v *Vaa) error {
+		sequences = append(sequences, v.Sequence)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3, 4, 5, 6, 7}, sequences)
+
+	// the resume stops at the first error, e.g. when the client disconnects.
+	writeErr := errors.New("write failed")
+	sequences = nil
+	err = srv.Resume(context.Background(), sdk.ChainIDCelo, testEmitter, 2, func(v *Vaa) error {
+		sequences = append(sequences, v.Sequence)
+		if v.Sequence == 4 {
+			return writeErr
+		}
+		return nil
+	})
+	assert.Equal(t, writeErr, err)
+	assert.Equal(t, []uint64{3, 4}, sequences)
+}
+
+func TestToMessage(t *testing.T) {
+	v := newTestVaa(1)
+	repo := &fakeRepository{parsed: map[string]*ParsedVaaDoc{
+		v.ID: {ID: v.ID, AppIDs: []string{domain.AppIdPortalTokenBridge}, ParsedPayload: "payload"},
+	}}
+	srv := newTestService(repo)
+
+	celo, ethereum := sdk.ChainIDCelo, sdk.ChainIDEthereum
+	testCases := []struct {
+		name    string
+		filter  Filter
+		matches bool
+	}{
+		{name: "no filter", filter: Filter{}, matches: true},
+		{name: "chain", filter: Filter{ChainID: &celo}, matches: true},
+		{name: "other chain", filter: Filter{ChainID: &ethereum}, matches: false},
+		{name: "emitter", filter: Filter{ChainID: &celo, Emitter: testEmitter}, matches: true},
+		{name: "other emitter", filter: Filter{Emitter: "01"}, matches: false},
+		{name: "app id", filter: Filter{AppID: domain.AppIdPortalTokenBridge}, matches: true},
+		{name: "other app id", filter: Filter{AppID: domain.AppIdGenericRelayer}, matches: false},
+		{name: "parsed payload", filter: Filter{ParsedPayload: true}, matches: true},
+	}
+
+	// the VAA is shared by the subscribers, as when it is dispatched by the hub.
+	shared := NewVaa(v)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := srv.ToMessage(shared, &tc.filter)
+			if !tc.matches {
+				assert.Nil(t, msg)
+				return
+			}
+			require.NotNil(t, msg)
+			assert.Equal(t, v.ID, msg.ID)
+			if tc.filter.ParsedPayload {
+				assert.Equal(t, "payload", msg.ParsedPayload)
+			} else {
+				assert.Nil(t, msg.ParsedPayload)
+			}
+		})
+	}
+
+	// the parsed VAA is found once for all the subscribers.
+	assert.Equal(t, 1, repo.findParsedVaas)
+}
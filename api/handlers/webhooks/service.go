@@ -0,0 +1,136 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
+	"github.com/deltaswapio/deltaswap-explorer/api/internal/pagination"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Service definition.
+type Service struct {
+	repo   *repository.WebhookRepository
+	logger *zap.Logger
+}
+
+// WebhookInput contains the editable fields of a webhook.
+type WebhookInput struct {
+	URL            string
+	EmitterChain   sdk.ChainID
+	EmitterAddress string
+	Events         []string
+	Enabled        bool
+}
+
+// NewService create a new Service.
+func NewService(repo *repository.WebhookRepository, logger *zap.Logger) *Service {
+	return &Service{repo: repo, logger: logger.With(zap.String("module", "WebhooksService"))}
+}
+
+// FindAll get all the webhooks.
+func (s *Service) FindAll(ctx context.Context) ([]*repository.WebhookDoc, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// FindByID get a webhook by id.
+func (s *Service) FindByID(ctx context.Context, id string) (*repository.WebhookDoc, error) {
+	webhook, err := s.repo.FindByID(ctx, id)
+	return webhook, s.mapError(err)
+}
+
+// Create creates a new webhook with a random secret.
+func (s *Service) Create(ctx context.Context, input *WebhookInput) (*repository.WebhookDoc, error) {
+	id, err := randomHex(12)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	webhook := repository.WebhookDoc{
+		ID:             id,
+		URL:            input.URL,
+		Secret:         secret,
+		EmitterChain:   input.EmitterChain,
+		EmitterAddress: input.EmitterAddress,
+		Events:         input.Events,
+		Enabled:        input.Enabled,
+	}
+	if err := s.repo.Insert(ctx, &webhook); err != nil {
+		return nil, errs.ErrInternalError
+	}
+	return &webhook, nil
+}
+
+// Update updates the editable fields of a webhook.
+func (s *Service) Update(ctx context.Context, id string, input *WebhookInput) (*repository.WebhookDoc, error) {
+	webhook, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	webhook.URL = input.URL
+	webhook.EmitterChain = input.EmitterChain
+	webhook.EmitterAddress = input.EmitterAddress
+	webhook.Events = input.Events
+	webhook.Enabled = input.Enabled
+	if err := s.repo.Update(ctx, webhook); err != nil {
+		return nil, s.mapError(err)
+	}
+	return webhook, nil
+}
+
+// RotateSecret replaces the secret of a webhook with a new random one.
+func (s *Service) RotateSecret(ctx context.Context, id string) (*repository.WebhookDoc, error) {
+	webhook, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = secret
+	if err := s.repo.Update(ctx, webhook); err != nil {
+		return nil, s.mapError(err)
+	}
+	return webhook, nil
+}
+
+// Delete deletes a webhook.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.mapError(s.repo.Delete(ctx, id))
+}
+
+// FindDeadLetters get the failed deliveries of a webhook.
+func (s *Service) FindDeadLetters(ctx context.Context, id string, p *pagination.Pagination) ([]*repository.WebhookDeadLetterDoc, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, s.mapError(err)
+	}
+	return s.repo.FindDeadLetters(ctx, id, p.Skip, p.Limit)
+}
+
+func (s *Service) mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return errs.ErrNotFound
+	}
+	s.logger.Error("webhook repository error", zap.Error(err))
+	return errs.ErrInternalError
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -57,6 +57,20 @@ type AppConfig struct {
 		Enabled bool
 		URL     string
 		Timeout int64
+		// GenericRelayerEmitters is a comma separated list of <chain id>/<address> of the generic relayer contracts
+		GenericRelayerEmitters string
+	}
+	Stream struct {
+		Enabled bool
+		// Redis channel where fly publishes the signed VAAs
+		VaaChannel string
+		// Prefix of the redis channel
+		Prefix string
+	}
+	Webhooks struct {
+		Enabled bool
+		// Key required in the X-Api-Key header to manage the webhooks
+		ApiKey string
 	}
 	RateLimit struct {
 		Enabled bool
@@ -94,6 +108,8 @@ func init() {
 	viper.SetDefault("p2pnetwork", P2pMainNet)
 	viper.SetDefault("PprofEnabled", false)
 	viper.SetDefault("RateLimit_Enabled", true)
+	viper.SetDefault("Stream_Enabled", false)
+	viper.SetDefault("Webhooks_Enabled", false)
 
 	// Consider environment variables in unmarshall doesn't work unless doing this: https://github.com/spf13/viper/issues/188#issuecomment-1168898503
 	b, err := json.Marshal(defaulConfig())
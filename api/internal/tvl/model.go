@@ -0,0 +1,61 @@
+package tvl
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// GroupBy is the grouping of the tvl breakdown.
+type GroupBy string
+
+const (
+	GroupByChain GroupBy = "chain"
+	GroupByToken GroupBy = "token"
+)
+
+// ParseGroupBy parses a string and returns a GroupBy.
+func ParseGroupBy(s string) (GroupBy, error) {
+	switch g := GroupBy(s); g {
+	case GroupByChain, GroupByToken:
+		return g, nil
+	default:
+		return "", fmt.Errorf("invalid group by: %s", s)
+	}
+}
+
+// Breakdown is the value locked in the token bridge grouped by chain or by token.
+// Only the field of the requested grouping is set.
+type Breakdown struct {
+	TvlUSD float64     `json:"tvlUsd"`
+	Chains []*ChainTvl `json:"chains,omitempty"`
+	Tokens []*TokenTvl `json:"tokens,omitempty"`
+}
+
+// ChainTvl is the value of the tokens locked in the token bridge of a chain.
+type ChainTvl struct {
+	ChainID sdk.ChainID `json:"chainId"`
+	TvlUSD  float64     `json:"tvlUsd"`
+}
+
+// TokenTvl is the value locked in the token bridge for a token, the token is locked in its original chain.
+type TokenTvl struct {
+	ChainID      sdk.ChainID `json:"chainId"`
+	TokenAddress string      `json:"tokenAddress"`
+	Symbol       string      `json:"symbol"`
+	Amount       float64     `json:"amount"`
+	Price        float64     `json:"price"`
+	TvlUSD       float64     `json:"tvlUsd"`
+}
+
+// TokenRow is the value locked for a token returned by InfluxDB.
+type TokenRow struct {
+	Time         time.Time `mapstructure:"_time"`
+	TokenChain   string    `mapstructure:"token_chain"`
+	TokenAddress string    `mapstructure:"token_address"`
+	Symbol       string    `mapstructure:"symbol"`
+	Amount       float64   `mapstructure:"amount"`
+	Price        float64   `mapstructure:"price"`
+	TvlUSD       float64   `mapstructure:"tvl_usd"`
+}
@@ -0,0 +1,62 @@
+package tvl
+
+import (
+	"context"
+	"fmt"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// the tvl job runs every hour, the last two days are queried so a few failed runs don't empty the tvl.
+const queryTemplateLastTvl = `
+from(bucket: "%s")
+  |> range(start: -2d)
+  |> filter(fn: (r) => r._measurement == "tvl")
+  |> last()
+  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+`
+
+// Repository definition.
+type Repository struct {
+	queryAPI              api.QueryAPI
+	bucket30DaysRetention string
+	logger                *zap.Logger
+}
+
+// NewRepository create a new Repository.
+func NewRepository(client influxdb2.Client, org string, bucket30DaysRetention string, logger *zap.Logger) *Repository {
+	return &Repository{
+		queryAPI:              client.QueryAPI(org),
+		bucket30DaysRetention: bucket30DaysRetention,
+		logger:                logger.With(zap.String("module", "TvlRepository")),
+	}
+}
+
+// FindLastTvl get the last value locked of each token computed by the tvl job.
+func (r *Repository) FindLastTvl(ctx context.Context) ([]TokenRow, error) {
+	query := fmt.Sprintf(queryTemplateLastTvl, r.bucket30DaysRetention)
+	result, err := r.queryAPI.Query(ctx, query)
+	if err != nil {
+		r.logger.Error("failed to query tvl", zap.Error(err))
+		return nil, errors.WithStack(err)
+	}
+	defer result.Close()
+
+	var rows []TokenRow
+	for result.Next() {
+		var row TokenRow
+		if err := mapstructure.Decode(result.Record().Values(), &row); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		rows = append(rows, row)
+	}
+	if result.Err() != nil {
+		r.logger.Error("failed to read tvl", zap.Error(result.Err()))
+		return nil, errors.WithStack(result.Err())
+	}
+	return rows, nil
+}
@@ -3,16 +3,22 @@ package tvl
 import (
 	"context"
 	"errors"
+	"sort"
+	"strconv"
 	"time"
 
 	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
 	deltaswapscanCache "github.com/deltaswapio/deltaswap-explorer/common/client/cache"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
 	"go.uber.org/zap"
 )
 
 // Tvl is the tvl client.
+//
+// The value locked in the token bridge is computed by the tvl job from the token bridge transfers
+// and the wrapped assets supply, and stored in InfluxDB.
 type Tvl struct {
-	api        *TvlAPI
+	repo       *Repository
 	cache      deltaswapscanCache.Cache
 	tvlKey     string
 	expiration time.Duration
@@ -20,16 +26,16 @@ type Tvl struct {
 }
 
 // NewTVL init a new tvl client.
-func NewTVL(p2pNetwork string, cache deltaswapscanCache.Cache, tvlKey string, expiration int, logger *zap.Logger) *Tvl {
+func NewTVL(repo *Repository, cache deltaswapscanCache.Cache, tvlKey string, expiration int, logger *zap.Logger) *Tvl {
 	return &Tvl{
-		api:        NewTvlAPI(p2pNetwork),
+		repo:       repo,
 		cache:      cache,
 		tvlKey:     tvlKey,
 		expiration: time.Duration(expiration) * time.Second,
 		logger:     logger}
 }
 
-// Get get tvl value from cache if exists or call wormhole api to get tvl value and set the in cache for t.expiration time.
+// Get get tvl value from cache if exists or compute the tvl value from the last tvl of each token and set the in cache for t.expiration time.
 func (t *Tvl) Get(ctx context.Context) (string, error) {
 
 	// Get tvl from cache
@@ -43,22 +49,106 @@ func (t *Tvl) Get(ctx context.Context) (string, error) {
 			zap.String("key", t.tvlKey))
 	}
 
-	// Get tvl from wormhole api
-	tvlUSD, err := t.api.GetNotionalUSD(ctx, []string{"all"})
+	// Get tvl from influx
+	rows, err := t.repo.FindLastTvl(ctx)
 	if err != nil {
-		t.logger.Error("error getting tvl from wormhole api",
+		t.logger.Error("error getting tvl from influx",
 			zap.Error(err))
+		return "", err
 	}
-	if tvlUSD == nil {
+	rows = lastRun(rows)
+	if len(rows) == 0 {
 		return "", errs.ErrNotFound
 	}
+	var tvlUSD float64
+	for _, row := range rows {
+		tvlUSD += row.TvlUSD
+	}
+	tvl = strconv.FormatFloat(tvlUSD, 'f', 2, 64)
 
 	// Set tvl in cache with t.expiration time
-	err = t.cache.Set(ctx, t.tvlKey, *tvlUSD, t.expiration)
+	err = t.cache.Set(ctx, t.tvlKey, tvl, t.expiration)
 	if err != nil {
 		t.logger.Error("error setting tvl in cache",
 			zap.Error(err),
 			zap.String("key", t.tvlKey))
 	}
-	return *tvlUSD, nil
+	return tvl, nil
+}
+
+// GetBreakdown get the value locked in the token bridge grouped by chain or by token.
+func (t *Tvl) GetBreakdown(ctx context.Context, groupBy GroupBy) (*Breakdown, error) {
+	rows, err := t.repo.FindLastTvl(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return breakdown(lastRun(rows), groupBy), nil
+}
+
+// lastRun returns the rows written by the last run of the tvl job.
+// The tokens without value in the last run are discarded.
+func lastRun(rows []TokenRow) []TokenRow {
+	var last time.Time
+	for _, row := range rows {
+		if row.Time.After(last) {
+			last = row.Time
+		}
+	}
+	result := make([]TokenRow, 0, len(rows))
+	for _, row := range rows {
+		if row.Time.Equal(last) {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// breakdown groups the tvl of the tokens by chain or by token, sorted by value, the highest first.
+// The rows with an invalid chain are ignored.
+func breakdown(rows []TokenRow, groupBy GroupBy) *Breakdown {
+	var b Breakdown
+	chains := make(map[sdk.ChainID]*ChainTvl)
+	for _, row := range rows {
+		chainID, err := strconv.ParseUint(row.TokenChain, 10, 16)
+		if err != nil {
+			continue
+		}
+		b.TvlUSD += row.TvlUSD
+
+		switch groupBy {
+		case GroupByToken:
+			b.Tokens = append(b.Tokens, &TokenTvl{
+				ChainID:      sdk.ChainID(chainID),
+				TokenAddress: row.TokenAddress,
+				Symbol:       row.Symbol,
+				Amount:       row.Amount,
+				Price:        row.Price,
+				TvlUSD:       row.TvlUSD,
+			})
+		default:
+			c, ok := chains[sdk.ChainID(chainID)]
+			if !ok {
+				c = &ChainTvl{ChainID: sdk.ChainID(chainID)}
+				chains[sdk.ChainID(chainID)] = c
+			}
+			c.TvlUSD += row.TvlUSD
+		}
+	}
+
+	switch groupBy {
+	case GroupByToken:
+		sort.Slice(b.Tokens, func(i, j int) bool { return b.Tokens[i].TvlUSD > b.Tokens[j].TvlUSD })
+	default:
+		b.Chains = make([]*ChainTvl, 0, len(chains))
+		for _, c := range chains {
+			b.Chains = append(b.Chains, c)
+		}
+		sort.Slice(b.Chains, func(i, j int) bool {
+			if b.Chains[i].TvlUSD != b.Chains[j].TvlUSD {
+				return b.Chains[i].TvlUSD > b.Chains[j].TvlUSD
+			}
+			return b.Chains[i].ChainID < b.Chains[j].ChainID
+		})
+	}
+	return &b
 }
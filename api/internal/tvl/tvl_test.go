@@ -0,0 +1,51 @@
+package tvl
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastRun(t *testing.T) {
+	previous := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	last := previous.Add(time.Hour)
+	rows := []TokenRow{
+		{Time: last, TokenChain: "2", TokenAddress: "a", TvlUSD: 10},
+		{Time: previous, TokenChain: "2", TokenAddress: "b", TvlUSD: 20},
+		{Time: last, TokenChain: "4", TokenAddress: "c", TvlUSD: 30},
+	}
+
+	result := lastRun(rows)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "a", result[0].TokenAddress)
+	assert.Equal(t, "c", result[1].TokenAddress)
+	assert.Empty(t, lastRun(nil))
+}
+
+func TestBreakdown(t *testing.T) {
+	rows := []TokenRow{
+		{TokenChain: "2", TokenAddress: "a", Symbol: "A", Amount: 5, Price: 2, TvlUSD: 10},
+		{TokenChain: "4", TokenAddress: "b", Symbol: "B", Amount: 30, Price: 1, TvlUSD: 30},
+		{TokenChain: "2", TokenAddress: "c", Symbol: "C", Amount: 1, Price: 25, TvlUSD: 25},
+		{TokenChain: "invalid", TokenAddress: "d", TvlUSD: 100},
+	}
+
+	byChain := breakdown(rows, GroupByChain)
+	assert.Equal(t, float64(65), byChain.TvlUSD)
+	assert.Nil(t, byChain.Tokens)
+	assert.Equal(t, []*ChainTvl{
+		{ChainID: sdk.ChainIDEthereum, TvlUSD: 35},
+		{ChainID: sdk.ChainIDBSC, TvlUSD: 30},
+	}, byChain.Chains)
+
+	byToken := breakdown(rows, GroupByToken)
+	assert.Equal(t, float64(65), byToken.TvlUSD)
+	assert.Nil(t, byToken.Chains)
+	assert.Len(t, byToken.Tokens, 3)
+	assert.Equal(t, "b", byToken.Tokens[0].TokenAddress)
+	assert.Equal(t, sdk.ChainIDBSC, byToken.Tokens[0].ChainID)
+	assert.Equal(t, "c", byToken.Tokens[1].TokenAddress)
+	assert.Equal(t, "a", byToken.Tokens[2].TokenAddress)
+}
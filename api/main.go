@@ -25,9 +25,13 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/heartbeats"
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/infrastructure"
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/observations"
+	phylaxsvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/phylax"
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/phylaxstats"
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/relays"
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/stream"
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/transactions"
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/vaa"
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/webhooks"
 	"github.com/deltaswapio/deltaswap-explorer/api/internal/config"
 	"github.com/deltaswapio/deltaswap-explorer/api/internal/tvl"
 	"github.com/deltaswapio/deltaswap-explorer/api/middleware"
@@ -38,13 +42,16 @@ import (
 	deltaswapscanCache "github.com/deltaswapio/deltaswap-explorer/common/client/cache"
 	vaaPayloadParser "github.com/deltaswapio/deltaswap-explorer/common/client/parser"
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	xlogger "github.com/deltaswapio/deltaswap-explorer/common/logger"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/common/tokens"
 	"github.com/deltaswapio/deltaswap-explorer/common/utils"
-	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
 	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/adaptor/v2"
 	"github.com/gofiber/fiber/v2"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
@@ -111,6 +118,15 @@ func main() {
 		rootLogger.Fatal("failed to connect to MongoDB", zap.Error(err))
 	}
 
+	// Load the token registry
+	rootLogger.Info("loading token registry")
+	tokenRegistry := tokens.NewRegistry(repository.NewTokenRepository(db.Database, rootLogger), rootLogger)
+	if err := tokenRegistry.Init(appCtx); err != nil {
+		rootLogger.Fatal("failed to load token registry", zap.Error(err))
+	}
+	go tokenRegistry.Watch(appCtx)
+	domain.SetTokenProvider(tokenRegistry)
+
 	// Get cache get function
 	rootLogger.Info("initializing cache")
 	cache, err := NewCache(appCtx, cfg, rootLogger)
@@ -118,14 +134,15 @@ func main() {
 		rootLogger.Fatal("failed to initialize cache", zap.Error(err))
 	}
 
-	// cfg.Cache.Expiration
-	rootLogger.Info("initializing TVL cache")
-	tvl := tvl.NewTVL(cfg.P2pNetwork, cache, cfg.Cache.TvlKey, cfg.Cache.TvlExpiration, rootLogger)
-
 	//InfluxDB client
 	rootLogger.Info("initializing InfluxDB client")
 	influxCli := newInfluxClient(cfg.Influx.URL, cfg.Influx.Token)
 
+	// cfg.Cache.Expiration
+	rootLogger.Info("initializing TVL cache")
+	tvlRepo := tvl.NewRepository(influxCli, cfg.Influx.Organization, cfg.Influx.Bucket30Days, rootLogger)
+	tvl := tvl.NewTVL(tvlRepo, cache, cfg.Cache.TvlKey, cfg.Cache.TvlExpiration, rootLogger)
+
 	//VaaPayloadParser client
 	vaaParserFunc, err := NewVaaParserFunc(cfg, rootLogger)
 	if err != nil {
@@ -151,6 +168,8 @@ func main() {
 		rootLogger,
 	)
 	relaysRepo := relays.NewRepository(db.Database, rootLogger)
+	phylaxSetRepo := repository.NewPhylaxSetRepository(db.Database, rootLogger)
+	phylaxStatsRepo := phylaxstats.NewRepository(influxCli, cfg.Influx.Organization, cfg.Influx.Bucket30Days, rootLogger)
 
 	// Set up services
 	rootLogger.Info("initializing services")
@@ -162,6 +181,10 @@ func main() {
 	heartbeatsService := heartbeats.NewService(heartbeatsRepo, rootLogger)
 	transactionsService := transactions.NewService(transactionsRepo, cache, time.Duration(cfg.Cache.MetricExpiration)*time.Second, rootLogger)
 	relaysService := relays.NewService(relaysRepo, rootLogger)
+	phylaxService := phylaxsvc.NewService(phylaxSetRepo, rootLogger)
+	phylaxStatsService := phylaxstats.NewService(phylaxStatsRepo, rootLogger)
+	streamService := NewStreamService(appCtx, cfg, db.Database, vaaParserFunc, rootLogger)
+	webhooksService := NewWebhooksService(cfg, db.Database, rootLogger)
 
 	// Set up a custom error handler
 	response.SetEnableStackTrace(*cfg)
@@ -203,11 +226,11 @@ func main() {
 
 	// Set up route handlers
 	app.Get("/swagger.json", GetSwagger)
-	deltaswapscan.RegisterRoutes(app, rootLogger, addressService, vaaService, obsService, governorService, infrastructureService, transactionsService, relaysService)
-	phylax.RegisterRoutes(cfg, app, rootLogger, vaaService, governorService, heartbeatsService)
+	deltaswapscan.RegisterRoutes(app, rootLogger, addressService, vaaService, obsService, governorService, infrastructureService, transactionsService, relaysService, phylaxService, heartbeatsService, phylaxStatsService, tvl, streamService, webhooksService, cfg.Webhooks.ApiKey)
+	phylax.RegisterRoutes(cfg, app, rootLogger, vaaService, governorService, heartbeatsService, phylaxService)
 
 	// Set up gRPC handlers
-	handler := rpcApi.NewHandler(vaaService, heartbeatsService, governorService, rootLogger, cfg.P2pNetwork)
+	handler := rpcApi.NewHandler(vaaService, heartbeatsService, governorService, phylaxService, rootLogger)
 	grpcServer := rpcApi.NewServer(handler, rootLogger)
 	grpcWebServer := grpcweb.WrapServer(grpcServer)
 	app.Use(
@@ -275,6 +298,39 @@ func NewCache(ctx context.Context, cfg *config.AppConfig, logger *zap.Logger) (d
 	return cacheClient, nil
 }
 
+// NewStreamService creates the service that streams the signed VAAs published by fly.
+// It returns nil when the stream is disabled.
+func NewStreamService(ctx context.Context, cfg *config.AppConfig, db *mongo.Database, parseVaaFunc vaaPayloadParser.ParseVaaFunc, logger *zap.Logger) *stream.Service {
+	if !cfg.Stream.Enabled {
+		return nil
+	}
+	if cfg.Stream.VaaChannel == "" {
+		logger.Fatal("vaa stream is enabled but the redis channel is not configured")
+	}
+
+	opt, err := redis.ParseURL(cfg.Cache.URL)
+	if err != nil {
+		logger.Fatal("failed to parse redis url for vaa stream", zap.Error(err))
+	}
+	hub := stream.NewHub(redis.NewClient(opt), cfg.Stream.Prefix, cfg.Stream.VaaChannel, logger)
+	hub.Start(ctx)
+
+	logger.Info("vaa stream enabled", zap.String("channel", cfg.Stream.VaaChannel))
+	return stream.NewService(hub, stream.NewRepository(db, logger), parseVaaFunc, logger)
+}
+
+// NewWebhooksService creates the service to manage the webhooks.
+// It returns nil when the webhooks are disabled.
+func NewWebhooksService(cfg *config.AppConfig, db *mongo.Database, logger *zap.Logger) *webhooks.Service {
+	if !cfg.Webhooks.Enabled {
+		return nil
+	}
+	if cfg.Webhooks.ApiKey == "" {
+		logger.Fatal("webhooks are enabled but the api key is not configured")
+	}
+	return webhooks.NewService(repository.NewWebhookRepository(db, logger), logger)
+}
+
 func newInfluxClient(url, token string) influxdb2.Client {
 	return influxdb2.NewClient(url, token)
 }
@@ -328,15 +384,18 @@ func NewRateLimiter(ctx context.Context, cfg *config.AppConfig, logger *zap.Logg
 
 // NewVaaParserFunc returns a function to parse VAA payload.
 func NewVaaParserFunc(cfg *config.AppConfig, logger *zap.Logger) (vaaPayloadParser.ParseVaaFunc, error) {
+	fallbackURL := cfg.VaaPayloadParser.URL
 	if cfg.RunMode == config.RunModeDevelopmernt && !cfg.VaaPayloadParser.Enabled {
-		return func(vaa *sdk.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
-			return &vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{}, nil
-		}, nil
+		fallbackURL = ""
+	}
+	relayerEmitters, err := vaaPayloadParser.ParseEmitters(cfg.VaaPayloadParser.GenericRelayerEmitters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid generic relayer emitters: %w", err)
 	}
-	vaaPayloadParserClient, err := vaaPayloadParser.NewParserVAAAPIClient(cfg.VaaPayloadParser.Timeout,
-		cfg.VaaPayloadParser.URL, logger)
+	parseVaaFunc, err := vaaPayloadParser.NewParseVaaFunc(cfg.P2pNetwork, fallbackURL, cfg.VaaPayloadParser.Timeout, logger,
+		vaaPayloadParser.WithGenericRelayerEmitters(relayerEmitters))
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize VAA parser client: %w", err)
 	}
-	return vaaPayloadParserClient.ParseVaaWithStandarizedProperties, nil
+	return parseVaaFunc, nil
 }
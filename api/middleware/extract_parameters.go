@@ -8,7 +8,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/heartbeats"
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/phylaxstats"
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/transactions"
+	"github.com/deltaswapio/deltaswap-explorer/api/internal/tvl"
 	"github.com/deltaswapio/deltaswap-explorer/api/response"
 	"github.com/deltaswapio/deltaswap-explorer/api/types"
 	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
@@ -371,6 +374,36 @@ func ExtractTopStatisticsTimeSpan(ctx *fiber.Ctx) (*transactions.TopStatisticsTi
 	return timeSpan, nil
 }
 
+// ExtractHeartbeatHistoryTimeSpan parses the `timeSpan` parameter used on phylax history endpoints.
+func ExtractHeartbeatHistoryTimeSpan(ctx *fiber.Ctx) (heartbeats.HistoryTimeSpan, error) {
+	s := ctx.Query("timeSpan", string(heartbeats.HistoryTimeSpan1Day))
+	timeSpan, err := heartbeats.ParseHistoryTimeSpan(s)
+	if err != nil {
+		return "", response.NewInvalidQueryParamError(ctx, "INVALID <timeSpan> QUERY PARAMETER", nil)
+	}
+	return timeSpan, nil
+}
+
+// ExtractPhylaxStatsTimeSpan parses the `timeSpan` parameter used on phylax observation statistics endpoints.
+func ExtractPhylaxStatsTimeSpan(ctx *fiber.Ctx) (phylaxstats.TimeSpan, error) {
+	s := ctx.Query("timeSpan", string(phylaxstats.TimeSpan24Hours))
+	timeSpan, err := phylaxstats.ParseTimeSpan(s)
+	if err != nil {
+		return "", response.NewInvalidQueryParamError(ctx, "INVALID <timeSpan> QUERY PARAMETER", nil)
+	}
+	return timeSpan, nil
+}
+
+// ExtractTvlGroupBy parses the `groupBy` parameter used on the tvl endpoint.
+func ExtractTvlGroupBy(ctx *fiber.Ctx) (tvl.GroupBy, error) {
+	s := ctx.Query("groupBy", string(tvl.GroupByChain))
+	groupBy, err := tvl.ParseGroupBy(s)
+	if err != nil {
+		return "", response.NewInvalidQueryParamError(ctx, "INVALID <groupBy> QUERY PARAMETER", nil)
+	}
+	return groupBy, nil
+}
+
 // ExtractTokenAddress get token address from route path.
 func ExtractTokenAddress(c *fiber.Ctx, l *zap.Logger) (*types.Address, error) {
 	strTokenAddress := c.Params("token_address")
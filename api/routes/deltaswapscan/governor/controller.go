@@ -3,11 +3,14 @@ package governor
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/governor"
 	"github.com/deltaswapio/deltaswap-explorer/api/middleware"
 	"github.com/deltaswapio/deltaswap-explorer/api/response"
 	_ "github.com/deltaswapio/deltaswap-explorer/api/response" // needed by swaggo docs
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -350,3 +353,109 @@ func (c *Controller) GetEnqueuedVaasByChainID(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(enqueuedVaas)
 }
+
+// GovernorReleaseProjectionsResponse response definition.
+type GovernorReleaseProjectionsResponse struct {
+	EnqueuedVaas []*governor.EnqueuedVaaProjection `json:"enqueuedVaas"`
+}
+
+// NotionalHistoryResponse response definition.
+type NotionalHistoryResponse struct {
+	ChainID vaa.ChainID                      `json:"chainId"`
+	History []*governor.NotionalHistoryPoint `json:"history"`
+}
+
+// maxNotionalHistoryRange is the longest range accepted by the notional history endpoint.
+const maxNotionalHistoryRange = 30 * 24 * time.Hour
+
+// GetVaaTimeline godoc
+// @Description Returns the governor history of a VAA: when each phylax enqueued, released or dropped it.
+// @Description While the VAA is enqueued, the response includes the time when a quorum of phylaxs is projected to release it.
+// @Tags deltaswapscan
+// @ID governor-vaa-timeline
+// @Param chain_id path integer true "id of the blockchain"
+// @Param emitter path string true "address of the emitter"
+// @Param seq path integer true "sequence of the VAA"
+// @Success 200 {object} governor.GovernorVaaTimeline
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/governor/vaas/{chain_id}/{emitter}/{seq} [get]
+func (c *Controller) GetVaaTimeline(ctx *fiber.Ctx) error {
+
+	chainID, emitter, seq, err := middleware.ExtractVAAParams(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	timeline, err := c.srv.GetVaaTimeline(ctx.Context(), chainID, emitter, strconv.FormatUint(seq, 10))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(timeline)
+}
+
+// GetReleaseProjections godoc
+// @Description Returns the VAAs currently enqueued by the governor with the time when a quorum of phylaxs
+// @Description is projected to release them, sorted by the projected release time.
+// @Tags deltaswapscan
+// @ID governor-release-projections
+// @Success 200 {object} GovernorReleaseProjectionsResponse
+// @Failure 500
+// @Router /api/v1/governor/release_projections [get]
+func (c *Controller) GetReleaseProjections(ctx *fiber.Ctx) error {
+
+	projections, err := c.srv.GetReleaseProjections(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(GovernorReleaseProjectionsResponse{EnqueuedVaas: projections})
+}
+
+// GetNotionalHistory godoc
+// @Description Returns the hourly available notional of a blockchain. The range is limited to 30 days.
+// @Tags deltaswapscan
+// @ID governor-notional-history-by-chain
+// @Param chain path integer true "id of the blockchain"
+// @Param from query string false "Start of the range, format 20060102T150405Z. Default: 24 hours ago."
+// @Param to query string false "End of the range, format 20060102T150405Z. Default: now."
+// @Success 200 {object} NotionalHistoryResponse
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/governor/notional/history/{chain} [get]
+func (c *Controller) GetNotionalHistory(ctx *fiber.Ctx) error {
+
+	chainID, err := middleware.ExtractChainID(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	from, err := middleware.ExtractTime(ctx, "from")
+	if err != nil {
+		return err
+	}
+	to, err := middleware.ExtractTime(ctx, "to")
+	if err != nil {
+		return err
+	}
+	if to == nil {
+		now := time.Now()
+		to = &now
+	}
+	if from == nil {
+		dayAgo := to.Add(-24 * time.Hour)
+		from = &dayAgo
+	}
+	if !from.Before(*to) || to.Sub(*from) > maxNotionalHistoryRange {
+		return response.NewInvalidQueryParamError(ctx, "INVALID <from>, <to> QUERY PARAMETERS", nil)
+	}
+
+	history, err := c.srv.GetNotionalHistory(ctx.Context(), chainID, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(NotionalHistoryResponse{ChainID: chainID, History: history})
+}
@@ -0,0 +1,152 @@
+package heartbeats
+
+import (
+	"errors"
+
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/heartbeats"
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/phylax"
+	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
+	"github.com/deltaswapio/deltaswap-explorer/api/middleware"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv       *heartbeats.Service
+	phylaxSrv *phylax.Service
+	logger    *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *heartbeats.Service, phylaxSrv *phylax.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:       srv,
+		phylaxSrv: phylaxSrv,
+		logger:    logger.With(zap.String("module", "PhylaxHistoryController")),
+	}
+}
+
+// UptimeResponse response definition.
+type UptimeResponse struct {
+	Phylaxs []*heartbeats.PhylaxUptime `json:"phylaxs"`
+}
+
+// BootsResponse response definition.
+type BootsResponse struct {
+	Boots []*heartbeats.PhylaxBoot `json:"boots"`
+}
+
+// VersionsResponse response definition.
+type VersionsResponse struct {
+	Versions []*heartbeats.VersionRollout `json:"versions"`
+}
+
+// HeightLagResponse response definition.
+type HeightLagResponse struct {
+	Phylaxs []*heartbeats.PhylaxHeightLag `json:"phylaxs"`
+}
+
+// GetUptime godoc
+// @Description Returns the percentage of time each phylax sent heartbeats during the time span.
+// @Description The phylaxs of the current phylax set without heartbeats are returned with zero uptime.
+// @Tags deltaswapscan
+// @ID get-phylax-uptime
+// @Param timeSpan query string false "Time span, default: 1d, supported values: [1d, 7d, 30d]."
+// @Success 200 {object} UptimeResponse
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/phylax/uptime [get]
+func (c *Controller) GetUptime(ctx *fiber.Ctx) error {
+	timeSpan, err := middleware.ExtractHeartbeatHistoryTimeSpan(ctx)
+	if err != nil {
+		return err
+	}
+
+	var phylaxAddrs []string
+	phylaxSet, err := c.phylaxSrv.GetCurrentPhylaxSet(ctx.Context())
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return err
+	}
+	if phylaxSet != nil {
+		phylaxAddrs = phylaxSet.Keys
+	}
+
+	uptimes, err := c.srv.GetUptime(ctx.Context(), timeSpan, phylaxAddrs)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(UptimeResponse{Phylaxs: uptimes})
+}
+
+// GetBoots godoc
+// @Description Returns the boots of a phylax node seen during the time span, found by changes of the boot timestamp of its heartbeats.
+// @Tags deltaswapscan
+// @ID get-phylax-boots
+// @Param phylax_address path string true "phylax address"
+// @Param timeSpan query string false "Time span, default: 1d, supported values: [1d, 7d, 30d]."
+// @Success 200 {object} BootsResponse
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/phylax/{phylax_address}/boots [get]
+func (c *Controller) GetBoots(ctx *fiber.Ctx) error {
+	phylaxAddress, err := middleware.ExtractPhylaxAddress(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	timeSpan, err := middleware.ExtractHeartbeatHistoryTimeSpan(ctx)
+	if err != nil {
+		return err
+	}
+
+	boots, err := c.srv.GetBoots(ctx.Context(), phylaxAddress.ShortHex(), timeSpan)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(BootsResponse{Boots: boots})
+}
+
+// GetVersions godoc
+// @Description Returns the versions run by the phylaxs during the time span, sorted by the first time each version was seen.
+// @Tags deltaswapscan
+// @ID get-phylax-versions
+// @Param timeSpan query string false "Time span, default: 1d, supported values: [1d, 7d, 30d]."
+// @Success 200 {object} VersionsResponse
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/phylax/versions [get]
+func (c *Controller) GetVersions(ctx *fiber.Ctx) error {
+	timeSpan, err := middleware.ExtractHeartbeatHistoryTimeSpan(ctx)
+	if err != nil {
+		return err
+	}
+
+	versions, err := c.srv.GetVersionRollouts(ctx.Context(), timeSpan)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(VersionsResponse{Versions: versions})
+}
+
+// GetHeightLag godoc
+// @Description Returns, for each phylax and chain, the number of blocks the phylax was behind the median height
+// @Description reported by all the phylaxs during the time span.
+// @Tags deltaswapscan
+// @ID get-phylax-height-lag
+// @Param timeSpan query string false "Time span, default: 1d, supported values: [1d, 7d, 30d]."
+// @Success 200 {object} HeightLagResponse
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/phylax/height-lag [get]
+func (c *Controller) GetHeightLag(ctx *fiber.Ctx) error {
+	timeSpan, err := middleware.ExtractHeartbeatHistoryTimeSpan(ctx)
+	if err != nil {
+		return err
+	}
+
+	lags, err := c.srv.GetHeightLags(ctx.Context(), timeSpan)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(HeightLagResponse{Phylaxs: lags})
+}
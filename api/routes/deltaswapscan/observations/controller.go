@@ -143,6 +143,34 @@ func (c *Controller) FindAllByVAA(ctx *fiber.Ctx) error {
 	return ctx.JSON(obs)
 }
 
+// FindQuorumByVAA godoc
+// @Description Returns the progress of the phylax signatures of a VAA towards the quorum:
+// @Description the distinct signers, the missing signers of the phylax set, the first observation time and the quorum time.
+// @Tags deltaswapscan
+// @ID find-observations-quorum-by-sequence
+// @Param chain_id path integer true "id of the blockchain"
+// @Param emitter path string true "address of the emitter"
+// @Param seq path integer true "sequence of the VAA"
+// @Success 200 {object} observations.ObservationQuorumDoc
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/observations/:chain/:emitter/:sequence/quorum [get]
+func (c *Controller) FindQuorumByVAA(ctx *fiber.Ctx) error {
+
+	chainID, addr, seq, err := middleware.ExtractVAAParams(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	quorum, err := c.srv.FindQuorumByVAA(ctx.Context(), chainID, addr, strconv.FormatUint(seq, 10))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(quorum)
+}
+
 // FindOne godoc
 // @Description Find a specific observation.
 // @Tags deltaswapscan
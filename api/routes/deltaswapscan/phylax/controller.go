@@ -0,0 +1,64 @@
+package phylax
+
+import (
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/phylax"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *phylax.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *phylax.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "PhylaxSetsController")),
+	}
+}
+
+// PhylaxSetsResponse response definition.
+type PhylaxSetsResponse struct {
+	PhylaxSets []*PhylaxSetResponse `json:"phylaxSets"`
+}
+
+// PhylaxSetResponse response definition.
+type PhylaxSetResponse struct {
+	Index          uint32     `json:"index"`
+	Keys           []string   `json:"keys"`
+	CreationVaaID  string     `json:"creationVaaId,omitempty"`
+	ExpirationTime *time.Time `json:"expirationTime"`
+}
+
+// FindPhylaxSets godoc
+// @Description Returns all the historical phylax sets, with their keys, the ID of the VAA that created them
+// @Description and their expiration time. The expiration time of the current phylax set is null.
+// @Tags deltaswapscan
+// @ID get-phylax-sets
+// @Success 200 {object} PhylaxSetsResponse
+// @Failure 500
+// @Router /api/v1/phylaxsets [get]
+func (c *Controller) FindPhylaxSets(ctx *fiber.Ctx) error {
+	phylaxSets, err := c.srv.GetPhylaxSets(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	response := PhylaxSetsResponse{
+		PhylaxSets: make([]*PhylaxSetResponse, 0, len(phylaxSets)),
+	}
+	for _, ps := range phylaxSets {
+		response.PhylaxSets = append(response.PhylaxSets, &PhylaxSetResponse{
+			Index:          ps.Index,
+			Keys:           ps.Keys,
+			CreationVaaID:  ps.VaaID,
+			ExpirationTime: ps.ExpirationTime,
+		})
+	}
+	return ctx.JSON(response)
+}
@@ -0,0 +1,79 @@
+// Package phylaxstats handle the request of the phylax observation statistics.
+package phylaxstats
+
+import (
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/phylaxstats"
+	"github.com/deltaswapio/deltaswap-explorer/api/middleware"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *phylaxstats.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *phylaxstats.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "PhylaxStatsController")),
+	}
+}
+
+// LatencyResponse response definition.
+type LatencyResponse struct {
+	Phylaxs []*phylaxstats.PhylaxLatency `json:"phylaxs"`
+}
+
+// MissRateResponse response definition.
+type MissRateResponse struct {
+	Phylaxs []*phylaxstats.PhylaxMissRate `json:"phylaxs"`
+}
+
+// GetLatency godoc
+// @Description Returns the phylaxs ranked by the median delay of their observations relative to the VAA timestamp, the slowest first.
+// @Description Each phylax includes the median delay for each chain.
+// @Tags deltaswapscan
+// @ID get-phylax-latency
+// @Param timeSpan query string false "Time span, default: 24h, supported values: [24h, 7d, 30d]."
+// @Success 200 {object} LatencyResponse
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/phylax/latency [get]
+func (c *Controller) GetLatency(ctx *fiber.Ctx) error {
+	timeSpan, err := middleware.ExtractPhylaxStatsTimeSpan(ctx)
+	if err != nil {
+		return err
+	}
+
+	latencies, err := c.srv.GetLatencyRanking(ctx.Context(), timeSpan)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(LatencyResponse{Phylaxs: latencies})
+}
+
+// GetMissRate godoc
+// @Description Returns the phylaxs ranked by the percentage of messages they never observed, the highest first.
+// @Description Each phylax includes the miss rate for each chain.
+// @Tags deltaswapscan
+// @ID get-phylax-miss-rate
+// @Param timeSpan query string false "Time span, default: 24h, supported values: [24h, 7d, 30d]."
+// @Success 200 {object} MissRateResponse
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/phylax/miss-rate [get]
+func (c *Controller) GetMissRate(ctx *fiber.Ctx) error {
+	timeSpan, err := middleware.ExtractPhylaxStatsTimeSpan(ctx)
+	if err != nil {
+		return err
+	}
+
+	missRates, err := c.srv.GetMissRateRanking(ctx.Context(), timeSpan)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(MissRateResponse{Phylaxs: missRates})
+}
@@ -5,21 +5,34 @@ import (
 
 	addrsvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/address"
 	govsvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/governor"
+	heartbeatssvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/heartbeats"
 	infrasvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/infrastructure"
 	obssvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/observations"
+	phylaxsvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/phylax"
+	phylaxstatssvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/phylaxstats"
 	relayssvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/relays"
+	streamsvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/stream"
 	trxsvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/transactions"
 	vaasvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/vaa"
+	webhooksvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/webhooks"
+	tvlsvc "github.com/deltaswapio/deltaswap-explorer/api/internal/tvl"
 	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/address"
 	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/governor"
+	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/heartbeats"
 	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/infrastructure"
 	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/observations"
+	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/phylax"
+	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/phylaxstats"
 	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/relays"
+	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/stream"
 	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/transactions"
+	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/tvl"
 	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/vaa"
+	"github.com/deltaswapio/deltaswap-explorer/api/routes/deltaswapscan/webhooks"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cache"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/websocket/v2"
 
 	"go.uber.org/zap"
 )
@@ -44,6 +57,13 @@ func RegisterRoutes(
 	infrastructureService *infrasvc.Service,
 	transactionsService *trxsvc.Service,
 	relaysService *relayssvc.Service,
+	phylaxService *phylaxsvc.Service,
+	heartbeatsService *heartbeatssvc.Service,
+	phylaxStatsService *phylaxstatssvc.Service,
+	tvlService *tvlsvc.Tvl,
+	streamService *streamsvc.Service,
+	webhooksService *webhooksvc.Service,
+	webhooksApiKey string,
 ) {
 
 	// Set up controllers
@@ -54,6 +74,10 @@ func RegisterRoutes(
 	infrastructureCtrl := infrastructure.NewController(infrastructureService)
 	transactionCtrl := transactions.NewController(transactionsService, rootLogger)
 	relaysCtrl := relays.NewController(relaysService, rootLogger)
+	phylaxCtrl := phylax.NewController(phylaxService, rootLogger)
+	heartbeatsCtrl := heartbeats.NewController(heartbeatsService, phylaxService, rootLogger)
+	phylaxStatsCtrl := phylaxstats.NewController(phylaxStatsService, rootLogger)
+	tvlCtrl := tvl.NewController(tvlService, rootLogger)
 
 	// Set up route handlers
 	api := app.Group("/api/v1")
@@ -74,6 +98,7 @@ func RegisterRoutes(
 	api.Get("/x-chain-activity", transactionCtrl.GetChainActivity)
 	api.Get("/top-assets-by-volume", transactionCtrl.GetTopAssets)
 	api.Get("/top-chain-pairs-by-num-transfers", transactionCtrl.GetTopChainPairs)
+	api.Get("/tvl", tvlCtrl.GetTvl)
 	api.Get("token/:chain/:token_address", transactionCtrl.GetTokenByChainAndAddress)
 	api.Get("/transactions", transactionCtrl.ListTransactions)
 	api.Get("/transactions/:chain/:emitter/:sequence", transactionCtrl.GetTransactionByID)
@@ -94,6 +119,7 @@ func RegisterRoutes(
 	observations.Get("/:chain", observationsCtrl.FindAllByChain)
 	observations.Get("/:chain/:emitter", observationsCtrl.FindAllByEmitter)
 	observations.Get("/:chain/:emitter/:sequence", observationsCtrl.FindAllByVAA)
+	observations.Get("/:chain/:emitter/:sequence/quorum", observationsCtrl.FindQuorumByVAA)
 	observations.Get("/:chain/:emitter/:sequence/:signer/:hash", observationsCtrl.FindOne)
 
 	// governor resources
@@ -115,11 +141,46 @@ func RegisterRoutes(
 	governorNotional.Get("/available/", governorCtrl.GetAvailableNotional)
 	governorNotional.Get("/available/:chain", governorCtrl.GetAvailableNotionalByChainID)
 	governorNotional.Get("/max_available/:chain", governorCtrl.GetMaxNotionalAvailableByChainID)
+	governorNotional.Get("/history/:chain", governorCtrl.GetNotionalHistory)
 
 	enqueueVaas := governor.Group("/enqueued_vaas")
 	enqueueVaas.Get("/", governorCtrl.GetEnqueuedVaas)
 	enqueueVaas.Get("/:chain", governorCtrl.GetEnqueuedVaasByChainID)
 
+	governor.Get("/release_projections", governorCtrl.GetReleaseProjections)
+	governor.Get("/vaas/:chain/:emitter/:sequence", governorCtrl.GetVaaTimeline)
+
 	relays := api.Group("/relays")
 	relays.Get("/:chain/:emitter/:sequence", relaysCtrl.FindOne)
+
+	// phylax sets resource
+	api.Get("/phylaxsets", phylaxCtrl.FindPhylaxSets)
+
+	// phylax history resources, computed from the sampled heartbeats.
+	phylaxHistory := api.Group("/phylax")
+	phylaxHistory.Get("/uptime", heartbeatsCtrl.GetUptime)
+	phylaxHistory.Get("/versions", heartbeatsCtrl.GetVersions)
+	phylaxHistory.Get("/height-lag", heartbeatsCtrl.GetHeightLag)
+	phylaxHistory.Get("/latency", phylaxStatsCtrl.GetLatency)
+	phylaxHistory.Get("/miss-rate", phylaxStatsCtrl.GetMissRate)
+	phylaxHistory.Get("/:phylax_address/boots", heartbeatsCtrl.GetBoots)
+
+	// websocket stream of new vaas, only available when the stream is enabled.
+	if streamService != nil {
+		streamCtrl := stream.NewController(streamService, rootLogger)
+		api.Get("/ws/vaas", streamCtrl.Upgrade, websocket.New(streamCtrl.StreamVaas))
+	}
+
+	// webhooks resource, only available when the webhooks are enabled.
+	if webhooksService != nil {
+		webhooksCtrl := webhooks.NewController(webhooksService, webhooksApiKey, rootLogger)
+		webhooks := api.Group("/webhooks", webhooksCtrl.Authorize)
+		webhooks.Get("/", webhooksCtrl.FindAll)
+		webhooks.Post("/", webhooksCtrl.Create)
+		webhooks.Get("/:id", webhooksCtrl.FindByID)
+		webhooks.Put("/:id", webhooksCtrl.Update)
+		webhooks.Delete("/:id", webhooksCtrl.Delete)
+		webhooks.Post("/:id/secret", webhooksCtrl.RotateSecret)
+		webhooks.Get("/:id/dead-letters", webhooksCtrl.FindDeadLetters)
+	}
 }
@@ -0,0 +1,189 @@
+// Package stream handle the request of the websocket endpoint to stream new VAAs.
+package stream
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/stream"
+	"github.com/deltaswapio/deltaswap-explorer/api/middleware"
+	"github.com/deltaswapio/deltaswap-explorer/api/response"
+	"github.com/deltaswapio/deltaswap-explorer/api/types"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// localsFilter is the key of the subscription filter in the fiber locals.
+	localsFilter = "streamFilter"
+	// localsFromSequence is the key of the resume sequence in the fiber locals.
+	localsFromSequence = "streamFromSequence"
+
+	// pingInterval is the interval to send ping messages to keep the connection alive.
+	pingInterval = 30 * time.Second
+	// writeTimeout is the maximum time to write a message to the client.
+	writeTimeout = 10 * time.Second
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *stream.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *stream.Service, logger *zap.Logger) *Controller {
+	return &Controller{srv: srv, logger: logger.With(zap.String("module", "StreamController"))}
+}
+
+// Upgrade validates the subscription parameters before upgrading the connection to websocket.
+func (c *Controller) Upgrade(ctx *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(ctx) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	filter := stream.Filter{AppID: middleware.ExtractAppId(ctx, c.logger)}
+
+	includeParsedPayload, err := middleware.ExtractParsedPayload(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	filter.ParsedPayload = includeParsedPayload
+
+	if param := ctx.Query("chainId"); param != "" {
+		chain, err := strconv.ParseUint(param, 10, 16)
+		if err != nil {
+			return response.NewInvalidQueryParamError(ctx, "INVALID <chainId> QUERY PARAMETER", errors.WithStack(err))
+		}
+		chainID := sdk.ChainID(chain)
+		filter.ChainID = &chainID
+	}
+
+	if param := ctx.Query("emitter"); param != "" {
+		acceptSolanaFormat := filter.ChainID != nil && *filter.ChainID == sdk.ChainIDSolana
+		emitter, err := types.StringToAddress(param, acceptSolanaFormat)
+		if err != nil {
+			return response.NewInvalidQueryParamError(ctx, "INVALID <emitter> QUERY PARAMETER", errors.WithStack(err))
+		}
+		filter.Emitter = emitter.Hex()
+	}
+
+	if param := ctx.Query("fromSequence"); param != "" {
+		if filter.ChainID == nil || filter.Emitter == "" {
+			return response.NewInvalidQueryParamError(ctx, "<fromSequence> QUERY PARAMETER REQUIRES <chainId> AND <emitter>", nil)
+		}
+		sequence, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return response.NewInvalidQueryParamError(ctx, "INVALID <fromSequence> QUERY PARAMETER", errors.WithStack(err))
+		}
+		ctx.Locals(localsFromSequence, sequence)
+	}
+
+	ctx.Locals(localsFilter, &filter)
+	return ctx.Next()
+}
+
+// StreamVaas godoc
+// @Description Streams the new VAAs through a websocket connection.
+// @Description When `fromSequence` is set, the VAAs of the emitter after that sequence are sent before the new VAAs.
+// @Tags deltaswapscan
+// @ID stream-vaas
+// @Param chainId query integer false "filter by emitter chain"
+// @Param emitter query string false "filter by emitter address"
+// @Param appId query string false "filter by application ID"
+// @Param parsedPayload query bool false "include the parsed contents of the VAA, if available"
+// @Param fromSequence query integer false "resume from the sequence, requires chainId and emitter"
+// @Success 101 {object} stream.VaaMessage
+// @Failure 400
+// @Failure 426
+// @Router /api/v1/ws/vaas [get]
+func (c *Controller) StreamVaas(conn *websocket.Conn) {
+	filter, _ := conn.Locals(localsFilter).(*stream.Filter)
+	if filter == nil {
+		filter = &stream.Filter{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// read messages until the client closes the connection.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// subscribe before sending the missed VAAs so no VAA is lost in between.
+	sub := c.srv.Subscribe()
+	defer c.srv.Unsubscribe(sub)
+
+	var lastSequence uint64
+	resuming := false
+	if sequence, ok := conn.Locals(localsFromSequence).(uint64); ok {
+		resuming = true
+		lastSequence = sequence
+		err := c.srv.Resume(ctx, *filter.ChainID, filter.Emitter, sequence, func(v *stream.Vaa) error {
+			if msg := c.srv.ToMessage(v, filter); msg != nil {
+				if err := c.write(conn, msg); err != nil {
+					return err
+				}
+			}
+			lastSequence = v.Sequence
+			return nil
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				c.logger.Error("failed to resume subscription", zap.Error(err))
+			}
+			return
+		}
+	}
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		case v, ok := <-sub.C:
+			if !ok {
+				// the subscription was closed by the hub.
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscription closed"), time.Now().Add(writeTimeout))
+				return
+			}
+			if resuming && v.Sequence <= lastSequence {
+				continue
+			}
+			msg := c.srv.ToMessage(v, filter)
+			if msg == nil {
+				continue
+			}
+			if err := c.write(conn, msg); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *Controller) write(conn *websocket.Conn, msg *stream.VaaMessage) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	if err := conn.WriteJSON(msg); err != nil {
+		c.logger.Debug("failed to write message", zap.String("id", msg.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
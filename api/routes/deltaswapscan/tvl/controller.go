@@ -0,0 +1,46 @@
+// Package tvl handle the request of the value locked in the token bridge.
+package tvl
+
+import (
+	"github.com/deltaswapio/deltaswap-explorer/api/internal/tvl"
+	"github.com/deltaswapio/deltaswap-explorer/api/middleware"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *tvl.Tvl
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *tvl.Tvl, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "TvlController")),
+	}
+}
+
+// GetTvl godoc
+// @Description Returns the total value locked in the token bridge in USD, grouped by chain or by token.
+// @Description The tokens are locked in the token bridge of their original chain.
+// @Tags deltaswapscan
+// @ID get-tvl
+// @Param groupBy query string false "Grouping, default: chain, supported values: [chain, token]."
+// @Success 200 {object} tvl.Breakdown
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/tvl [get]
+func (c *Controller) GetTvl(ctx *fiber.Ctx) error {
+	groupBy, err := middleware.ExtractTvlGroupBy(ctx)
+	if err != nil {
+		return err
+	}
+
+	breakdown, err := c.srv.GetBreakdown(ctx.Context(), groupBy)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(breakdown)
+}
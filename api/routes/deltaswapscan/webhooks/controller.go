@@ -0,0 +1,292 @@
+// Package webhooks handle the request of the webhooks management endpoints.
+package webhooks
+
+import (
+	"crypto/subtle"
+	"net/url"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/api/handlers/webhooks"
+	"github.com/deltaswapio/deltaswap-explorer/api/middleware"
+	"github.com/deltaswapio/deltaswap-explorer/api/response"
+	"github.com/deltaswapio/deltaswap-explorer/api/types"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// apiKeyHeader is the header with the key to manage the webhooks.
+const apiKeyHeader = "X-Api-Key"
+
+// Controller definition.
+type Controller struct {
+	srv    *webhooks.Service
+	apiKey string
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *webhooks.Service, apiKey string, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		apiKey: apiKey,
+		logger: logger.With(zap.String("module", "WebhooksController")),
+	}
+}
+
+// WebhookRequest request definition.
+type WebhookRequest struct {
+	URL            string   `json:"url"`
+	EmitterChain   uint16   `json:"emitterChain"`
+	EmitterAddress string   `json:"emitterAddr"`
+	Events         []string `json:"events"`
+	Enabled        *bool    `json:"enabled"`
+}
+
+// WebhookResponse response definition.
+// The secret is only returned when the webhook is created or its secret is rotated.
+type WebhookResponse struct {
+	ID             string      `json:"id"`
+	URL            string      `json:"url"`
+	EmitterChain   sdk.ChainID `json:"emitterChain"`
+	EmitterAddress string      `json:"emitterAddr"`
+	Events         []string    `json:"events"`
+	Enabled        bool        `json:"enabled"`
+	Secret         string      `json:"secret,omitempty"`
+	CreatedAt      *time.Time  `json:"createdAt"`
+	UpdatedAt      *time.Time  `json:"updatedAt"`
+}
+
+// WebhooksResponse response definition.
+type WebhooksResponse struct {
+	Webhooks []*WebhookResponse `json:"webhooks"`
+}
+
+// DeadLettersResponse response definition.
+type DeadLettersResponse struct {
+	DeadLetters []*repository.WebhookDeadLetterDoc `json:"deadLetters"`
+}
+
+// Authorize checks the api key of the request.
+func (c *Controller) Authorize(ctx *fiber.Ctx) error {
+	key := ctx.Get(apiKeyHeader)
+	if subtle.ConstantTimeCompare([]byte(key), []byte(c.apiKey)) != 1 {
+		return response.NewApiError(ctx, fiber.StatusUnauthorized, response.Unauthenticated, "UNAUTHENTICATED", nil)
+	}
+	return ctx.Next()
+}
+
+// FindAll godoc
+// @Description Returns all the webhooks.
+// @Tags deltaswapscan
+// @ID get-webhooks
+// @Param X-Api-Key header string true "webhooks api key"
+// @Success 200 {object} WebhooksResponse
+// @Failure 401
+// @Failure 500
+// @Router /api/v1/webhooks [get]
+func (c *Controller) FindAll(ctx *fiber.Ctx) error {
+	docs, err := c.srv.FindAll(ctx.Context())
+	if err != nil {
+		return err
+	}
+	res := WebhooksResponse{Webhooks: make([]*WebhookResponse, 0, len(docs))}
+	for _, doc := range docs {
+		res.Webhooks = append(res.Webhooks, toResponse(doc, false))
+	}
+	return ctx.JSON(res)
+}
+
+// FindByID godoc
+// @Description Returns a webhook.
+// @Tags deltaswapscan
+// @ID get-webhook-by-id
+// @Param X-Api-Key header string true "webhooks api key"
+// @Param id path string true "webhook id"
+// @Success 200 {object} WebhookResponse
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/webhooks/{id} [get]
+func (c *Controller) FindByID(ctx *fiber.Ctx) error {
+	doc, err := c.srv.FindByID(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(toResponse(doc, false))
+}
+
+// Create godoc
+// @Description Creates a webhook for the VAAs of an emitter. The secret used to sign the deliveries is only returned in this response.
+// @Description Supported events are `vaa.signed` and `tx.confirmed`.
+// @Tags deltaswapscan
+// @ID create-webhook
+// @Param X-Api-Key header string true "webhooks api key"
+// @Param request body WebhookRequest true "webhook"
+// @Success 201 {object} WebhookResponse
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /api/v1/webhooks [post]
+func (c *Controller) Create(ctx *fiber.Ctx) error {
+	input, err := c.parseRequest(ctx)
+	if err != nil {
+		return err
+	}
+	doc, err := c.srv.Create(ctx.Context(), input)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(toResponse(doc, true))
+}
+
+// Update godoc
+// @Description Updates a webhook.
+// @Tags deltaswapscan
+// @ID update-webhook
+// @Param X-Api-Key header string true "webhooks api key"
+// @Param id path string true "webhook id"
+// @Param request body WebhookRequest true "webhook"
+// @Success 200 {object} WebhookResponse
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/webhooks/{id} [put]
+func (c *Controller) Update(ctx *fiber.Ctx) error {
+	input, err := c.parseRequest(ctx)
+	if err != nil {
+		return err
+	}
+	doc, err := c.srv.Update(ctx.Context(), ctx.Params("id"), input)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(toResponse(doc, false))
+}
+
+// RotateSecret godoc
+// @Description Replaces the secret of a webhook. The new secret is only returned in this response.
+// @Tags deltaswapscan
+// @ID rotate-webhook-secret
+// @Param X-Api-Key header string true "webhooks api key"
+// @Param id path string true "webhook id"
+// @Success 200 {object} WebhookResponse
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/webhooks/{id}/secret [post]
+func (c *Controller) RotateSecret(ctx *fiber.Ctx) error {
+	doc, err := c.srv.RotateSecret(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(toResponse(doc, true))
+}
+
+// Delete godoc
+// @Description Deletes a webhook.
+// @Tags deltaswapscan
+// @ID delete-webhook
+// @Param X-Api-Key header string true "webhooks api key"
+// @Param id path string true "webhook id"
+// @Success 204
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/webhooks/{id} [delete]
+func (c *Controller) Delete(ctx *fiber.Ctx) error {
+	if err := c.srv.Delete(ctx.Context(), ctx.Params("id")); err != nil {
+		return err
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// FindDeadLetters godoc
+// @Description Returns the deliveries of a webhook that failed after all the retries, newest first.
+// @Tags deltaswapscan
+// @ID get-webhook-dead-letters
+// @Param X-Api-Key header string true "webhooks api key"
+// @Param id path string true "webhook id"
+// @Param page query integer false "Page number."
+// @Param pageSize query integer false "Number of elements per page."
+// @Success 200 {object} DeadLettersResponse
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/webhooks/{id}/dead-letters [get]
+func (c *Controller) FindDeadLetters(ctx *fiber.Ctx) error {
+	p, err := middleware.ExtractPagination(ctx)
+	if err != nil {
+		return err
+	}
+	deadLetters, err := c.srv.FindDeadLetters(ctx.Context(), ctx.Params("id"), p)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(DeadLettersResponse{DeadLetters: deadLetters})
+}
+
+// parseRequest validates the request body and converts it to the service input.
+func (c *Controller) parseRequest(ctx *fiber.Ctx) (*webhooks.WebhookInput, error) {
+	var req WebhookRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return nil, response.NewRequestBodyError(ctx, "invalid webhook request, unable to parse", errors.WithStack(err))
+	}
+
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, response.NewRequestBodyError(ctx, "invalid webhook request, url must be an absolute http or https url", nil)
+	}
+
+	chainID := sdk.ChainID(req.EmitterChain)
+	if chainID == sdk.ChainIDUnset {
+		return nil, response.NewRequestBodyError(ctx, "invalid webhook request, emitterChain is required", nil)
+	}
+	emitter, err := types.StringToAddress(req.EmitterAddress, chainID == sdk.ChainIDSolana)
+	if err != nil {
+		return nil, response.NewRequestBodyError(ctx, "invalid webhook request, invalid emitterAddr", errors.WithStack(err))
+	}
+
+	if len(req.Events) == 0 {
+		return nil, response.NewRequestBodyError(ctx, "invalid webhook request, events is empty", nil)
+	}
+	for _, e := range req.Events {
+		if e != repository.WebhookEventVaaSigned && e != repository.WebhookEventTxConfirmed {
+			return nil, response.NewRequestBodyError(ctx, "invalid webhook request, unknown event "+e, nil)
+		}
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	return &webhooks.WebhookInput{
+		URL:            req.URL,
+		EmitterChain:   chainID,
+		EmitterAddress: emitter.Hex(),
+		Events:         req.Events,
+		Enabled:        enabled,
+	}, nil
+}
+
+func toResponse(doc *repository.WebhookDoc, withSecret bool) *WebhookResponse {
+	res := WebhookResponse{
+		ID:             doc.ID,
+		URL:            doc.URL,
+		EmitterChain:   doc.EmitterChain,
+		EmitterAddress: doc.EmitterAddress,
+		Events:         doc.Events,
+		Enabled:        doc.Enabled,
+		CreatedAt:      doc.CreatedAt,
+		UpdatedAt:      doc.UpdatedAt,
+	}
+	if withSecret {
+		res.Secret = doc.Secret
+	}
+	return &res
+}
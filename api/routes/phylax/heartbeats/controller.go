@@ -1,10 +1,12 @@
 package heartbeats
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/heartbeats"
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/phylax"
+	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
 	"github.com/deltaswapio/deltaswap-explorer/api/response"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -12,17 +14,17 @@ import (
 
 // Controller definition.
 type Controller struct {
-	srv    *heartbeats.Service
-	logger *zap.Logger
-	gs     phylax.PhylaxSet
+	srv       *heartbeats.Service
+	phylaxSrv *phylax.Service
+	logger    *zap.Logger
 }
 
 // NewController create a new controler.
-func NewController(srv *heartbeats.Service, logger *zap.Logger, p2pNetwork string) *Controller {
+func NewController(srv *heartbeats.Service, phylaxSrv *phylax.Service, logger *zap.Logger) *Controller {
 	return &Controller{
-		srv:    srv,
-		logger: logger.With(zap.String("module", "HeartbeatsController")),
-		gs:     phylax.GetByEnv(p2pNetwork),
+		srv:       srv,
+		phylaxSrv: phylaxSrv,
+		logger:    logger.With(zap.String("module", "HeartbeatsController")),
 	}
 }
 
@@ -66,8 +68,9 @@ type HeartbeatNetworkResponse struct {
 // @Router /v1/heartbeats [get]
 func (c *Controller) GetLastHeartbeats(ctx *fiber.Ctx) error {
 
-	// check phylaxSet exists.
-	if len(c.gs.GstByIndex) == 0 {
+	// get the latest phylaxSet.
+	phylaxSet, err := c.phylaxSrv.GetCurrentPhylaxSet(ctx.Context())
+	if errors.Is(err, errs.ErrNotFound) {
 		err := response.NewApiError(
 			ctx,
 			fiber.StatusServiceUnavailable,
@@ -77,13 +80,12 @@ func (c *Controller) GetLastHeartbeats(ctx *fiber.Ctx) error {
 		)
 		return err
 	}
-
-	// get the latest phylaxSet.
-	phylaxSet := c.gs.GetLatest()
-	phylaxAddresses := phylaxSet.KeysAsHexStrings()
+	if err != nil {
+		return err
+	}
 
 	// get last heartbeats by ids.
-	heartbeats, err := c.srv.GetHeartbeatsByIds(ctx.Context(), phylaxAddresses)
+	heartbeats, err := c.srv.GetHeartbeatsByIds(ctx.Context(), phylaxSet.Keys)
 	if err != nil {
 		return err
 	}
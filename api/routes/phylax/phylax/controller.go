@@ -1,7 +1,10 @@
 package phylax
 
 import (
+	"errors"
+
 	"github.com/deltaswapio/deltaswap-explorer/api/handlers/phylax"
+	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
 	"github.com/deltaswapio/deltaswap-explorer/api/response"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -9,13 +12,13 @@ import (
 
 // Controller definition.
 type Controller struct {
-	gs     phylax.PhylaxSet
+	srv    *phylax.Service
 	logger *zap.Logger
 }
 
 // NewController create a new controler.
-func NewController(logger *zap.Logger, p2pNetwork string) *Controller {
-	return &Controller{gs: phylax.GetByEnv(p2pNetwork),
+func NewController(srv *phylax.Service, logger *zap.Logger) *Controller {
+	return &Controller{srv: srv,
 		logger: logger.With(zap.String("module", "PhylaxController"))}
 }
 
@@ -39,26 +42,21 @@ type PhylaxSet struct {
 // @Failure 500
 // @Router /v1/phylaxset/current [get]
 func (c *Controller) GetPhylaxSet(ctx *fiber.Ctx) error {
-	// check phylaxSet exists.
-	if len(c.gs.GstByIndex) == 0 {
+	// get lasted phylaxSet.
+	phylaxSet, err := c.srv.GetCurrentPhylaxSet(ctx.Context())
+	if errors.Is(err, errs.ErrNotFound) {
 		return response.NewApiError(ctx, fiber.StatusServiceUnavailable, response.Unavailable,
 			"phylax set not fetched from chain yet", nil)
 	}
-
-	// get lasted phylaxSet.
-	guardinSet := c.gs.GetLatest()
-
-	// get phylax addresses.
-	addresses := make([]string, len(guardinSet.Keys))
-	for i, v := range guardinSet.Keys {
-		addresses[i] = v.Hex()
+	if err != nil {
+		return err
 	}
 
 	// create response.
 	response := PhylaxSetResponse{
 		PhylaxSet: PhylaxSet{
-			Index:     guardinSet.Index,
-			Addresses: addresses,
+			Index:     phylaxSet.Index,
+			Addresses: phylaxSet.Keys,
 		},
 	}
 	return ctx.Status(fiber.StatusOK).JSON(response)
@@ -3,6 +3,7 @@ package phylax
 import (
 	govsvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/governor"
 	heartbeatssvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/heartbeats"
+	phylaxsvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/phylax"
 	vaasvc "github.com/deltaswapio/deltaswap-explorer/api/handlers/vaa"
 	"github.com/deltaswapio/deltaswap-explorer/api/internal/config"
 	"github.com/deltaswapio/deltaswap-explorer/api/routes/phylax/governor"
@@ -21,13 +22,14 @@ func RegisterRoutes(
 	vaaService *vaasvc.Service,
 	governorService *govsvc.Service,
 	heartbeatsService *heartbeatssvc.Service,
+	phylaxService *phylaxsvc.Service,
 ) {
 
 	// Set up controllers
 	vaaCtrl := vaa.NewController(vaaService, rootLogger)
 	governorCtrl := governor.NewController(governorService, rootLogger)
-	phylaxCtrl := phylax.NewController(rootLogger, cfg.P2pNetwork)
-	heartbeatsCtrl := heartbeats.NewController(heartbeatsService, rootLogger, cfg.P2pNetwork)
+	phylaxCtrl := phylax.NewController(phylaxService, rootLogger)
+	heartbeatsCtrl := heartbeats.NewController(heartbeatsService, phylaxService, rootLogger)
 
 	// Set up route handlers
 	apiV1 := app.Group("/v1")
@@ -13,6 +13,7 @@ import (
 	vaaservice "github.com/deltaswapio/deltaswap-explorer/api/handlers/vaa"
 	errs "github.com/deltaswapio/deltaswap-explorer/api/internal/errors"
 	"github.com/deltaswapio/deltaswap-explorer/api/types"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
 	gossipv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/gossip/v1"
 	publicrpcv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/publicrpc/v1"
 	"github.com/deltaswapio/deltaswap/sdk/vaa"
@@ -24,16 +25,16 @@ import (
 // Handler rpc handler.
 type Handler struct {
 	publicrpcv1.UnimplementedPublicRPCServiceServer
-	gs     phylax.PhylaxSet
-	vaaSrv *vaaservice.Service
-	hbSrv  *heartbeats.Service
-	govSrv *governor.Service
-	logger *zap.Logger
+	vaaSrv    *vaaservice.Service
+	hbSrv     *heartbeats.Service
+	govSrv    *governor.Service
+	phylaxSrv *phylax.Service
+	logger    *zap.Logger
 }
 
 // NewHandler create a new rpc Handler.
-func NewHandler(vaaSrv *vaaservice.Service, hbSrv *heartbeats.Service, govSrv *governor.Service, logger *zap.Logger, p2pNetwork string) *Handler {
-	return &Handler{gs: phylax.GetByEnv(p2pNetwork), vaaSrv: vaaSrv, hbSrv: hbSrv, govSrv: govSrv, logger: logger}
+func NewHandler(vaaSrv *vaaservice.Service, hbSrv *heartbeats.Service, govSrv *governor.Service, phylaxSrv *phylax.Service, logger *zap.Logger) *Handler {
+	return &Handler{vaaSrv: vaaSrv, hbSrv: hbSrv, govSrv: govSrv, phylaxSrv: phylaxSrv, logger: logger}
 }
 
 // GetSignedVAA get signedVAA by chainID, address, sequence.
@@ -94,17 +95,14 @@ func (h *Handler) GetSignedBatchVAA(ctx context.Context, _ *publicrpcv1.GetSigne
 
 // GetLastHeartbeats get last heartbeats.
 func (h *Handler) GetLastHeartbeats(ctx context.Context, request *publicrpcv1.GetLastHeartbeatsRequest) (*publicrpcv1.GetLastHeartbeatsResponse, error) {
-	// check phylaxSet exists.
-	if len(h.gs.GstByIndex) == 0 {
-		return nil, status.Error(codes.Unavailable, "phylax set not fetched from chain yet")
-	}
-
 	// get lasted phylaxSet.
-	phylaxSet := h.gs.GetLatest()
-	phylaxAddresses := phylaxSet.KeysAsHexStrings()
+	phylaxSet, err := h.getCurrentPhylaxSet(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// get last heartbeats by ids.
-	heartbeats, err := h.hbSrv.GetHeartbeatsByIds(ctx, phylaxAddresses)
+	heartbeats, err := h.hbSrv.GetHeartbeatsByIds(ctx, phylaxSet.Keys)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
@@ -147,27 +145,32 @@ func (h *Handler) GetLastHeartbeats(ctx context.Context, request *publicrpcv1.Ge
 
 // GetCurrentPhylaxSet get current phylax set.
 func (h *Handler) GetCurrentPhylaxSet(ctx context.Context, request *publicrpcv1.GetCurrentPhylaxSetRequest) (*publicrpcv1.GetCurrentPhylaxSetResponse, error) {
-	// check phylaxSet exists.
-	if len(h.gs.GstByIndex) == 0 {
-		return nil, status.Error(codes.Unavailable, "phylax set not fetched from chain yet")
-	}
 	// get lasted phylaxSet.
-	guardinSet := h.gs.GetLatest()
-
-	// get phylax addresses.
-	addresses := make([]string, len(guardinSet.Keys))
-	for i, v := range guardinSet.Keys {
-		addresses[i] = v.Hex()
+	phylaxSet, err := h.getCurrentPhylaxSet(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return &publicrpcv1.GetCurrentPhylaxSetResponse{
 		PhylaxSet: &publicrpcv1.PhylaxSet{
-			Index:     guardinSet.Index,
-			Addresses: addresses,
+			Index:     phylaxSet.Index,
+			Addresses: phylaxSet.Keys,
 		},
 	}, nil
 }
 
+// getCurrentPhylaxSet get the current phylax set mapping errors to grpc status.
+func (h *Handler) getCurrentPhylaxSet(ctx context.Context) (*repository.PhylaxSetDoc, error) {
+	phylaxSet, err := h.phylaxSrv.GetCurrentPhylaxSet(ctx)
+	if errors.Is(err, errs.ErrNotFound) {
+		return nil, status.Error(codes.Unavailable, "phylax set not fetched from chain yet")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	return phylaxSet, nil
+}
+
 // GovernorGetAvailableNotionalByChain get availableNotional.
 func (h *Handler) GovernorGetAvailableNotionalByChain(ctx context.Context, _ *publicrpcv1.GovernorGetAvailableNotionalByChainRequest) (*publicrpcv1.GovernorGetAvailableNotionalByChainResponse, error) {
 	availableNotional, err := h.govSrv.GetAvailNotionByChain(ctx)
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// knownEmitter is an emitter with a decoder in the registry.
+type knownEmitter struct {
+	ChainID sdk.ChainID
+	Address string
+	AppID   string
+}
+
+// mainnetEmitters are the token bridge and nft bridge deployments of the network, the same listed in
+// TOKEN_BRIDGE_EMITTERS and NFT_BRIDGE_EMITTERS of event-watcher/src/common/src/consts.ts.
+var mainnetEmitters = []knownEmitter{
+	{ChainID: sdk.ChainIDSolana, Address: "ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDEthereum, Address: "0x3ee18B2214AFF97000D974cf647E7C347E8fa585", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDPlanq, Address: "0x4FD8625cfE4B0034642140005b78291D26183df1", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDPolygon, Address: "0x5a58505a96D1dbf8dF91cB21B54419FC36e93fdE", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDBSC, Address: "0xC891aBa0b42818fb4c975Bf6461033c62BCE75ff", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDFantom, Address: "0x7C9Fc5741288cDFdD83CeB07f3ea7e22618D79D2", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDAvalanche, Address: "0x0e082F06FF657D94310cB8cE8B0D9a04541d8052", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDOasis, Address: "0x5848C791e09901b40A9Ef749f2a6735b418d7564", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDMoonbeam, Address: "0xb1731c586ca89a23809861c6103f0b96b3f57d92", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDCelo, Address: "0x796Dff6D74F3E27060B71255Fe517BFb23C93eed", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDBase, Address: "0x8d2de8d2f73F1F4cAB472AC9A881C9b123C79627", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDArbitrum, Address: "0x0b2402144Bb366A632D14B83F244D2e0e21bD39c", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDOptimism, Address: "0x1D68124e65faFC907325e3EDbF8c4d84499DAa8b", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDAptos, Address: "0000000000000000000000000000000000000000000000000000000000000001", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDAptos, Address: "0000000000000000000000000000000000000000000000000000000000000005", AppID: domain.AppIdPortalNftBridge},
+}
+
+// testnetEmitters are the token bridge deployments of the network, the same set as tokenBridgeAddress
+// of the chains in contract-watcher/config/chains/testnet.yaml.
+var testnetEmitters = []knownEmitter{
+	{ChainID: sdk.ChainIDEthereum, Address: "0xF890982f9310df57d00f659cf4fd87e65adEd8d7", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDPolygon, Address: "0x377D55a7928c046E18eEbb61977e714d2a76472a", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDBSC, Address: "0x9dcF9D205C9De35334D646BeE44b2D2859712A09", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDFantom, Address: "0x599CEa2204B4FaECd584Ab1F2b6aCA137a0afbE8", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDAvalanche, Address: "0x61E44E506Ca5659E6c0bba9b678586fA2d729756", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDOasis, Address: "0x88d8004A9BdbfD9D28090A02010C19897a29605c", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDMoonbeam, Address: "0xbc976D4b9D57E57c3cA52e1Fd136C45FF7955A96", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDCelo, Address: "0x05ca6037eC51F8b712eD2E6Fa72219FEaE74E153", AppID: domain.AppIdPortalTokenBridge},
+	{ChainID: sdk.ChainIDBase, Address: "0xA31aa3FDb7aF7Db93d18DDA4e19F811342EDF780", AppID: domain.AppIdPortalTokenBridge},
+}
+
+// Emitter identifies the contract that emits the VAAs of an application in a chain.
+type Emitter struct {
+	ChainID sdk.ChainID
+	Address string
+}
+
+// ParseEmitters parses a comma separated list of emitters with the format <chain id>/<address>,
+// e.g. "2/0x27428DD2d3DD32A4D7f7C497eAaa23130d894911,4/0x27428DD2d3DD32A4D7f7C497eAaa23130d894911".
+func ParseEmitters(s string) ([]Emitter, error) {
+	var emitters []Emitter
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		chain, address, ok := strings.Cut(item, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid emitter %s", item)
+		}
+		chainID, err := strconv.ParseUint(chain, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid emitter chain %s: %w", item, err)
+		}
+		if _, err := sdk.StringToAddress(strings.TrimPrefix(address, "0x")); err != nil {
+			return nil, fmt.Errorf("invalid emitter address %s: %w", item, err)
+		}
+		emitters = append(emitters, Emitter{ChainID: sdk.ChainID(chainID), Address: address})
+	}
+	return emitters, nil
+}
+
+// knownEmitters returns the emitters with a decoder for the p2p network.
+func knownEmitters(p2pNetwork string) []knownEmitter {
+	switch p2pNetwork {
+	case domain.P2pMainNet:
+		return mainnetEmitters
+	case domain.P2pTestNet:
+		return testnetEmitters
+	default:
+		return nil
+	}
+}
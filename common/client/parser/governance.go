@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+const (
+	// coreActionPhylaxSetUpgrade is the core bridge governance action for a phylax set upgrade.
+	coreActionPhylaxSetUpgrade uint8 = 2
+
+	// phylaxKeyLength is the length of a phylax key (an ethereum address).
+	phylaxKeyLength = 20
+)
+
+// Governance is the parsed payload of a governance VAA.
+type Governance struct {
+	Module         string      `json:"module" bson:"module"`
+	Action         uint8       `json:"type" bson:"type"`
+	Chain          sdk.ChainID `json:"chain" bson:"chain"`
+	NewPhylaxSetID *uint32     `json:"newPhylaxSetIndex,omitempty" bson:"newPhylaxSetIndex,omitempty"`
+	Keys           []string    `json:"keys,omitempty" bson:"keys,omitempty"`
+	Data           string      `json:"data,omitempty" bson:"data,omitempty"`
+}
+
+func decodeGovernance(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newPayloadReader(vaa.Payload)
+	module := r.next(32)
+	action := r.uint8()
+	chain := r.chainID()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	parsed := Governance{
+		Module: string(bytes.TrimLeft(module, "\x00")),
+		Action: action,
+		Chain:  chain,
+	}
+	if bytes.Equal(module, sdk.CoreModule) && action == coreActionPhylaxSetUpgrade {
+		index := r.uint32()
+		numKeys := int(r.uint8())
+		keys := make([]string, 0, numKeys)
+		for i := 0; i < numKeys; i++ {
+			keys = append(keys, "0x"+hex.EncodeToString(r.next(phylaxKeyLength)))
+		}
+		if r.err != nil {
+			return nil, fmt.Errorf("invalid phylax set upgrade: %w", r.err)
+		}
+		parsed.NewPhylaxSetID = &index
+		parsed.Keys = keys
+	} else {
+		parsed.Data = hex.EncodeToString(r.rest())
+	}
+
+	return &ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload: parsed,
+		StandardizedProperties: StandardizedProperties{
+			AppIds:    []string{domain.AppIdCoreGovernance},
+			FromChain: vaa.EmitterChain,
+			ToChain:   chain,
+		},
+	}, nil
+}
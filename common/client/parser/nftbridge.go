@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// nftBridgeTransfer is the payload type of a NFT bridge transfer.
+const nftBridgeTransfer uint8 = 1
+
+// NftBridgeTransfer is the parsed payload of a NFT bridge transfer.
+type NftBridgeTransfer struct {
+	PayloadID  uint8       `json:"payloadId" bson:"payloadId"`
+	NftAddress string      `json:"tokenAddress" bson:"tokenAddress"`
+	NftChain   sdk.ChainID `json:"tokenChain" bson:"tokenChain"`
+	Symbol     string      `json:"symbol" bson:"symbol"`
+	Name       string      `json:"name" bson:"name"`
+	TokenID    string      `json:"tokenId" bson:"tokenId"`
+	URI        string      `json:"uri" bson:"uri"`
+	ToAddress  string      `json:"toAddress" bson:"toAddress"`
+	ToChain    sdk.ChainID `json:"chain" bson:"chain"`
+}
+
+func decodeNftBridge(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newPayloadReader(vaa.Payload)
+	payloadID := r.uint8()
+	if r.err == nil && payloadID != nftBridgeTransfer {
+		return nil, fmt.Errorf("unknown nft bridge payload type %d", payloadID)
+	}
+	nftAddress := r.address()
+	nftChain := r.chainID()
+	symbol := r.next(32)
+	name := r.next(32)
+	tokenID := r.uint256()
+	uri := r.next(int(r.uint8()))
+	toAddress := r.address()
+	toChain := r.chainID()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload: NftBridgeTransfer{
+			PayloadID:  payloadID,
+			NftAddress: toHexAddress(nftAddress),
+			NftChain:   nftChain,
+			Symbol:     toFixedString(symbol),
+			Name:       toFixedString(name),
+			TokenID:    tokenID.String(),
+			URI:        string(uri),
+			ToAddress:  toHexAddress(toAddress),
+			ToChain:    toChain,
+		},
+		StandardizedProperties: StandardizedProperties{
+			AppIds:       []string{domain.AppIdPortalNftBridge},
+			FromChain:    vaa.EmitterChain,
+			ToChain:      toChain,
+			ToAddress:    toNativeAddress(toChain, toAddress),
+			TokenChain:   nftChain,
+			TokenAddress: toNativeAddress(nftChain, nftAddress),
+			Amount:       "1",
+		},
+	}, nil
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// payloadReader reads big-endian encoded fields from a VAA payload.
+// The first read error is kept and every subsequent read returns a zero value.
+type payloadReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func newPayloadReader(data []byte) *payloadReader {
+	return &payloadReader{data: data}
+}
+
+func (r *payloadReader) next(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	if n < 0 || r.offset+n > len(r.data) {
+		r.err = fmt.Errorf("payload too short: reading %d bytes at offset %d of %d", n, r.offset, len(r.data))
+		return make([]byte, n)
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b
+}
+
+func (r *payloadReader) uint8() uint8 {
+	return r.next(1)[0]
+}
+
+func (r *payloadReader) uint16() uint16 {
+	return binary.BigEndian.Uint16(r.next(2))
+}
+
+func (r *payloadReader) uint32() uint32 {
+	return binary.BigEndian.Uint32(r.next(4))
+}
+
+func (r *payloadReader) chainID() sdk.ChainID {
+	return sdk.ChainID(r.uint16())
+}
+
+func (r *payloadReader) uint256() *big.Int {
+	return new(big.Int).SetBytes(r.next(32))
+}
+
+func (r *payloadReader) address() sdk.Address {
+	var addr sdk.Address
+	copy(addr[:], r.next(32))
+	return addr
+}
+
+func (r *payloadReader) bytes(n int) []byte {
+	b := r.next(n)
+	return append([]byte(nil), b...)
+}
+
+// rest returns the unread bytes of the payload.
+func (r *payloadReader) rest() []byte {
+	if r.err != nil {
+		return nil
+	}
+	return r.bytes(len(r.data) - r.offset)
+}
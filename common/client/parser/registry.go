@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Decoder decodes the payload of a VAA into a parsed payload and its standardized properties.
+type Decoder interface {
+	Decode(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error)
+}
+
+// DecoderFunc is an adapter to use a function as a Decoder.
+type DecoderFunc func(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error)
+
+// Decode calls f(vaa).
+func (f DecoderFunc) Decode(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error) {
+	return f(vaa)
+}
+
+// Registry is an in-process VAA payload parser. It selects a Decoder by the VAA emitter
+// and falls back to the vaa-payload-parser service for emitters it doesn't know.
+type Registry struct {
+	mu       sync.RWMutex
+	decoders map[string]Decoder
+	fallback ParseVaaFunc
+	logger   *zap.Logger
+}
+
+// NewRegistry creates a registry with the decoders for the known emitters of the p2p network.
+// fallback can be nil, in which case VAAs from unknown emitters return ErrNotFound.
+func NewRegistry(p2pNetwork string, fallback ParseVaaFunc, logger *zap.Logger, opts ...RegistryOption) *Registry {
+	r := &Registry{
+		decoders: make(map[string]Decoder),
+		fallback: fallback,
+		logger:   logger,
+	}
+
+	r.Register(sdk.GovernanceChain, sdk.GovernanceEmitter.String(), DecoderFunc(decodeGovernance))
+	for _, e := range knownEmitters(p2pNetwork) {
+		decoder, ok := decodersByAppID[e.AppID]
+		if !ok {
+			logger.Warn("no decoder for known emitter", zap.String("appId", e.AppID))
+			continue
+		}
+		r.Register(e.ChainID, e.Address, decoder)
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RegistryOption represents a registry option function.
+type RegistryOption func(*Registry)
+
+// WithGenericRelayerEmitters registers the generic relayer decoder for the emitters.
+// The generic relayer emitters are not known by the registry, they are set in the configuration of the services.
+func WithGenericRelayerEmitters(emitters []Emitter) RegistryOption {
+	return func(r *Registry) {
+		for _, e := range emitters {
+			r.Register(e.ChainID, e.Address, DecoderFunc(decodeGenericRelayer))
+		}
+	}
+}
+
+// NewParseVaaFunc creates a function to parse VAA payloads with the in-process decoders.
+// The vaa-payload-parser service is used as a fallback for unknown emitters when its URL is set.
+func NewParseVaaFunc(p2pNetwork, fallbackURL string, timeout int64, logger *zap.Logger, opts ...RegistryOption) (ParseVaaFunc, error) {
+	var fallback ParseVaaFunc
+	if fallbackURL != "" {
+		client, err := NewParserVAAAPIClient(timeout, fallbackURL, logger)
+		if err != nil {
+			return nil, err
+		}
+		fallback = client.ParseVaaWithStandarizedProperties
+	}
+	return NewRegistry(p2pNetwork, fallback, logger, opts...).ParseVaaWithStandarizedProperties, nil
+}
+
+// Register sets the decoder for the VAAs emitted by the given chain and emitter address (hex encoded, 32 bytes).
+func (r *Registry) Register(chainID sdk.ChainID, emitterAddress string, decoder Decoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decoders[emitterKey(chainID, emitterAddress)] = decoder
+}
+
+// ParseVaaWithStandarizedProperties parses a VAA with the decoder registered for its emitter.
+func (r *Registry) ParseVaaWithStandarizedProperties(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r.mu.RLock()
+	decoder, ok := r.decoders[emitterKey(vaa.EmitterChain, vaa.EmitterAddress.String())]
+	r.mu.RUnlock()
+
+	if !ok {
+		if r.fallback == nil {
+			return nil, ErrNotFound
+		}
+		return r.fallback(vaa)
+	}
+
+	result, err := decoder.Decode(vaa)
+	if err != nil {
+		r.logger.Debug("error decoding vaa payload",
+			zap.String("id", vaa.MessageID()),
+			zap.Error(err))
+		return nil, fmt.Errorf("%w: %s", ErrUnproceesableEntity, err.Error())
+	}
+	return result, nil
+}
+
+func emitterKey(chainID sdk.ChainID, emitterAddress string) string {
+	emitterAddress = strings.ToLower(strings.TrimPrefix(emitterAddress, "0x"))
+	if len(emitterAddress) < 64 {
+		emitterAddress = strings.Repeat("0", 64-len(emitterAddress)) + emitterAddress
+	}
+	return fmt.Sprintf("%d/%s", chainID, emitterAddress)
+}
+
+// decodersByAppID maps the app IDs of the known emitters to their decoders.
+var decodersByAppID = map[string]Decoder{
+	domain.AppIdPortalTokenBridge: DecoderFunc(decodeTokenBridge),
+	domain.AppIdPortalNftBridge:   DecoderFunc(decodeNftBridge),
+}
+
+// toNativeAddress encodes a deltaswap address in the native format of the chain.
+// If the chain doesn't have a known native format the hex encoding is returned.
+func toNativeAddress(chainID sdk.ChainID, addr sdk.Address) string {
+	native, err := domain.TranslateEmitterAddress(chainID, addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return native
+}
+
+// toHexAddress encodes a deltaswap address as a 0x prefixed hex string.
+func toHexAddress(addr sdk.Address) string {
+	return "0x" + hex.EncodeToString(addr[:])
+}
+
+// toFixedString decodes a fixed size string padded with zeros.
+func toFixedString(b []byte) string {
+	return strings.TrimRight(string(b), "\x00")
+}
@@ -0,0 +1,208 @@
+package parser
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func mustAddress(t *testing.T, s string) sdk.Address {
+	var addr sdk.Address
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) > len(addr) {
+		t.Fatalf("invalid address %s", s)
+	}
+	copy(addr[len(addr)-len(b):], b)
+	return addr
+}
+
+func mustPayload(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("invalid payload %s", s)
+	}
+	return b
+}
+
+// TestRegistryTokenBridgeTransfer test the decoding of a token bridge transfer.
+func TestRegistryTokenBridgeTransfer(t *testing.T) {
+	registry := NewRegistry(domain.P2pMainNet, nil, zap.NewNop())
+
+	payload := "01" +
+		"0000000000000000000000000000000000000000000000000000000000989680" + // amount
+		"000000000000000000000000dac17f958d2ee523a2206206994597c13d831ec7" + // token address
+		"0002" + // token chain
+		"0000000000000000000000000ff664edd699bd85610c2782d9dbbbad704b6fc5" + // to address
+		"0005" + // to chain
+		"0000000000000000000000000000000000000000000000000000000000000000" // fee
+	vaa := &sdk.VAA{
+		EmitterChain:   sdk.ChainIDBSC,
+		EmitterAddress: mustAddress(t, "c891aba0b42818fb4c975bf6461033c62bce75ff"),
+		Sequence:       1,
+		Payload:        mustPayload(t, payload),
+	}
+
+	result, err := registry.ParseVaaWithStandarizedProperties(vaa)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	transfer, ok := result.ParsedPayload.(TokenBridgeTransfer)
+	if !ok {
+		t.Fatalf("unexpected parsed payload %T", result.ParsedPayload)
+	}
+	if transfer.Amount != "10000000" || transfer.TokenChain != sdk.ChainIDEthereum || transfer.ToChain != sdk.ChainIDPolygon {
+		t.Errorf("unexpected parsed payload %+v", transfer)
+	}
+	if transfer.TokenAddress != "0x000000000000000000000000dac17f958d2ee523a2206206994597c13d831ec7" {
+		t.Errorf("unexpected token address %s", transfer.TokenAddress)
+	}
+
+	props := result.StandardizedProperties
+	if len(props.AppIds) != 1 || props.AppIds[0] != domain.AppIdPortalTokenBridge {
+		t.Errorf("unexpected app ids %v", props.AppIds)
+	}
+	if props.FromChain != sdk.ChainIDBSC || props.ToChain != sdk.ChainIDPolygon || props.Amount != "10000000" {
+		t.Errorf("unexpected standardized properties %+v", props)
+	}
+	if props.Fee != "" {
+		t.Errorf("unexpected fee %s", props.Fee)
+	}
+}
+
+// TestRegistryTokenBridgeAttestation test the decoding of a token bridge attestation.
+func TestRegistryTokenBridgeAttestation(t *testing.T) {
+	registry := NewRegistry(domain.P2pMainNet, nil, zap.NewNop())
+
+	payload := "02" +
+		"000000000000000000000000dac17f958d2ee523a2206206994597c13d831ec7" + // token address
+		"0002" + // token chain
+		"06" + // decimals
+		hex.EncodeToString([]byte("USDT")) + "00000000000000000000000000000000000000000000000000000000" + // symbol
+		hex.EncodeToString([]byte("Tether USD")) + "00000000000000000000000000000000000000000000" // name
+	vaa := &sdk.VAA{
+		EmitterChain:   sdk.ChainIDEthereum,
+		EmitterAddress: mustAddress(t, "3ee18b2214aff97000d974cf647e7c347e8fa585"),
+		Payload:        mustPayload(t, payload),
+	}
+
+	result, err := registry.ParseVaaWithStandarizedProperties(vaa)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	attestation, ok := result.ParsedPayload.(TokenBridgeAttestation)
+	if !ok {
+		t.Fatalf("unexpected parsed payload %T", result.ParsedPayload)
+	}
+	if attestation.Decimals != 6 || attestation.Symbol != "USDT" || attestation.Name != "Tether USD" {
+		t.Errorf("unexpected parsed payload %+v", attestation)
+	}
+}
+
+// TestRegistryGenericRelayer test the decoding of a generic relayer delivery instruction.
+func TestRegistryGenericRelayer(t *testing.T) {
+	emitters, err := ParseEmitters("2/0x27428DD2d3DD32A4D7f7C497eAaa23130d894911, 5/0x27428DD2d3DD32A4D7f7C497eAaa23130d894911")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	registry := NewRegistry(domain.P2pMainNet, nil, zap.NewNop(), WithGenericRelayerEmitters(emitters))
+
+	payload := "01" +
+		"0005" + // target chain
+		"0000000000000000000000000ff664edd699bd85610c2782d9dbbbad704b6fc5" + // target address
+		"00000002" + "abcd" + // payload
+		"0000000000000000000000000000000000000000000000000000000000000000" + // requested receiver value
+		"0000000000000000000000000000000000000000000000000000000000000064" + // extra receiver value
+		"00000001" + "01" + // execution info
+		"0005" + // refund chain
+		"0000000000000000000000000ff664edd699bd85610c2782d9dbbbad704b6fc5" + // refund address
+		"0000000000000000000000007a0a53847776f7e94cc35742971acb2217b0db81" + // refund delivery provider
+		"0000000000000000000000007a0a53847776f7e94cc35742971acb2217b0db81" + // source delivery provider
+		"000000000000000000000000dac17f958d2ee523a2206206994597c13d831ec7" // sender address
+	vaa := &sdk.VAA{
+		EmitterChain:   sdk.ChainIDEthereum,
+		EmitterAddress: mustAddress(t, "27428dd2d3dd32a4d7f7c497eaaa23130d894911"),
+		Sequence:       1,
+		Payload:        mustPayload(t, payload),
+	}
+
+	result, err := registry.ParseVaaWithStandarizedProperties(vaa)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	instruction, ok := result.ParsedPayload.(DeliveryInstruction)
+	if !ok {
+		t.Fatalf("unexpected parsed payload %T", result.ParsedPayload)
+	}
+	if instruction.TargetChainID != sdk.ChainIDPolygon || instruction.Payload != "abcd" || instruction.ExtraReceiverValue != "100" {
+		t.Errorf("unexpected parsed payload %+v", instruction)
+	}
+
+	props := result.StandardizedProperties
+	if len(props.AppIds) != 1 || props.AppIds[0] != domain.AppIdGenericRelayer {
+		t.Errorf("unexpected app ids %v", props.AppIds)
+	}
+	if props.FromChain != sdk.ChainIDEthereum || props.ToChain != sdk.ChainIDPolygon {
+		t.Errorf("unexpected standardized properties %+v", props)
+	}
+}
+
+// TestParseEmitters test the parsing of the emitters set in the configuration.
+func TestParseEmitters(t *testing.T) {
+	emitters, err := ParseEmitters("")
+	if err != nil || len(emitters) != 0 {
+		t.Errorf("unexpected emitters %v, error %v", emitters, err)
+	}
+
+	for _, s := range []string{"2", "x/0x27428DD2d3DD32A4D7f7C497eAaa23130d894911", "70000/0x27428DD2d3DD32A4D7f7C497eAaa23130d894911", "2/0xzz"} {
+		if _, err := ParseEmitters(s); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+}
+
+// TestRegistryInvalidPayload test a known emitter with a truncated payload.
+func TestRegistryInvalidPayload(t *testing.T) {
+	registry := NewRegistry(domain.P2pMainNet, nil, zap.NewNop())
+
+	vaa := &sdk.VAA{
+		EmitterChain:   sdk.ChainIDEthereum,
+		EmitterAddress: mustAddress(t, "3ee18b2214aff97000d974cf647e7c347e8fa585"),
+		Payload:        mustPayload(t, "0100"),
+	}
+
+	_, err := registry.ParseVaaWithStandarizedProperties(vaa)
+	if !errors.Is(err, ErrUnproceesableEntity) {
+		t.Errorf("expected error %v, got %v", ErrUnproceesableEntity, err)
+	}
+}
+
+// TestRegistryFallback test the fallback for unknown emitters.
+func TestRegistryFallback(t *testing.T) {
+	vaa := &sdk.VAA{
+		EmitterChain:   sdk.ChainIDEthereum,
+		EmitterAddress: mustAddress(t, "01"),
+		Payload:        mustPayload(t, "01"),
+	}
+
+	registry := NewRegistry(domain.P2pMainNet, nil, zap.NewNop())
+	if _, err := registry.ParseVaaWithStandarizedProperties(vaa); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected error %v, got %v", ErrNotFound, err)
+	}
+
+	called := false
+	fallback := func(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error) {
+		called = true
+		return &ParseVaaWithStandarizedPropertiesdResponse{}, nil
+	}
+	registry = NewRegistry(domain.P2pMainNet, fallback, zap.NewNop())
+	if _, err := registry.ParseVaaWithStandarizedProperties(vaa); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if !called {
+		t.Error("expected fallback to be called")
+	}
+}
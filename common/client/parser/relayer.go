@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// relayerDeliveryInstruction is the payload type of a generic relayer delivery instruction.
+const relayerDeliveryInstruction uint8 = 1
+
+// DeliveryInstruction is the parsed payload of a generic relayer delivery instruction.
+type DeliveryInstruction struct {
+	PayloadID              uint8       `json:"payloadId" bson:"payloadId"`
+	TargetChainID          sdk.ChainID `json:"targetChainId" bson:"targetChainId"`
+	TargetAddress          string      `json:"targetAddress" bson:"targetAddress"`
+	Payload                string      `json:"payload" bson:"payload"`
+	RequestedReceiverValue string      `json:"requestedReceiverValue" bson:"requestedReceiverValue"`
+	ExtraReceiverValue     string      `json:"extraReceiverValue" bson:"extraReceiverValue"`
+	EncodedExecutionInfo   string      `json:"encodedExecutionInfo" bson:"encodedExecutionInfo"`
+	RefundChainID          sdk.ChainID `json:"refundChainId" bson:"refundChainId"`
+	RefundAddress          string      `json:"refundAddress" bson:"refundAddress"`
+	RefundDeliveryProvider string      `json:"refundDeliveryProvider" bson:"refundDeliveryProvider"`
+	SourceDeliveryProvider string      `json:"sourceDeliveryProvider" bson:"sourceDeliveryProvider"`
+	SenderAddress          string      `json:"senderAddress" bson:"senderAddress"`
+}
+
+func decodeGenericRelayer(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newPayloadReader(vaa.Payload)
+	payloadID := r.uint8()
+	if r.err == nil && payloadID != relayerDeliveryInstruction {
+		return nil, fmt.Errorf("unsupported generic relayer payload type %d", payloadID)
+	}
+	targetChain := r.chainID()
+	targetAddress := r.address()
+	payload := r.next(int(r.uint32()))
+	requestedReceiverValue := r.uint256()
+	extraReceiverValue := r.uint256()
+	executionInfo := r.next(int(r.uint32()))
+	refundChain := r.chainID()
+	refundAddress := r.address()
+	refundDeliveryProvider := r.address()
+	sourceDeliveryProvider := r.address()
+	senderAddress := r.address()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload: DeliveryInstruction{
+			PayloadID:              payloadID,
+			TargetChainID:          targetChain,
+			TargetAddress:          toHexAddress(targetAddress),
+			Payload:                hex.EncodeToString(payload),
+			RequestedReceiverValue: requestedReceiverValue.String(),
+			ExtraReceiverValue:     extraReceiverValue.String(),
+			EncodedExecutionInfo:   hex.EncodeToString(executionInfo),
+			RefundChainID:          refundChain,
+			RefundAddress:          toHexAddress(refundAddress),
+			RefundDeliveryProvider: toHexAddress(refundDeliveryProvider),
+			SourceDeliveryProvider: toHexAddress(sourceDeliveryProvider),
+			SenderAddress:          toHexAddress(senderAddress),
+		},
+		StandardizedProperties: StandardizedProperties{
+			AppIds:      []string{domain.AppIdGenericRelayer},
+			FromChain:   vaa.EmitterChain,
+			FromAddress: toNativeAddress(vaa.EmitterChain, senderAddress),
+			ToChain:     targetChain,
+			ToAddress:   toNativeAddress(targetChain, targetAddress),
+		},
+	}, nil
+}
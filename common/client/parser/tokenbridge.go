@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// token bridge payload types.
+const (
+	tokenBridgeTransfer            uint8 = 1
+	tokenBridgeAttestMeta          uint8 = 2
+	tokenBridgeTransferWithPayload uint8 = 3
+)
+
+// TokenBridgeTransfer is the parsed payload of a token bridge transfer (payload 1)
+// or a token bridge transfer with payload (payload 3).
+type TokenBridgeTransfer struct {
+	PayloadID    uint8       `json:"payloadId" bson:"payloadId"`
+	Amount       string      `json:"amount" bson:"amount"`
+	TokenAddress string      `json:"tokenAddress" bson:"tokenAddress"`
+	TokenChain   sdk.ChainID `json:"tokenChain" bson:"tokenChain"`
+	ToAddress    string      `json:"toAddress" bson:"toAddress"`
+	ToChain      sdk.ChainID `json:"chain" bson:"chain"`
+	Fee          string      `json:"fee,omitempty" bson:"fee,omitempty"`
+	FromAddress  string      `json:"fromAddress,omitempty" bson:"fromAddress,omitempty"`
+	Payload      string      `json:"payload,omitempty" bson:"payload,omitempty"`
+}
+
+// TokenBridgeAttestation is the parsed payload of a token bridge attestation (payload 2).
+type TokenBridgeAttestation struct {
+	PayloadID    uint8       `json:"payloadId" bson:"payloadId"`
+	TokenAddress string      `json:"tokenAddress" bson:"tokenAddress"`
+	TokenChain   sdk.ChainID `json:"tokenChain" bson:"tokenChain"`
+	Decimals     uint8       `json:"decimals" bson:"decimals"`
+	Symbol       string      `json:"symbol" bson:"symbol"`
+	Name         string      `json:"name" bson:"name"`
+}
+
+func decodeTokenBridge(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error) {
+	if len(vaa.Payload) == 0 {
+		return nil, fmt.Errorf("empty token bridge payload")
+	}
+	switch payloadID := vaa.Payload[0]; payloadID {
+	case tokenBridgeTransfer, tokenBridgeTransferWithPayload:
+		return decodeTokenBridgeTransfer(vaa)
+	case tokenBridgeAttestMeta:
+		return decodeTokenBridgeAttestation(vaa)
+	default:
+		return nil, fmt.Errorf("unknown token bridge payload type %d", payloadID)
+	}
+}
+
+func decodeTokenBridgeTransfer(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newPayloadReader(vaa.Payload)
+	payloadID := r.uint8()
+	amount := r.uint256()
+	tokenAddress := r.address()
+	tokenChain := r.chainID()
+	toAddress := r.address()
+	toChain := r.chainID()
+	// payload 1 has a fee, payload 3 has the sender address in the same position.
+	feeOrFromAddress := r.bytes(32)
+	var payload []byte
+	if payloadID == tokenBridgeTransferWithPayload {
+		payload = r.rest()
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	parsed := TokenBridgeTransfer{
+		PayloadID:    payloadID,
+		Amount:       amount.String(),
+		TokenAddress: toHexAddress(tokenAddress),
+		TokenChain:   tokenChain,
+		ToAddress:    toHexAddress(toAddress),
+		ToChain:      toChain,
+	}
+	standardized := StandardizedProperties{
+		AppIds:       []string{domain.AppIdPortalTokenBridge},
+		FromChain:    vaa.EmitterChain,
+		ToChain:      toChain,
+		ToAddress:    toNativeAddress(toChain, toAddress),
+		TokenChain:   tokenChain,
+		TokenAddress: toNativeAddress(tokenChain, tokenAddress),
+		Amount:       amount.String(),
+	}
+
+	if payloadID == tokenBridgeTransfer {
+		fee := new(big.Int).SetBytes(feeOrFromAddress)
+		parsed.Fee = fee.String()
+		if fee.Sign() > 0 {
+			standardized.Fee = fee.String()
+			standardized.FeeChain = tokenChain
+			standardized.FeeAddress = standardized.TokenAddress
+		}
+	} else {
+		var fromAddress sdk.Address
+		copy(fromAddress[:], feeOrFromAddress)
+		parsed.FromAddress = toHexAddress(fromAddress)
+		parsed.Payload = hex.EncodeToString(payload)
+		standardized.FromAddress = toNativeAddress(vaa.EmitterChain, fromAddress)
+	}
+
+	return &ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload:          parsed,
+		StandardizedProperties: standardized,
+	}, nil
+}
+
+func decodeTokenBridgeAttestation(vaa *sdk.VAA) (*ParseVaaWithStandarizedPropertiesdResponse, error) {
+	r := newPayloadReader(vaa.Payload)
+	payloadID := r.uint8()
+	tokenAddress := r.address()
+	tokenChain := r.chainID()
+	decimals := r.uint8()
+	symbol := r.next(32)
+	name := r.next(32)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &ParseVaaWithStandarizedPropertiesdResponse{
+		ParsedPayload: TokenBridgeAttestation{
+			PayloadID:    payloadID,
+			TokenAddress: toHexAddress(tokenAddress),
+			TokenChain:   tokenChain,
+			Decimals:     decimals,
+			Symbol:       toFixedString(symbol),
+			Name:         toFixedString(name),
+		},
+		StandardizedProperties: StandardizedProperties{
+			AppIds:       []string{domain.AppIdPortalTokenBridge},
+			FromChain:    vaa.EmitterChain,
+			TokenChain:   tokenChain,
+			TokenAddress: toNativeAddress(tokenChain, tokenAddress),
+		},
+	}, nil
+}
@@ -10,6 +10,9 @@ const (
 const (
 	AppIdUnkonwn           = "UNKONWN"
 	AppIdPortalTokenBridge = "PORTAL_TOKEN_BRIDGE"
+	AppIdPortalNftBridge   = "PORTAL_NFT_BRIDGE"
+	AppIdGenericRelayer    = "GENERIC_RELAYER"
+	AppIdCoreGovernance    = "CORE_GOVERNANCE"
 )
 
 // SourceTxStatus is meant to be a user-facing enum that describes the status of the source transaction.
@@ -32,4 +35,6 @@ const (
 	DstTxStatusFailedToProcess = "failed"
 	DstTxStatusConfirmed       = "completed"
 	DstTxStatusUnkonwn         = "unknown"
+	// DstTxStatusOrphaned indicates that the destination transaction was removed from the chain by a reorg.
+	DstTxStatusOrphaned = "orphaned"
 )
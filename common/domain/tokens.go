@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"sync"
 
 	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
 )
@@ -24,52 +25,104 @@ type TokenMetadata struct {
 	Decimals    int64
 }
 
+// TokenProvider provides the metadata of the tokens supported by Portal Token Bridge.
+type TokenProvider interface {
+	GetAllTokens() []TokenMetadata
+	GetAllCoingeckoIDs() []string
+	GetTokenByCoingeckoID(coingeckoID string) (*TokenMetadata, bool)
+	GetTokenByAddress(tokenChain sdk.ChainID, tokenAddress string) (*TokenMetadata, bool)
+}
+
 var (
-	tokenMetadata              = generatedMainnetTokenList()
-	tokenMetadataByContractID  = make(map[string]*TokenMetadata)
-	tokenMetadataByCoingeckoID = make(map[string]*TokenMetadata)
+	// seedTokens is the generated token list, it is used until a token registry is set.
+	seedTokens = generatedMainnetTokenList()
+
+	tokenProviderMu sync.RWMutex
+	tokenProvider   TokenProvider = NewTokenList(seedTokens)
 )
 
 func (t *TokenMetadata) GetTokenID() string {
 	return fmt.Sprintf("%d/%s", t.TokenChain, t.TokenAddress)
 }
 
-func init() {
+// SeedTokens returns the generated token list used to seed the token registry.
+//
+// The caller must not modify the `[]TokenMetadata` returned.
+func SeedTokens() []TokenMetadata {
+	return seedTokens
+}
+
+// SetTokenProvider sets the provider used by the token functions of this package.
+//
+// Services with access to the token registry set it on startup, so the tokens attested after
+// the generated list was created are supported.
+func SetTokenProvider(p TokenProvider) {
+	tokenProviderMu.Lock()
+	defer tokenProviderMu.Unlock()
+	tokenProvider = p
+}
+
+func getTokenProvider() TokenProvider {
+	tokenProviderMu.RLock()
+	defer tokenProviderMu.RUnlock()
+	return tokenProvider
+}
 
-	for i := range tokenMetadata {
+// TokenList is an immutable TokenProvider backed by a list of tokens.
+type TokenList struct {
+	tokens                     []TokenMetadata
+	tokenMetadataByContractID  map[string]*TokenMetadata
+	tokenMetadataByCoingeckoID map[string]*TokenMetadata
+}
+
+// NewTokenList creates a TokenList, the list must not be modified after calling this function.
+func NewTokenList(tokens []TokenMetadata) *TokenList {
+
+	l := &TokenList{
+		tokens:                     tokens,
+		tokenMetadataByContractID:  make(map[string]*TokenMetadata, len(tokens)),
+		tokenMetadataByCoingeckoID: make(map[string]*TokenMetadata, len(tokens)),
+	}
+
+	for i := range tokens {
 
 		// populate the map `tokenMetadataByCoingeckoID`
-		coingeckoID := tokenMetadata[i].CoingeckoID
+		coingeckoID := tokens[i].CoingeckoID
 		if coingeckoID != "" {
-			tokenMetadataByCoingeckoID[coingeckoID] = &tokenMetadata[i]
+			l.tokenMetadataByCoingeckoID[coingeckoID] = &tokens[i]
 		}
 
 		// populate the map `tokenMetadataByContractID`
-		contractID := makeContractID(tokenMetadata[i].TokenChain, tokenMetadata[i].TokenAddress)
+		contractID := makeContractID(tokens[i].TokenChain, tokens[i].TokenAddress)
 		if contractID != "" {
-			tokenMetadataByContractID[contractID] = &tokenMetadata[i]
+			l.tokenMetadataByContractID[contractID] = &tokens[i]
 		}
 	}
+
+	return l
 }
 
 func makeContractID(tokenChain sdk.ChainID, tokenAddress string) string {
 	return fmt.Sprintf("%d-%s", tokenChain, tokenAddress)
 }
 
-// GetAllTokens returns a list of all tokens that exist in the database.
+// GetAllTokens returns a list of all tokens in the list.
 //
 // The caller must not modify the `[]TokenMetadata` returned.
-func GetAllTokens() []TokenMetadata {
-	return tokenMetadata
+func (l *TokenList) GetAllTokens() []TokenMetadata {
+	return l.tokens
 }
 
-// GetAllCoingeckoIDs returns a list of all coingecko IDs that exist in the database.
-func GetAllCoingeckoIDs() []string {
+// GetAllCoingeckoIDs returns a list of all coingecko IDs in the list.
+func (l *TokenList) GetAllCoingeckoIDs() []string {
 
 	// use a map to remove duplicates
-	uniqueIDs := make(map[string]bool, len(tokenMetadata))
-	for i := range tokenMetadata {
-		uniqueIDs[tokenMetadata[i].CoingeckoID] = true
+	uniqueIDs := make(map[string]bool, len(l.tokens))
+	for i := range l.tokens {
+		// the attested tokens have no coingecko ID until it is resolved.
+		if l.tokens[i].CoingeckoID != "" {
+			uniqueIDs[l.tokens[i].CoingeckoID] = true
+		}
 	}
 
 	// collect keys into a slice
@@ -84,9 +137,9 @@ func GetAllCoingeckoIDs() []string {
 // GetTokenByCoingeckoID returns information about a token identified by its coingecko ID.
 //
 // The caller must not modify the `*TokenMetadata` returned.
-func GetTokenByCoingeckoID(coingeckoID string) (*TokenMetadata, bool) {
+func (l *TokenList) GetTokenByCoingeckoID(coingeckoID string) (*TokenMetadata, bool) {
 
-	result, ok := tokenMetadataByCoingeckoID[coingeckoID]
+	result, ok := l.tokenMetadataByCoingeckoID[coingeckoID]
 	if !ok {
 		return nil, false
 	}
@@ -97,14 +150,40 @@ func GetTokenByCoingeckoID(coingeckoID string) (*TokenMetadata, bool) {
 // GetTokenByAddress returns information about a token identified by its original mint address.
 //
 // The caller must not modify the `*TokenMetadata` returned.
-func GetTokenByAddress(tokenChain sdk.ChainID, tokenAddress string) (*TokenMetadata, bool) {
+func (l *TokenList) GetTokenByAddress(tokenChain sdk.ChainID, tokenAddress string) (*TokenMetadata, bool) {
 
 	key := makeContractID(tokenChain, tokenAddress)
 
-	result, ok := tokenMetadataByContractID[key]
+	result, ok := l.tokenMetadataByContractID[key]
 	if !ok {
 		return nil, false
 	}
 
 	return result, true
 }
+
+// GetAllTokens returns a list of all tokens that exist in the database.
+//
+// The caller must not modify the `[]TokenMetadata` returned.
+func GetAllTokens() []TokenMetadata {
+	return getTokenProvider().GetAllTokens()
+}
+
+// GetAllCoingeckoIDs returns a list of all coingecko IDs that exist in the database.
+func GetAllCoingeckoIDs() []string {
+	return getTokenProvider().GetAllCoingeckoIDs()
+}
+
+// GetTokenByCoingeckoID returns information about a token identified by its coingecko ID.
+//
+// The caller must not modify the `*TokenMetadata` returned.
+func GetTokenByCoingeckoID(coingeckoID string) (*TokenMetadata, bool) {
+	return getTokenProvider().GetTokenByCoingeckoID(coingeckoID)
+}
+
+// GetTokenByAddress returns information about a token identified by its original mint address.
+//
+// The caller must not modify the `*TokenMetadata` returned.
+func GetTokenByAddress(tokenChain sdk.ChainID, tokenAddress string) (*TokenMetadata, bool) {
+	return getTokenProvider().GetTokenByAddress(tokenChain, tokenAddress)
+}
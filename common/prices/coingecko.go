@@ -0,0 +1,206 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/shopspring/decimal"
+)
+
+// CoingeckoSource is a price source backed by the coingecko API.
+type CoingeckoSource struct {
+	url       string
+	chunkSize int
+	client    *http.Client
+}
+
+// NewCoingeckoSource creates a new CoingeckoSource.
+// The url is the base url of the coingecko API, e.g. https://api.coingecko.com/api/v3
+func NewCoingeckoSource(url string) *CoingeckoSource {
+	return &CoingeckoSource{
+		url:       strings.TrimSuffix(url, "/"),
+		chunkSize: 200,
+		client:    http.DefaultClient,
+	}
+}
+
+// coingeckoPlatforms are the coingecko asset platforms of the chains.
+var coingeckoPlatforms = map[sdk.ChainID]string{
+	sdk.ChainIDSolana:    "solana",
+	sdk.ChainIDEthereum:  "ethereum",
+	sdk.ChainIDTerra:     "terra",
+	sdk.ChainIDBSC:       "binance-smart-chain",
+	sdk.ChainIDPolygon:   "polygon-pos",
+	sdk.ChainIDAvalanche: "avalanche",
+	sdk.ChainIDOasis:     "oasis",
+	sdk.ChainIDAlgorand:  "algorand",
+	sdk.ChainIDAurora:    "aurora",
+	sdk.ChainIDFantom:    "fantom",
+	sdk.ChainIDKarura:    "karura",
+	sdk.ChainIDAcala:     "acala",
+	sdk.ChainIDKlaytn:    "klay-token",
+	sdk.ChainIDCelo:      "celo",
+	sdk.ChainIDNear:      "near-protocol",
+	sdk.ChainIDMoonbeam:  "moonbeam",
+	sdk.ChainIDTerra2:    "terra-2",
+	sdk.ChainIDInjective: "injective",
+	sdk.ChainIDSui:       "sui",
+	sdk.ChainIDAptos:     "aptos",
+	sdk.ChainIDArbitrum:  "arbitrum-one",
+	sdk.ChainIDOptimism:  "optimistic-ethereum",
+	sdk.ChainIDXpla:      "xpla",
+	sdk.ChainIDBase:      "base",
+	sdk.ChainIDSei:       "sei-network",
+}
+
+// coingeckoCoin is the response of the coins/{platform}/contract/{address} endpoint.
+type coingeckoCoin struct {
+	ID string `json:"id"`
+}
+
+// coingeckoPrice is the price of a coin in the simple/price response.
+type coingeckoPrice struct {
+	USD *decimal.Decimal `json:"usd"`
+}
+
+// coingeckoHistory is the response of the coins/{id}/history endpoint.
+type coingeckoHistory struct {
+	MarketData *struct {
+		CurrentPrice map[string]decimal.Decimal `json:"current_price"`
+	} `json:"market_data"`
+}
+
+// coingeckoMarketChart is the response of the coins/{id}/market_chart/range endpoint.
+type coingeckoMarketChart struct {
+	Prices [][]decimal.Decimal `json:"prices"`
+}
+
+// GetPrices returns the current USD price of the given coingecko ids.
+// The ids are requested in chunks to keep the urls short.
+func (s *CoingeckoSource) GetPrices(ctx context.Context, coingeckoIDs []string) (map[string]decimal.Decimal, error) {
+	result := make(map[string]decimal.Decimal, len(coingeckoIDs))
+	for _, chunk := range chunkIDs(coingeckoIDs, s.chunkSize) {
+		url := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=usd", s.url, strings.Join(chunk, ","))
+		chunkResponse := map[string]coingeckoPrice{}
+		if err := s.get(ctx, url, &chunkResponse); err != nil {
+			return nil, err
+		}
+		for id, p := range chunkResponse {
+			// coingecko returns a nil price for the coins without market data.
+			if p.USD != nil {
+				result[id] = *p.USD
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetPriceByTime returns the USD price of a coingecko id at the day of the given time.
+func (s *CoingeckoSource) GetPriceByTime(ctx context.Context, coingeckoID string, day time.Time) (decimal.Decimal, error) {
+	url := fmt.Sprintf("%s/coins/%s/history?date=%s&localization=false", s.url, coingeckoID, truncateDay(day).Format("02-01-2006"))
+	var response coingeckoHistory
+	if err := s.get(ctx, url, &response); err != nil {
+		return decimal.Zero, err
+	}
+	if response.MarketData == nil {
+		return decimal.Zero, ErrPriceNotFound
+	}
+	price, ok := response.MarketData.CurrentPrice["usd"]
+	if !ok {
+		return decimal.Zero, ErrPriceNotFound
+	}
+	return price, nil
+}
+
+// GetDailyPrices returns a price per day of a coingecko id in the [from, to] interval.
+//
+// Coingecko returns daily prices for intervals longer than 90 days, for shorter intervals
+// the first price of each day is used.
+func (s *CoingeckoSource) GetDailyPrices(ctx context.Context, coingeckoID string, from, to time.Time) ([]DailyPrice, error) {
+	url := fmt.Sprintf("%s/coins/%s/market_chart/range?vs_currency=usd&from=%d&to=%d", s.url, coingeckoID, from.Unix(), to.Unix())
+	var response coingeckoMarketChart
+	if err := s.get(ctx, url, &response); err != nil {
+		return nil, err
+	}
+
+	prices := make([]DailyPrice, 0, len(response.Prices))
+	seen := make(map[int64]bool, len(response.Prices))
+	for _, p := range response.Prices {
+		if len(p) != 2 {
+			continue
+		}
+		day := truncateDay(time.UnixMilli(p[0].IntPart()))
+		if seen[day.Unix()] {
+			continue
+		}
+		seen[day.Unix()] = true
+		prices = append(prices, DailyPrice{CoingeckoID: coingeckoID, Day: day, Price: p[1]})
+	}
+	return prices, nil
+}
+
+// GetCoingeckoIDByContract returns the coingecko ID of a token from its native address on its original chain.
+func (s *CoingeckoSource) GetCoingeckoIDByContract(ctx context.Context, chainID sdk.ChainID, nativeAddress string) (string, error) {
+	platform, ok := coingeckoPlatforms[chainID]
+	if !ok {
+		return "", fmt.Errorf("%w: chain %d has no coingecko platform", ErrCoinNotFound, chainID)
+	}
+	url := fmt.Sprintf("%s/coins/%s/contract/%s", s.url, platform, nativeAddress)
+	var response coingeckoCoin
+	err := s.get(ctx, url, &response)
+	if errors.Is(err, ErrPriceNotFound) {
+		return "", ErrCoinNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if response.ID == "" {
+		return "", ErrCoinNotFound
+	}
+	return response.ID, nil
+}
+
+// get requests the given url and decodes the json response into v.
+func (s *CoingeckoSource) get(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return ErrPriceNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("coingecko request failed with status %d", res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func chunkIDs(slice []string, chunkSize int) [][]string {
+	var chunks [][]string
+	for i := 0; i < len(slice); i += chunkSize {
+		end := i + chunkSize
+		if end > len(slice) {
+			end = len(slice)
+		}
+		chunks = append(chunks, slice[i:end])
+	}
+	return chunks
+}
@@ -0,0 +1,131 @@
+package prices
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GovernorConfigSource is a price source backed by the token prices of the governor configs
+// sent by the phylaxs and stored in the governorConfig collection.
+//
+// The governor configs only have the current prices, so the historical prices are only
+// available for the current day. The prices are cached for governorPricesTTL, so the
+// collection is read once per period and not on every call.
+type GovernorConfigSource struct {
+	collection *mongo.Collection
+	load       func(ctx context.Context) (map[string]decimal.Decimal, error)
+	ttl        time.Duration
+	mu         sync.Mutex
+	prices     map[string]decimal.Decimal
+	expiresAt  time.Time
+}
+
+// governorPricesTTL is the time the governor prices are cached.
+const governorPricesTTL = 5 * time.Minute
+
+// NewGovernorConfigSource creates a new GovernorConfigSource.
+func NewGovernorConfigSource(db *mongo.Database) *GovernorConfigSource {
+	s := &GovernorConfigSource{collection: db.Collection("governorConfig"), ttl: governorPricesTTL}
+	s.load = s.loadPrices
+	return s
+}
+
+// governorConfigTokensDoc models the tokens of a document in the governorConfig collection.
+type governorConfigTokensDoc struct {
+	ParsedConfig struct {
+		Tokens []struct {
+			OriginChainID uint32  `bson:"originchainid"`
+			OriginAddress string  `bson:"originaddress"`
+			Price         float32 `bson:"price"`
+		} `bson:"tokens"`
+	} `bson:"parsedConfig"`
+}
+
+// GetPrices returns the governor price of the given coingecko ids.
+// When the phylaxs have different prices for a token, the price of the most recent config is used.
+func (s *GovernorConfigSource) GetPrices(ctx context.Context, coingeckoIDs []string) (map[string]decimal.Decimal, error) {
+	prices, err := s.cachedPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]decimal.Decimal, len(coingeckoIDs))
+	for _, id := range coingeckoIDs {
+		if price, ok := prices[id]; ok {
+			result[id] = price
+		}
+	}
+	return result, nil
+}
+
+// cachedPrices returns the governor prices of all the tokens, they are loaded again when the cache expires.
+// A failed load is not cached.
+func (s *GovernorConfigSource) cachedPrices(ctx context.Context) (map[string]decimal.Decimal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.prices != nil && time.Now().Before(s.expiresAt) {
+		return s.prices, nil
+	}
+	prices, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.prices = prices
+	s.expiresAt = time.Now().Add(s.ttl)
+	return prices, nil
+}
+
+// loadPrices reads the governor configs and returns the price of each known token by coingecko id.
+func (s *GovernorConfigSource) loadPrices(ctx context.Context) (map[string]decimal.Decimal, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"parsedConfig.tokens": 1}).
+		SetSort(bson.D{{Key: "updatedAt", Value: -1}})
+	cur, err := s.collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []governorConfigTokensDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]decimal.Decimal)
+	for _, doc := range docs {
+		for _, t := range doc.ParsedConfig.Tokens {
+			address := strings.ToLower(strings.TrimPrefix(t.OriginAddress, "0x"))
+			token, ok := domain.GetTokenByAddress(sdk.ChainID(t.OriginChainID), address)
+			if !ok {
+				continue
+			}
+			if _, ok := result[token.CoingeckoID]; ok || t.Price <= 0 {
+				continue
+			}
+			result[token.CoingeckoID] = decimal.NewFromFloat32(t.Price)
+		}
+	}
+	return result, nil
+}
+
+// GetPriceByTime returns the governor price of a coingecko id when the given time is in the current day.
+func (s *GovernorConfigSource) GetPriceByTime(ctx context.Context, coingeckoID string, day time.Time) (decimal.Decimal, error) {
+	if !truncateDay(day).Equal(truncateDay(time.Now())) {
+		return decimal.Zero, ErrPriceNotFound
+	}
+	prices, err := s.GetPrices(ctx, []string{coingeckoID})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	price, ok := prices[coingeckoID]
+	if !ok {
+		return decimal.Zero, ErrPriceNotFound
+	}
+	return price, nil
+}
@@ -0,0 +1,155 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoSource is a price source backed by the daily prices stored in the historicalPrices collection.
+//
+// The historical prices are cached in memory, a past price is not expected to change.
+type MongoSource struct {
+	collection *mongo.Collection
+	mu         sync.RWMutex
+	cache      map[string]decimal.Decimal
+}
+
+// HistoricalPriceDoc models a document in the historicalPrices collection.
+type HistoricalPriceDoc struct {
+	ID          string               `bson:"_id"`
+	CoingeckoID string               `bson:"coingeckoId"`
+	Day         time.Time            `bson:"day"`
+	Price       primitive.Decimal128 `bson:"price"`
+	UpdatedAt   time.Time            `bson:"updatedAt"`
+}
+
+// NewMongoSource creates a new MongoSource.
+func NewMongoSource(db *mongo.Database) *MongoSource {
+	return &MongoSource{
+		collection: db.Collection("historicalPrices"),
+		cache:      make(map[string]decimal.Decimal),
+	}
+}
+
+// historicalPriceID returns the id of the price of a coingecko id at a day.
+func historicalPriceID(coingeckoID string, day time.Time) string {
+	return fmt.Sprintf("%s/%s", coingeckoID, truncateDay(day).Format("2006-01-02"))
+}
+
+// GetPrices returns the most recent stored price of the given coingecko ids.
+func (s *MongoSource) GetPrices(ctx context.Context, coingeckoIDs []string) (map[string]decimal.Decimal, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"coingeckoId": bson.M{"$in": coingeckoIDs}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "coingeckoId", Value: 1}, {Key: "day", Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$coingeckoId"},
+			{Key: "price", Value: bson.M{"$first": "$price"}},
+		}}},
+	}
+	cur, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var docs []struct {
+		ID    string               `bson:"_id"`
+		Price primitive.Decimal128 `bson:"price"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]decimal.Decimal, len(docs))
+	for _, doc := range docs {
+		price, err := decimal.NewFromString(doc.Price.String())
+		if err != nil {
+			return nil, err
+		}
+		result[doc.ID] = price
+	}
+	return result, nil
+}
+
+// GetPriceByTime returns the stored price of a coingecko id at the day of the given time.
+func (s *MongoSource) GetPriceByTime(ctx context.Context, coingeckoID string, day time.Time) (decimal.Decimal, error) {
+	id := historicalPriceID(coingeckoID, day)
+
+	s.mu.RLock()
+	price, ok := s.cache[id]
+	s.mu.RUnlock()
+	if ok {
+		return price, nil
+	}
+
+	var doc HistoricalPriceDoc
+	err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return decimal.Zero, ErrPriceNotFound
+	}
+	if err != nil {
+		return decimal.Zero, err
+	}
+	price, err = decimal.NewFromString(doc.Price.String())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	// the price of the current day is not cached, it is updated until the day ends.
+	if truncateDay(day).Before(truncateDay(time.Now())) {
+		s.mu.Lock()
+		s.cache[id] = price
+		s.mu.Unlock()
+	}
+	return price, nil
+}
+
+// FindLatestDay returns the most recent day with a stored price of a coingecko id.
+// It returns ErrPriceNotFound when the coingecko id has no prices.
+func (s *MongoSource) FindLatestDay(ctx context.Context, coingeckoID string) (time.Time, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "day", Value: -1}})
+	var doc HistoricalPriceDoc
+	err := s.collection.FindOne(ctx, bson.M{"coingeckoId": coingeckoID}, opts).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return time.Time{}, ErrPriceNotFound
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return doc.Day, nil
+}
+
+// Save stores the given daily prices, the stored prices of the same days are replaced.
+func (s *MongoSource) Save(ctx context.Context, prices []DailyPrice) error {
+	if len(prices) == 0 {
+		return nil
+	}
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(prices))
+	for _, p := range prices {
+		price, err := primitive.ParseDecimal128(p.Price.String())
+		if err != nil {
+			return err
+		}
+		doc := HistoricalPriceDoc{
+			ID:          historicalPriceID(p.CoingeckoID, p.Day),
+			CoingeckoID: p.CoingeckoID,
+			Day:         truncateDay(p.Day),
+			Price:       price,
+			UpdatedAt:   now,
+		}
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": doc.ID}).
+			SetReplacement(doc).
+			SetUpsert(true))
+	}
+	_, err := s.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
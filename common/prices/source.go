@@ -0,0 +1,101 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrPriceNotFound is returned when a price source doesn't have the price of a token.
+var ErrPriceNotFound = errors.New("price not found")
+
+// ErrCoinNotFound is returned when a token is not listed by the price source.
+var ErrCoinNotFound = errors.New("coin not found")
+
+// PriceSource provides the USD price of the tokens, identified by their coingecko id.
+type PriceSource interface {
+	// GetPrices returns the current USD price of the given coingecko ids.
+	// The ids without a price are not included in the result.
+	GetPrices(ctx context.Context, coingeckoIDs []string) (map[string]decimal.Decimal, error)
+	// GetPriceByTime returns the USD price of a coingecko id at the day of the given time.
+	// It returns ErrPriceNotFound when the source doesn't have the price.
+	GetPriceByTime(ctx context.Context, coingeckoID string, day time.Time) (decimal.Decimal, error)
+}
+
+// DailyPrice is the USD price of a token at a day.
+type DailyPrice struct {
+	CoingeckoID string
+	Day         time.Time
+	Price       decimal.Decimal
+}
+
+// truncateDay removes the hours, minutes and seconds of a time, the days are in UTC.
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// FallbackSource queries a chain of price sources in order.
+// The prices not found in a source are looked up in the next one.
+type FallbackSource struct {
+	sources []PriceSource
+}
+
+// NewFallbackSource creates a new FallbackSource with the given sources, sorted by priority.
+func NewFallbackSource(sources ...PriceSource) *FallbackSource {
+	return &FallbackSource{sources: sources}
+}
+
+// GetPrices returns the current USD price of the given coingecko ids.
+// An error is returned only when no price was found and a source failed, the last error is returned.
+func (s *FallbackSource) GetPrices(ctx context.Context, coingeckoIDs []string) (map[string]decimal.Decimal, error) {
+	result := make(map[string]decimal.Decimal, len(coingeckoIDs))
+	pending := coingeckoIDs
+	var lastErr error
+	for _, source := range s.sources {
+		if len(pending) == 0 {
+			break
+		}
+		prices, err := source.GetPrices(ctx, pending)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		missing := make([]string, 0, len(pending))
+		for _, id := range pending {
+			if price, ok := prices[id]; ok {
+				result[id] = price
+			} else {
+				missing = append(missing, id)
+			}
+		}
+		pending = missing
+	}
+	if len(result) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+// GetPriceByTime returns the price of the first source that has the price of the coingecko id at the given day.
+//
+// When no source has the price, the first error different from ErrPriceNotFound is returned.
+func (s *FallbackSource) GetPriceByTime(ctx context.Context, coingeckoID string, day time.Time) (decimal.Decimal, error) {
+	var firstErr error
+	for _, source := range s.sources {
+		price, err := source.GetPriceByTime(ctx, coingeckoID, day)
+		if err == nil {
+			return price, nil
+		}
+		if firstErr == nil && !errors.Is(err, ErrPriceNotFound) {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return decimal.Zero, firstErr
+	}
+	return decimal.Zero, fmt.Errorf("%w for %s at %s", ErrPriceNotFound, coingeckoID, truncateDay(day).Format("2006-01-02"))
+}
@@ -0,0 +1,129 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSource struct {
+	prices map[string]decimal.Decimal
+	err    error
+}
+
+func (m *mockSource) GetPrices(_ context.Context, ids []string) (map[string]decimal.Decimal, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := map[string]decimal.Decimal{}
+	for _, id := range ids {
+		if p, ok := m.prices[id]; ok {
+			result[id] = p
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSource) GetPriceByTime(_ context.Context, id string, _ time.Time) (decimal.Decimal, error) {
+	if m.err != nil {
+		return decimal.Zero, m.err
+	}
+	if p, ok := m.prices[id]; ok {
+		return p, nil
+	}
+	return decimal.Zero, ErrPriceNotFound
+}
+
+func TestFallbackSource_GetPrices(t *testing.T) {
+	failing := &mockSource{err: errors.New("rate limited")}
+	primary := &mockSource{prices: map[string]decimal.Decimal{"bitcoin": decimal.NewFromInt(30000)}}
+	secondary := &mockSource{prices: map[string]decimal.Decimal{
+		"bitcoin":  decimal.NewFromInt(1),
+		"ethereum": decimal.NewFromInt(2000),
+	}}
+
+	s := NewFallbackSource(failing, primary, secondary)
+	prices, err := s.GetPrices(context.Background(), []string{"bitcoin", "ethereum", "unknown"})
+	assert.NoError(t, err)
+	assert.Len(t, prices, 2)
+	assert.True(t, decimal.NewFromInt(30000).Equal(prices["bitcoin"]))
+	assert.True(t, decimal.NewFromInt(2000).Equal(prices["ethereum"]))
+
+	_, err = NewFallbackSource(failing).GetPrices(context.Background(), []string{"bitcoin"})
+	assert.Error(t, err)
+}
+
+func TestFallbackSource_GetPriceByTime(t *testing.T) {
+	failing := &mockSource{err: errors.New("rate limited")}
+	secondary := &mockSource{prices: map[string]decimal.Decimal{"ethereum": decimal.NewFromInt(2000)}}
+	s := NewFallbackSource(failing, secondary)
+
+	price, err := s.GetPriceByTime(context.Background(), "ethereum", time.Now())
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(2000).Equal(price))
+
+	_, err = s.GetPriceByTime(context.Background(), "bitcoin", time.Now())
+	assert.EqualError(t, err, "rate limited")
+
+	_, err = NewFallbackSource(secondary).GetPriceByTime(context.Background(), "bitcoin", time.Now())
+	assert.ErrorIs(t, err, ErrPriceNotFound)
+}
+
+func TestStaticSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "prices.csv")
+	content := "2,ethereum,ETH,1672531200000,1200.5\n" +
+		"2,ethereum,ETH,1672617600000,1210.25\n"
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	s, err := NewStaticSource(file)
+	assert.NoError(t, err)
+
+	price, err := s.GetPriceByTime(context.Background(), "ethereum", time.Date(2023, 1, 1, 15, 30, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "1200.5", price.String())
+
+	_, err = s.GetPriceByTime(context.Background(), "ethereum", time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, ErrPriceNotFound)
+
+	prices, err := s.GetPrices(context.Background(), []string{"ethereum", "bitcoin"})
+	assert.NoError(t, err)
+	assert.Len(t, prices, 1)
+	assert.Equal(t, "1210.25", prices["ethereum"].String())
+}
+
+func TestGovernorConfigSource_Cache(t *testing.T) {
+	var loads int
+	var loadErr error
+	s := &GovernorConfigSource{ttl: time.Minute}
+	s.load = func(_ context.Context) (map[string]decimal.Decimal, error) {
+		loads++
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return map[string]decimal.Decimal{"ethereum": decimal.NewFromInt(1200)}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		prices, err := s.GetPrices(context.Background(), []string{"ethereum", "bitcoin"})
+		assert.NoError(t, err)
+		assert.Len(t, prices, 1)
+		assert.Equal(t, "1200", prices["ethereum"].String())
+	}
+	assert.Equal(t, 1, loads)
+
+	// the prices are loaded again when the cache expires, a failed load is not cached.
+	s.expiresAt = time.Now().Add(-time.Second)
+	loadErr = errors.New("unavailable")
+	_, err := s.GetPrices(context.Background(), []string{"ethereum"})
+	assert.Error(t, err)
+	loadErr = nil
+	_, err = s.GetPrices(context.Background(), []string{"ethereum"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, loads)
+}
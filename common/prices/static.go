@@ -0,0 +1,100 @@
+package prices
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// StaticSource is a price source backed by a csv file with daily prices.
+//
+// Each line of the file has the format: tokenChain,coingeckoID,symbol,dayInUnixMillis,price
+type StaticSource struct {
+	prices map[string]map[int64]decimal.Decimal
+	latest map[string]DailyPrice
+}
+
+// NewStaticSource creates a new StaticSource with the prices of the given csv file.
+func NewStaticSource(priceFile string) (*StaticSource, error) {
+	f, err := os.Open(priceFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := &StaticSource{
+		prices: make(map[string]map[int64]decimal.Decimal),
+		latest: make(map[string]DailyPrice),
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		row := scanner.Text()
+		p, err := parseDailyPrice(row)
+		if err != nil {
+			return nil, fmt.Errorf("invalid line %s: %w", row, err)
+		}
+		s.add(p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// parseDailyPrice parses a line of the prices csv file.
+func parseDailyPrice(row string) (DailyPrice, error) {
+	tokens := strings.Split(row, ",")
+	if len(tokens) != 5 {
+		return DailyPrice{}, fmt.Errorf("expected 5 columns, got %d", len(tokens))
+	}
+	millis, err := strconv.ParseInt(tokens[3], 10, 64)
+	if err != nil {
+		return DailyPrice{}, err
+	}
+	price, err := decimal.NewFromString(tokens[4])
+	if err != nil {
+		return DailyPrice{}, err
+	}
+	return DailyPrice{
+		CoingeckoID: tokens[1],
+		Day:         truncateDay(time.UnixMilli(millis)),
+		Price:       price,
+	}, nil
+}
+
+func (s *StaticSource) add(p DailyPrice) {
+	days, ok := s.prices[p.CoingeckoID]
+	if !ok {
+		days = make(map[int64]decimal.Decimal)
+		s.prices[p.CoingeckoID] = days
+	}
+	days[p.Day.UnixMilli()] = p.Price
+	if latest, ok := s.latest[p.CoingeckoID]; !ok || p.Day.After(latest.Day) {
+		s.latest[p.CoingeckoID] = p
+	}
+}
+
+// GetPrices returns the most recent price in the file of the given coingecko ids.
+func (s *StaticSource) GetPrices(_ context.Context, coingeckoIDs []string) (map[string]decimal.Decimal, error) {
+	result := make(map[string]decimal.Decimal, len(coingeckoIDs))
+	for _, id := range coingeckoIDs {
+		if p, ok := s.latest[id]; ok {
+			result[id] = p.Price
+		}
+	}
+	return result, nil
+}
+
+// GetPriceByTime returns the price of a coingecko id at the day of the given time.
+func (s *StaticSource) GetPriceByTime(_ context.Context, coingeckoID string, day time.Time) (decimal.Decimal, error) {
+	if price, ok := s.prices[coingeckoID][truncateDay(day).UnixMilli()]; ok {
+		return price, nil
+	}
+	return decimal.Zero, ErrPriceNotFound
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// PhylaxSetRepository is a repository for phylax sets.
+type PhylaxSetRepository struct {
+	db         *mongo.Database
+	logger     *zap.Logger
+	phylaxSets *mongo.Collection
+}
+
+// PhylaxSetDoc is a document for a phylax set.
+type PhylaxSetDoc struct {
+	ID             uint32     `bson:"_id" json:"-"`
+	Index          uint32     `bson:"index" json:"index"`
+	Keys           []string   `bson:"keys" json:"keys"`
+	VaaID          string     `bson:"vaaId,omitempty" json:"vaaId,omitempty"`
+	ExpirationTime *time.Time `bson:"expirationTime,omitempty" json:"expirationTime,omitempty"`
+	UpdatedAt      *time.Time `bson:"updatedAt" json:"-"`
+}
+
+// IsExpired returns true if the phylax set was expired at the given time.
+func (d *PhylaxSetDoc) IsExpired(t time.Time) bool {
+	return d.ExpirationTime != nil && !d.ExpirationTime.After(t)
+}
+
+// NewPhylaxSetRepository create a new phylax set repository.
+func NewPhylaxSetRepository(db *mongo.Database, logger *zap.Logger) *PhylaxSetRepository {
+	return &PhylaxSetRepository{db: db,
+		logger:     logger.With(zap.String("module", "PhylaxSetRepository")),
+		phylaxSets: db.Collection("phylaxSets"),
+	}
+}
+
+// FindAll finds all the phylax sets sorted by index.
+func (r *PhylaxSetRepository) FindAll(ctx context.Context) ([]*PhylaxSetDoc, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "index", Value: 1}})
+	cur, err := r.phylaxSets.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var phylaxSets []*PhylaxSetDoc
+	err = cur.All(ctx, &phylaxSets)
+	return phylaxSets, err
+}
+
+// FindLatest finds the phylax set with the highest index.
+func (r *PhylaxSetRepository) FindLatest(ctx context.Context) (*PhylaxSetDoc, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "index", Value: -1}})
+	var phylaxSet PhylaxSetDoc
+	err := r.phylaxSets.FindOne(ctx, bson.D{}, opts).Decode(&phylaxSet)
+	if err != nil {
+		return nil, err
+	}
+	return &phylaxSet, nil
+}
+
+// Upsert inserts or updates a phylax set.
+func (r *PhylaxSetRepository) Upsert(ctx context.Context, doc *PhylaxSetDoc) error {
+	now := time.Now()
+	doc.ID = doc.Index
+	doc.UpdatedAt = &now
+	update := bson.M{
+		"$set":         doc,
+		"$setOnInsert": bson.M{"indexedAt": now},
+	}
+	_, err := r.phylaxSets.UpdateByID(ctx, doc.ID, update, options.Update().SetUpsert(true))
+	if err != nil {
+		r.logger.Error("failed to upsert phylax set", zap.Uint32("index", doc.Index), zap.Error(err))
+	}
+	return err
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Token sources.
+const (
+	// TokenSourceSeed is the source of the tokens of the generated token list.
+	TokenSourceSeed = "seed"
+	// TokenSourceAttestation is the source of the tokens found in token bridge attestations.
+	TokenSourceAttestation = "attestation"
+)
+
+// TokenRepository is a repository for the tokens supported by the token bridge.
+type TokenRepository struct {
+	db     *mongo.Database
+	logger *zap.Logger
+	tokens *mongo.Collection
+}
+
+// TokenDoc is a document for a token. The ID is the token ID, `<token chain>/<token address>`.
+type TokenDoc struct {
+	ID           string      `bson:"_id"`
+	TokenChain   sdk.ChainID `bson:"tokenChain"`
+	TokenAddress string      `bson:"tokenAddress"`
+	Symbol       string      `bson:"symbol"`
+	Name         string      `bson:"name,omitempty"`
+	Decimals     int64       `bson:"decimals"`
+	CoingeckoID  string      `bson:"coingeckoId,omitempty"`
+	Source       string      `bson:"source"`
+	VaaID        string      `bson:"vaaId,omitempty"`
+	// CoingeckoCheckedAt is the last time the coingecko ID was looked up.
+	CoingeckoCheckedAt *time.Time `bson:"coingeckoCheckedAt,omitempty"`
+	CreatedAt          *time.Time `bson:"createdAt"`
+	UpdatedAt          *time.Time `bson:"updatedAt"`
+}
+
+// ToTokenMetadata converts the document to the token metadata used by the services.
+func (d *TokenDoc) ToTokenMetadata() domain.TokenMetadata {
+	return domain.TokenMetadata{
+		TokenChain:   d.TokenChain,
+		TokenAddress: d.TokenAddress,
+		Symbol:       domain.Symbol(d.Symbol),
+		CoingeckoID:  d.CoingeckoID,
+		Decimals:     d.Decimals,
+	}
+}
+
+// NewTokenRepository create a new token repository.
+func NewTokenRepository(db *mongo.Database, logger *zap.Logger) *TokenRepository {
+	return &TokenRepository{db: db,
+		logger: logger.With(zap.String("module", "TokenRepository")),
+		tokens: db.Collection("tokens"),
+	}
+}
+
+// FindAll finds all the tokens.
+func (r *TokenRepository) FindAll(ctx context.Context) ([]*TokenDoc, error) {
+	cur, err := r.tokens.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	var tokens []*TokenDoc
+	err = cur.All(ctx, &tokens)
+	return tokens, err
+}
+
+// FindWithoutCoingeckoID finds the tokens without coingecko ID that were not looked up after the given time.
+func (r *TokenRepository) FindWithoutCoingeckoID(ctx context.Context, checkedBefore time.Time, limit int64) ([]*TokenDoc, error) {
+	filter := bson.D{
+		{Key: "coingeckoId", Value: bson.D{{Key: "$in", Value: bson.A{"", nil}}}},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "coingeckoCheckedAt", Value: nil}},
+			bson.D{{Key: "coingeckoCheckedAt", Value: bson.D{{Key: "$lt", Value: checkedBefore}}}},
+		}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}).SetLimit(limit)
+	cur, err := r.tokens.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []*TokenDoc
+	err = cur.All(ctx, &tokens)
+	return tokens, err
+}
+
+// InsertIfNotExists inserts a token if it doesn't exist.
+// The existing tokens are not modified, so the seed metadata is kept for the tokens attested again.
+func (r *TokenRepository) InsertIfNotExists(ctx context.Context, doc *TokenDoc) error {
+	now := time.Now()
+	doc.ID = tokenID(doc.TokenChain, doc.TokenAddress)
+	doc.CreatedAt = &now
+	doc.UpdatedAt = &now
+	update := bson.M{"$setOnInsert": doc}
+	_, err := r.tokens.UpdateByID(ctx, doc.ID, update, options.Update().SetUpsert(true))
+	if err != nil {
+		r.logger.Error("failed to insert token", zap.String("id", doc.ID), zap.Error(err))
+	}
+	return err
+}
+
+// Seed upserts the tokens of the generated token list.
+// The seed fields overwrite the fields of the tokens from the seed and fill the empty fields
+// of the tokens inserted by InsertIfNotExists, so their seed coingecko ID is used.
+func (r *TokenRepository) Seed(ctx context.Context, tokens []domain.TokenMetadata) error {
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(tokens))
+	for _, t := range tokens {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: tokenID(t.TokenChain, t.TokenAddress)}}).
+			SetUpdate(seedPipeline(t, now)).
+			SetUpsert(true))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	_, err := r.tokens.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		r.logger.Error("failed to seed tokens", zap.Error(err))
+	}
+	return err
+}
+
+// UpdateCoingeckoID sets the coingecko ID of a token, an empty coingecko ID records the lookup without result.
+func (r *TokenRepository) UpdateCoingeckoID(ctx context.Context, id string, coingeckoID string) error {
+	now := time.Now()
+	update := bson.M{"$set": bson.M{
+		"coingeckoId":        coingeckoID,
+		"coingeckoCheckedAt": now,
+		"updatedAt":          now,
+	}}
+	_, err := r.tokens.UpdateByID(ctx, id, update)
+	if err != nil {
+		r.logger.Error("failed to update token coingecko id", zap.String("id", id), zap.Error(err))
+	}
+	return err
+}
+
+// Watch opens a change stream on the tokens collection.
+func (r *TokenRepository) Watch(ctx context.Context) (*mongo.ChangeStream, error) {
+	return r.tokens.Watch(ctx, mongo.Pipeline{})
+}
+
+// seedPipeline returns the update of a seed token. A seed field is set when the token is from the seed,
+// a new token has no source yet, or when the field is empty. The empty seed fields are skipped, so the
+// coingecko ID resolved for a token is not removed. The updatedAt is only changed with the fields.
+func seedPipeline(t domain.TokenMetadata, now time.Time) mongo.Pipeline {
+	isSeed := bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$source", TokenSourceSeed}}, TokenSourceSeed}}
+	fields := []struct {
+		key   string
+		value interface{}
+		empty interface{}
+	}{
+		{key: "symbol", value: t.Symbol.String(), empty: ""},
+		{key: "decimals", value: t.Decimals, empty: int64(0)},
+		{key: "coingeckoId", value: t.CoingeckoID, empty: ""},
+	}
+
+	set := bson.D{
+		{Key: "tokenChain", Value: bson.M{"$ifNull": bson.A{"$tokenChain", t.TokenChain}}},
+		{Key: "tokenAddress", Value: bson.M{"$ifNull": bson.A{"$tokenAddress", t.TokenAddress}}},
+		{Key: "source", Value: bson.M{"$ifNull": bson.A{"$source", TokenSourceSeed}}},
+		{Key: "createdAt", Value: bson.M{"$ifNull": bson.A{"$createdAt", now}}},
+	}
+	changes := bson.A{bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$source", nil}}, nil}}}
+	for _, f := range fields {
+		if f.value == f.empty {
+			continue
+		}
+		field := "$" + f.key
+		isEmpty := bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{field, f.empty}}, f.empty}}
+		value := bson.M{"$cond": bson.A{bson.M{"$or": bson.A{isSeed, isEmpty}}, f.value, field}}
+		set = append(set, bson.E{Key: f.key, Value: value})
+		changes = append(changes, bson.M{"$ne": bson.A{field, value}})
+	}
+	set = append(set, bson.E{Key: "updatedAt", Value: bson.M{"$cond": bson.A{bson.M{"$or": changes}, now, "$updatedAt"}}})
+
+	return mongo.Pipeline{{{Key: "$set", Value: set}}}
+}
+
+func tokenID(tokenChain sdk.ChainID, tokenAddress string) string {
+	t := domain.TokenMetadata{TokenChain: tokenChain, TokenAddress: tokenAddress}
+	return t.GetTokenID()
+}
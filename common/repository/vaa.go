@@ -19,8 +19,10 @@ type VaaRepository struct {
 
 // VaaDoc is a document for VAA.
 type VaaDoc struct {
-	ID  string `bson:"_id" json:"id"`
-	Vaa []byte `bson:"vaas" json:"vaa"`
+	ID        string     `bson:"_id" json:"id"`
+	Vaa       []byte     `bson:"vaas" json:"vaa"`
+	TxHash    string     `bson:"txHash,omitempty" json:"txHash,omitempty"`
+	IndexedAt *time.Time `bson:"indexedAt,omitempty" json:"indexedAt,omitempty"`
 }
 
 // NewVaaRepository create a new Vaa repository.
@@ -52,7 +54,7 @@ func (r *VaaRepository) FindPageByTimeRange(ctx context.Context, startTime time.
 	}
 
 	skip := page * pageSize
-	opts := &options.FindOptions{Skip: &skip, Limit: &pageSize, Sort: bson.M{"timestamp": sort}}
+	opts := &options.FindOptions{Skip: &skip, Limit: &pageSize, Sort: bson.D{{Key: "timestamp", Value: sort}, {Key: "_id", Value: sort}}}
 	cur, err := r.vaas.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
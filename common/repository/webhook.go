@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Webhook event types.
+const (
+	// WebhookEventVaaSigned is sent when a VAA of the emitter is signed.
+	WebhookEventVaaSigned = "vaa.signed"
+	// WebhookEventTxConfirmed is sent when the destination transaction of a VAA of the emitter is confirmed.
+	WebhookEventTxConfirmed = "tx.confirmed"
+)
+
+// ErrWebhookNotFound is returned when the webhook does not exist.
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// WebhookRepository is a repository for webhooks and their failed deliveries.
+type WebhookRepository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	webhooks    *mongo.Collection
+	deadLetters *mongo.Collection
+}
+
+// WebhookDoc is a document for a webhook subscription.
+type WebhookDoc struct {
+	ID             string      `bson:"_id" json:"id"`
+	URL            string      `bson:"url" json:"url"`
+	Secret         string      `bson:"secret" json:"-"`
+	EmitterChain   sdk.ChainID `bson:"emitterChain" json:"emitterChain"`
+	EmitterAddress string      `bson:"emitterAddr" json:"emitterAddr"`
+	Events         []string    `bson:"events" json:"events"`
+	Enabled        bool        `bson:"enabled" json:"enabled"`
+	CreatedAt      *time.Time  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      *time.Time  `bson:"updatedAt" json:"updatedAt"`
+}
+
+// WebhookDeadLetterDoc is a document for a delivery that could not be completed after all the retries.
+type WebhookDeadLetterDoc struct {
+	ID             string     `bson:"_id" json:"id"`
+	WebhookID      string     `bson:"webhookId" json:"webhookId"`
+	URL            string     `bson:"url" json:"url"`
+	Event          string     `bson:"event" json:"event"`
+	Payload        string     `bson:"payload" json:"payload"`
+	Attempts       int        `bson:"attempts" json:"attempts"`
+	LastStatusCode int        `bson:"lastStatusCode,omitempty" json:"lastStatusCode,omitempty"`
+	LastError      string     `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	CreatedAt      *time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+// HasEvent returns true if the webhook is subscribed to the event.
+func (d *WebhookDoc) HasEvent(event string) bool {
+	for _, e := range d.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NewWebhookRepository create a new webhook repository.
+func NewWebhookRepository(db *mongo.Database, logger *zap.Logger) *WebhookRepository {
+	return &WebhookRepository{db: db,
+		logger:      logger.With(zap.String("module", "WebhookRepository")),
+		webhooks:    db.Collection("webhooks"),
+		deadLetters: db.Collection("webhookDeadLetters"),
+	}
+}
+
+// FindAll finds all the webhooks sorted by creation time.
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]*WebhookDoc, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cur, err := r.webhooks.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	webhooks := []*WebhookDoc{}
+	err = cur.All(ctx, &webhooks)
+	return webhooks, err
+}
+
+// FindByID finds a webhook by id.
+// It returns ErrWebhookNotFound if the webhook does not exist.
+func (r *WebhookRepository) FindByID(ctx context.Context, id string) (*WebhookDoc, error) {
+	var webhook WebhookDoc
+	err := r.webhooks.FindOne(ctx, bson.M{"_id": id}).Decode(&webhook)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// FindSubscribed finds the enabled webhooks of the emitter subscribed to the event.
+func (r *WebhookRepository) FindSubscribed(ctx context.Context, chainID sdk.ChainID, emitterAddress, event string) ([]*WebhookDoc, error) {
+	filter := bson.M{
+		"emitterChain": chainID,
+		"emitterAddr":  emitterAddress,
+		"events":       event,
+		"enabled":      true,
+	}
+	cur, err := r.webhooks.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []*WebhookDoc
+	err = cur.All(ctx, &webhooks)
+	return webhooks, err
+}
+
+// Insert inserts a new webhook.
+func (r *WebhookRepository) Insert(ctx context.Context, doc *WebhookDoc) error {
+	now := time.Now()
+	doc.CreatedAt = &now
+	doc.UpdatedAt = &now
+	_, err := r.webhooks.InsertOne(ctx, doc)
+	if err != nil {
+		r.logger.Error("failed to insert webhook", zap.String("id", doc.ID), zap.Error(err))
+	}
+	return err
+}
+
+// Update replaces the editable fields of a webhook.
+// It returns ErrWebhookNotFound if the webhook does not exist.
+func (r *WebhookRepository) Update(ctx context.Context, doc *WebhookDoc) error {
+	now := time.Now()
+	doc.UpdatedAt = &now
+	update := bson.M{
+		"$set": bson.M{
+			"url":          doc.URL,
+			"secret":       doc.Secret,
+			"emitterChain": doc.EmitterChain,
+			"emitterAddr":  doc.EmitterAddress,
+			"events":       doc.Events,
+			"enabled":      doc.Enabled,
+			"updatedAt":    doc.UpdatedAt,
+		},
+	}
+	result, err := r.webhooks.UpdateByID(ctx, doc.ID, update)
+	if err != nil {
+		r.logger.Error("failed to update webhook", zap.String("id", doc.ID), zap.Error(err))
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Delete deletes a webhook.
+// It returns ErrWebhookNotFound if the webhook does not exist.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.webhooks.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		r.logger.Error("failed to delete webhook", zap.String("id", id), zap.Error(err))
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// InsertDeadLetter stores a failed delivery.
+func (r *WebhookRepository) InsertDeadLetter(ctx context.Context, doc *WebhookDeadLetterDoc) error {
+	now := time.Now()
+	doc.CreatedAt = &now
+	_, err := r.deadLetters.InsertOne(ctx, doc)
+	if err != nil {
+		r.logger.Error("failed to insert webhook dead letter", zap.String("id", doc.ID), zap.Error(err))
+	}
+	return err
+}
+
+// FindDeadLetters finds the failed deliveries of a webhook, newest first.
+func (r *WebhookRepository) FindDeadLetters(ctx context.Context, webhookID string, skip, limit int64) ([]*WebhookDeadLetterDoc, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+	cur, err := r.deadLetters.Find(ctx, bson.M{"webhookId": webhookID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	deadLetters := []*WebhookDeadLetterDoc{}
+	err = cur.All(ctx, &deadLetters)
+	return deadLetters, err
+}
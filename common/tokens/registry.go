@@ -0,0 +1,140 @@
+// Package tokens provides a token registry persisted in MongoDB.
+package tokens
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// retryDelay is the time to wait before watching the tokens again after a failure.
+const retryDelay = 10 * time.Second
+
+// Registry is a domain.TokenProvider that caches the tokens of the registry in memory.
+//
+// The tokens of the generated token list are used as a seed, the tokens stored in the registry
+// take precedence over them. The cache is reloaded when the tokens collection changes.
+type Registry struct {
+	repository *repository.TokenRepository
+	tokens     atomic.Value // *domain.TokenList
+	logger     *zap.Logger
+}
+
+// NewRegistry creates a Registry with the seed tokens.
+// Call Init to load the tokens of the registry.
+func NewRegistry(repository *repository.TokenRepository, logger *zap.Logger) *Registry {
+	r := &Registry{
+		repository: repository,
+		logger:     logger.With(zap.String("module", "TokenRegistry")),
+	}
+	r.tokens.Store(domain.NewTokenList(domain.SeedTokens()))
+	return r
+}
+
+// Init loads the tokens of the registry.
+func (r *Registry) Init(ctx context.Context) error {
+	return r.reload(ctx)
+}
+
+// Watch reloads the tokens each time the tokens collection changes, until the context is done.
+func (r *Registry) Watch(ctx context.Context) {
+	for {
+		err := r.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		r.logger.Error("failed to watch tokens", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+		// the changes may have been missed while the stream was closed.
+		if err := r.reload(ctx); err != nil {
+			r.logger.Error("failed to reload tokens", zap.Error(err))
+		}
+	}
+}
+
+func (r *Registry) watch(ctx context.Context) error {
+	stream, err := r.repository.Watch(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		// the tokens collection is small, it is reloaded on each change.
+		if err := r.reload(ctx); err != nil {
+			r.logger.Error("failed to reload tokens", zap.Error(err))
+		}
+	}
+	return stream.Err()
+}
+
+func (r *Registry) reload(ctx context.Context) error {
+	docs, err := r.repository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	tokens := merge(domain.SeedTokens(), docs)
+	r.tokens.Store(domain.NewTokenList(tokens))
+	r.logger.Info("Tokens loaded", zap.Int("tokens", len(tokens)))
+	return nil
+}
+
+// merge returns the seed tokens with the tokens of the registry, the tokens of the registry take precedence.
+func merge(seed []domain.TokenMetadata, docs []*repository.TokenDoc) []domain.TokenMetadata {
+	index := make(map[string]int, len(seed)+len(docs))
+	tokens := make([]domain.TokenMetadata, 0, len(seed)+len(docs))
+	for _, t := range seed {
+		index[t.GetTokenID()] = len(tokens)
+		tokens = append(tokens, t)
+	}
+	for _, doc := range docs {
+		t := doc.ToTokenMetadata()
+		if i, ok := index[t.GetTokenID()]; ok {
+			tokens[i] = t
+			continue
+		}
+		index[t.GetTokenID()] = len(tokens)
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+func (r *Registry) list() *domain.TokenList {
+	return r.tokens.Load().(*domain.TokenList)
+}
+
+// GetAllTokens returns a list of all tokens that exist in the registry.
+//
+// The caller must not modify the `[]TokenMetadata` returned.
+func (r *Registry) GetAllTokens() []domain.TokenMetadata {
+	return r.list().GetAllTokens()
+}
+
+// GetAllCoingeckoIDs returns a list of all coingecko IDs that exist in the registry.
+func (r *Registry) GetAllCoingeckoIDs() []string {
+	return r.list().GetAllCoingeckoIDs()
+}
+
+// GetTokenByCoingeckoID returns information about a token identified by its coingecko ID.
+//
+// The caller must not modify the `*TokenMetadata` returned.
+func (r *Registry) GetTokenByCoingeckoID(coingeckoID string) (*domain.TokenMetadata, bool) {
+	return r.list().GetTokenByCoingeckoID(coingeckoID)
+}
+
+// GetTokenByAddress returns information about a token identified by its original mint address.
+//
+// The caller must not modify the `*TokenMetadata` returned.
+func (r *Registry) GetTokenByAddress(tokenChain sdk.ChainID, tokenAddress string) (*domain.TokenMetadata, bool) {
+	return r.list().GetTokenByAddress(tokenChain, tokenAddress)
+}
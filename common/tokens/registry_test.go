@@ -0,0 +1,40 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	seed := []domain.TokenMetadata{
+		{TokenChain: sdk.ChainIDEthereum, TokenAddress: "01", Symbol: "A", CoingeckoID: "a", Decimals: 18},
+		{TokenChain: sdk.ChainIDSolana, TokenAddress: "02", Symbol: "B", CoingeckoID: "b", Decimals: 9},
+	}
+	docs := []*repository.TokenDoc{
+		{TokenChain: sdk.ChainIDSolana, TokenAddress: "02", Symbol: "B", CoingeckoID: "b-updated", Decimals: 9},
+		{TokenChain: sdk.ChainIDBSC, TokenAddress: "03", Symbol: "C", Decimals: 8},
+	}
+
+	tokens := merge(seed, docs)
+	assert.Len(t, tokens, 3)
+
+	list := domain.NewTokenList(tokens)
+	token, ok := list.GetTokenByAddress(sdk.ChainIDSolana, "02")
+	assert.True(t, ok)
+	assert.Equal(t, "b-updated", token.CoingeckoID)
+
+	// the attested tokens are supported before their coingecko ID is resolved.
+	token, ok = list.GetTokenByAddress(sdk.ChainIDBSC, "03")
+	assert.True(t, ok)
+	assert.Equal(t, domain.Symbol("C"), token.Symbol)
+
+	_, ok = list.GetTokenByCoingeckoID("b")
+	assert.False(t, ok)
+	token, ok = list.GetTokenByCoingeckoID("a")
+	assert.True(t, ok)
+	assert.Equal(t, "01", token.TokenAddress)
+}
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/config"
@@ -70,13 +71,38 @@ func CreateEvmWatcher(
 	limiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
 	client := evm.NewEvmSDK(chainURL, limiter, metrics)
 	params := watcher.EVMParams{
-		ChainID:          wb.ChainID,
-		Blockchain:       wb.Name,
-		SizeBlocks:       wb.SizeBlocks,
-		WaitSeconds:      wb.WaitSeconds,
-		InitialBlock:     wb.InitialBlock,
-		MethodsByAddress: wb.MethodsByAddress,
+		ChainID:            wb.ChainID,
+		Blockchain:         wb.Name,
+		SizeBlocks:         wb.SizeBlocks,
+		WaitSeconds:        wb.WaitSeconds,
+		InitialBlock:       wb.InitialBlock,
+		ConfirmationBlocks: wb.ConfirmationBlocks,
+		BlockTag:           wb.BlockTag,
+		DetectionMode:      wb.DetectionMode,
+		TokenBridgeAddress: wb.TokenBridgeAddress,
+		MethodsByAddress:   wb.MethodsByAddress,
+	}
+	if params.DetectionMode == config.DetectionModeLogs && params.TokenBridgeAddress == "" {
+		logger.Fatal("token bridge address is required to detect the redeems by logs", zap.String("blockchain", wb.Name))
 	}
 
 	return watcher.NewEvmStandardWatcher(client, params, repo, metrics, logger)
 }
+
+// CreateWatcher creates the watcher of a chain from its configuration.
+func CreateWatcher(chain config.ChainConfig, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) (watcher.ContractWatcher, error) {
+	switch chain.Type {
+	case config.WatcherTypeEvm:
+		return CreateEvmWatcher(chain.RequestsPerSecond, chain.URL, chain.WatcherBlockchainAddresses(), logger, repo, metrics), nil
+	case config.WatcherTypeAnkr:
+		return CreateAnkrEvmWatcher(chain.RequestsPerSecond, chain.URL, chain.WatcherBlockchainAddresses(), repo, metrics, logger), nil
+	case config.WatcherTypeSolana:
+		return CreateSolanaWatcher(chain.RequestsPerSecond, chain.URL, chain.WatcherBlockchain(), logger, repo, metrics), nil
+	case config.WatcherTypeTerra:
+		return CreateTerraWatcher(chain.RequestsPerSecond, chain.URL, chain.WatcherBlockchain(), logger, repo, metrics), nil
+	case config.WatcherTypeAptos:
+		return CreateAptosWatcher(chain.RequestsPerSecond, chain.URL, chain.WatcherBlockchain(), logger, repo, metrics), nil
+	default:
+		return nil, fmt.Errorf("watcher type %s not supported", chain.Type)
+	}
+}
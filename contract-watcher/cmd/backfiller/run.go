@@ -2,11 +2,11 @@ package backfiller
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
-	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/builder"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/config"
@@ -39,15 +39,9 @@ func Run(config *config.BackfillerConfiguration) {
 	// create repositories
 	repo := storage.NewRepository(db.Database, metrics, alerts, logger)
 
-	var watcher watcher.ContractWatcher
-
-	switch config.Network {
-	case domain.P2pMainNet:
-		watcher = newWatcherForMainnet(config, repo, metrics, logger)
-	case domain.P2pTestNet:
-		watcher = newWatcherForTestnet(config, repo, metrics, logger)
-	default:
-		logger.Fatal("P2P network not supported")
+	watcher, err := newWatcher(config, repo, metrics, logger)
+	if err != nil {
+		logger.Fatal("failed to create watcher", zap.Error(err))
 	}
 
 	logger.Info("Processing backfill ...",
@@ -66,76 +60,26 @@ func Run(config *config.BackfillerConfiguration) {
 
 }
 
-func newWatcherForMainnet(cfg *config.BackfillerConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) watcher.ContractWatcher {
-	var watcher watcher.ContractWatcher
-	switch cfg.ChainName {
-	case config.ETHEREUM_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ETHEREUM_MAINNET, repo, metrics, logger)
-	case config.POLYGON_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.POLYGON_MAINNET, repo, metrics, logger)
-	case config.BSC_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BSC_MAINNET, repo, metrics, logger)
-	case config.FANTOM_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.FANTOM_MAINNET, repo, metrics, logger)
-	case config.AVALANCHE_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.AVALANCHE_MAINNET, repo, metrics, logger)
-	case config.SOLANA_MAINNET.ChainID.String():
-		watcher = builder.CreateSolanaWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.SOLANA_MAINNET, logger, repo, metrics)
-	case config.TERRA_MAINNET.ChainID.String():
-		watcher = builder.CreateTerraWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.TERRA_MAINNET, logger, repo, metrics)
-	case config.APTOS_MAINNET.ChainID.String():
-		watcher = builder.CreateAptosWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.APTOS_MAINNET, logger, repo, metrics)
-	case config.OASIS_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.OASIS_MAINNET, logger, repo, metrics)
-	case config.MOONBEAM_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.MOONBEAM_MAINNET, logger, repo, metrics)
-	case config.PLANQ_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.PLANQ_MAINNET, logger, repo, metrics)
-	case config.CELO_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.CELO_MAINNET, logger, repo, metrics)
-	case config.ARBITRUM_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ARBITRUM_MAINNET, logger, repo, metrics)
-	case config.OPTIMISM_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.OPTIMISM_MAINNET, logger, repo, metrics)
-	case config.BASE_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BASE_MAINNET, logger, repo, metrics)
-	default:
-		logger.Fatal("chain not supported")
+// newWatcher creates the watcher of the chain to backfill with the url and the rate limit of the backfiller.
+func newWatcher(cfg *config.BackfillerConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) (watcher.ContractWatcher, error) {
+	var chains *config.ChainsConfiguration
+	var err error
+	if cfg.ChainsConfigPath != "" {
+		chains, err = config.LoadChains(cfg.ChainsConfigPath)
+	} else {
+		chains, err = config.LoadDefaultChains(cfg.Network)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return watcher
-}
 
-func newWatcherForTestnet(cfg *config.BackfillerConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) watcher.ContractWatcher {
-	var watcher watcher.ContractWatcher
-	switch cfg.ChainName {
-	case config.ETHEREUM_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ETHEREUM_TESTNET, repo, metrics, logger)
-	case config.POLYGON_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.POLYGON_TESTNET, repo, metrics, logger)
-	case config.BSC_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BSC_TESTNET, repo, metrics, logger)
-	case config.FANTOM_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.FANTOM_TESTNET, repo, metrics, logger)
-	case config.AVALANCHE_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.AVALANCHE_TESTNET, repo, metrics, logger)
-	case config.SOLANA_TESTNET.ChainID.String():
-		watcher = builder.CreateSolanaWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.SOLANA_TESTNET, logger, repo, metrics)
-	case config.APTOS_TESTNET.ChainID.String():
-		watcher = builder.CreateAptosWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.APTOS_TESTNET, logger, repo, metrics)
-	case config.OASIS_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.OASIS_TESTNET, logger, repo, metrics)
-	case config.MOONBEAM_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.MOONBEAM_TESTNET, logger, repo, metrics)
-	case config.CELO_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.CELO_TESTNET, logger, repo, metrics)
-	case config.ARBITRUM_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ARBITRUM_TESTNET, logger, repo, metrics)
-	case config.OPTIMISM_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.OPTIMISM_TESTNET, logger, repo, metrics)
-	case config.BASE_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BASE_TESTNET, logger, repo, metrics)
-	default:
-		logger.Fatal("chain not supported")
+	chain, ok := chains.FindChain(cfg.ChainName)
+	if !ok {
+		return nil, fmt.Errorf("chain %s not supported", cfg.ChainName)
 	}
-	return watcher
+	// the disabled chains can be backfilled too.
+	chain.Enabled = true
+	chain.URL = cfg.ChainUrl
+	chain.RequestsPerSecond = cfg.RateLimitPerSecond
+	return builder.CreateWatcher(*chain, repo, metrics, logger)
 }
@@ -39,7 +39,7 @@ func addServiceCommand(root *cobra.Command) {
 }
 
 func addBackfillerCommand(parent *cobra.Command) {
-	var network, mongoUri, mongoDb, chainName, chainURL, logLevel string
+	var network, mongoUri, mongoDb, chainName, chainURL, chainsConfigPath, logLevel string
 	var fromBlock, toBlock, pageSize uint64
 	var rateLimit int
 	var persistBlock bool
@@ -59,6 +59,7 @@ func addBackfillerCommand(parent *cobra.Command) {
 				RateLimitPerSecond: rateLimit,
 				PageSize:           pageSize,
 				PersistBlock:       persistBlock,
+				ChainsConfigPath:   chainsConfigPath,
 			}
 
 			backfiller.Run(cfg)
@@ -75,6 +76,7 @@ func addBackfillerCommand(parent *cobra.Command) {
 	backfillerCommand.Flags().IntVar(&rateLimit, "rate-limit", 3, "rate limit per second")
 	backfillerCommand.Flags().Uint64Var(&pageSize, "page-size", 100, "maximum number to process at one time")
 	backfillerCommand.Flags().BoolVar(&persistBlock, "persist-blocks", false, "persist processed blocks in storage")
+	backfillerCommand.Flags().StringVar(&chainsConfigPath, "chains-config", "", "chains configuration file (default the configuration of the network)")
 
 	backfillerCommand.MarkFlagRequired("network")
 	backfillerCommand.MarkFlagRequired("mongo-uri")
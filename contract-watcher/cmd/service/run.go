@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"log"
 	"os"
@@ -10,20 +11,17 @@ import (
 
 	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
-	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/common/health"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/builder"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/config"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/http/infrastructure"
 	cwAlert "github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/alert"
-	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/ankr"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/metrics"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/processor"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/storage"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/watcher"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.uber.org/ratelimit"
 	"go.uber.org/zap"
 )
 
@@ -38,43 +36,6 @@ func handleExit() {
 	}
 }
 
-type watchersConfig struct {
-	ankr      []config.WatcherBlockchainAddresses
-	aptos     *config.WatcherBlockchain
-	arbitrum  *config.WatcherBlockchainAddresses
-	avalanche *config.WatcherBlockchainAddresses
-	bsc       *config.WatcherBlockchainAddresses
-	base      *config.WatcherBlockchainAddresses
-	ethereum  *config.WatcherBlockchainAddresses
-	celo      *config.WatcherBlockchainAddresses
-	moonbeam  *config.WatcherBlockchainAddresses
-	oasis     *config.WatcherBlockchainAddresses
-	optimism  *config.WatcherBlockchainAddresses
-	planq     *config.WatcherBlockchainAddresses
-	polygon   *config.WatcherBlockchainAddresses
-	solana    *config.WatcherBlockchain
-	terra     *config.WatcherBlockchain
-	rateLimit rateLimitConfig
-}
-
-type rateLimitConfig struct {
-	ankr      int
-	aptos     int
-	arbitrum  int
-	avalanche int
-	base      int
-	bsc       int
-	celo      int
-	ethereum  int
-	moonbeam  int
-	oasis     int
-	optimism  int
-	planq     int
-	polygon   int
-	solana    int
-	terra     int
-}
-
 func Run() {
 
 	defer handleExit()
@@ -102,23 +63,30 @@ func Run() {
 	}
 
 	// create metrics client
-	metrics := metrics.NewPrometheusMetrics(config.Environment)
+	prometheusMetrics := metrics.NewPrometheusMetrics(config.Environment)
 
 	// create alert client
 	alerts := newAlertClient(config, logger)
 
 	// create repositories
-	repo := storage.NewRepository(db.Database, metrics, alerts, logger)
+	repo := storage.NewRepository(db.Database, prometheusMetrics, alerts, logger)
 
-	// create watchers
-	watchers := newWatchers(config, repo, metrics, logger)
+	// load chains configuration
+	chains, chainsData, err := loadChains(config)
+	if err != nil {
+		logger.Fatal("failed to load chains configuration", zap.Error(err))
+	}
 
-	//create processor
-	processor := processor.NewProcessor(watchers, logger)
-	processor.Start(rootCtx)
+	// create processor
+	tracker := metrics.NewBlockTracker(prometheusMetrics)
+	processor := processor.NewProcessor(newWatcherFactory(repo, tracker, logger), tracker, logger)
+	if err := processor.Apply(rootCtx, chains.Chains); err != nil {
+		logger.Fatal("failed to start watchers", zap.Error(err))
+	}
+	go watchChains(rootCtx, config, chainsData, processor, logger)
 
 	// create and start server.
-	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, healthChecks...)
+	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, processor, healthChecks...)
 	server.Start()
 
 	logger.Info("Started deltaswap-explorer-contract-watcher")
@@ -152,189 +120,70 @@ func newHealthChecks(ctx context.Context, db *mongo.Database) ([]health.Check, e
 	return []health.Check{health.Mongo(db)}, nil
 }
 
-func newWatchers(config *config.ServiceConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) []watcher.ContractWatcher {
-	var watchers *watchersConfig
-	switch config.P2pNetwork {
-	case domain.P2pMainNet:
-		watchers = newWatchersForMainnet(config)
-	case domain.P2pTestNet:
-		watchers = newWatchersForTestnet(config)
-	default:
-		watchers = &watchersConfig{}
-	}
-
-	result := make([]watcher.ContractWatcher, 0)
-
-	// add evm watchers
-	evmLimiter := ratelimit.New(watchers.rateLimit.ankr, ratelimit.Per(time.Second))
-	ankrClient := ankr.NewAnkrSDK(config.AnkrUrl, evmLimiter, metrics)
-	for _, w := range watchers.ankr {
-		params := watcher.EVMParams{ChainID: w.ChainID, Blockchain: w.Name, SizeBlocks: w.SizeBlocks,
-			WaitSeconds: w.WaitSeconds, InitialBlock: w.InitialBlock, MethodsByAddress: w.MethodsByAddress}
-		result = append(result, watcher.NewEVMWatcher(ankrClient, repo, params, metrics, logger))
-	}
-
-	// add ethereum watcher
-	if watchers.ethereum != nil {
-		ethereumWatcher := builder.CreateEvmWatcher(watchers.rateLimit.ethereum, config.EthereumUrl, *watchers.ethereum, logger, repo, metrics)
-		result = append(result, ethereumWatcher)
-	}
-
-	if watchers.bsc != nil {
-		bscWatcher := builder.CreateEvmWatcher(watchers.rateLimit.bsc, config.BscUrl, *watchers.bsc, logger, repo, metrics)
-		result = append(result, bscWatcher)
-	}
-
-	if watchers.planq != nil {
-		planqWatcher := builder.CreateEvmWatcher(watchers.rateLimit.planq, config.PlanqUrl, *watchers.planq, logger, repo, metrics)
-		result = append(result, planqWatcher)
-	}
-
-	// add solana watcher
-	if watchers.solana != nil {
-		solanaWatcher := builder.CreateSolanaWatcher(watchers.rateLimit.solana, config.SolanaUrl, *watchers.solana, logger, repo, metrics)
-		result = append(result, solanaWatcher)
-	}
-
-	// add avalanche watcher
-	if watchers.avalanche != nil {
-		avalancheWatcher := builder.CreateEvmWatcher(watchers.rateLimit.avalanche, config.AvalancheUrl, *watchers.avalanche, logger, repo, metrics)
-		result = append(result, avalancheWatcher)
-	}
-
-	// add terra watcher
-	if watchers.terra != nil {
-		terraWatcher := builder.CreateTerraWatcher(watchers.rateLimit.terra, config.TerraUrl, *watchers.terra, logger, repo, metrics)
-		result = append(result, terraWatcher)
-	}
-
-	// add aptos watcher
-	if watchers.aptos != nil {
-		aptosWatcher := builder.CreateAptosWatcher(watchers.rateLimit.aptos, config.AptosUrl, *watchers.aptos, logger, repo, metrics)
-		result = append(result, aptosWatcher)
-	}
-
-	// add oasis watcher
-	if watchers.oasis != nil {
-		oasisWatcher := builder.CreateEvmWatcher(watchers.rateLimit.oasis, config.OasisUrl, *watchers.oasis, logger, repo, metrics)
-		result = append(result, oasisWatcher)
-	}
-
-	// add moonbeam watcher
-	if watchers.moonbeam != nil {
-		moonbeamWatcher := builder.CreateEvmWatcher(watchers.rateLimit.moonbeam, config.MoonbeamUrl, *watchers.moonbeam, logger, repo, metrics)
-		result = append(result, moonbeamWatcher)
-	}
-
-	// add celo watcher
-	if watchers.celo != nil {
-		celoWatcher := builder.CreateEvmWatcher(watchers.rateLimit.celo, config.CeloUrl, *watchers.celo, logger, repo, metrics)
-		result = append(result, celoWatcher)
-	}
-
-	// add optimism watcher
-	if watchers.optimism != nil {
-		optimismWatcher := builder.CreateEvmWatcher(watchers.rateLimit.optimism, config.OptimismUrl, *watchers.optimism, logger, repo, metrics)
-		result = append(result, optimismWatcher)
-	}
-
-	// add arbitrum watcher
-	if watchers.arbitrum != nil {
-		arbitrumWatcher := builder.CreateEvmWatcher(watchers.rateLimit.arbitrum, config.ArbitrumUrl, *watchers.arbitrum, logger, repo, metrics)
-		result = append(result, arbitrumWatcher)
+func newWatcherFactory(repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) processor.WatcherFactory {
+	return func(chain config.ChainConfig) (watcher.ContractWatcher, error) {
+		return builder.CreateWatcher(chain, repo, metrics, logger)
 	}
+}
 
-	// add base watcher
-	if watchers.base != nil {
-		baseWatcher := builder.CreateEvmWatcher(watchers.rateLimit.base, config.BaseUrl, *watchers.base, logger, repo, metrics)
-		result = append(result, baseWatcher)
+// loadChains loads the chains configuration from the file of the configuration,
+// or the configuration embedded for the p2p network when the file is not set.
+func loadChains(cfg *config.ServiceConfiguration) (*config.ChainsConfiguration, []byte, error) {
+	if cfg.ChainsConfigPath == "" {
+		chains, err := config.LoadDefaultChains(cfg.P2pNetwork)
+		if err != nil {
+			return nil, nil, err
+		}
+		return chains, nil, chains.ValidateEndpoints()
 	}
-
-	// add polygon watcher
-	if watchers.polygon != nil {
-		polygonWatcher := builder.CreateEvmWatcher(watchers.rateLimit.polygon, config.PolygonUrl, *watchers.polygon, logger, repo, metrics)
-		result = append(result, polygonWatcher)
+	data, err := os.ReadFile(cfg.ChainsConfigPath)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	return result
+	chains, err := parseChains(data)
+	return chains, data, err
 }
 
-func newWatchersForMainnet(cfg *config.ServiceConfiguration) *watchersConfig {
-	return &watchersConfig{
-		/*ankr: []config.WatcherBlockchainAddresses{
-			config.BSC_MAINNET,
-			config.FANTOM_MAINNET,
-		},
-		aptos:     &config.APTOS_MAINNET,
-		arbitrum:  &config.ARBITRUM_MAINNET,
-		avalanche: &config.AVALANCHE_MAINNET,
-		base:      &config.BASE_MAINNET,
-		bsc:       &config.BSC_MAINNET,
-		celo:      &config.CELO_MAINNET,
-		ethereum:  &config.ETHEREUM_MAINNET,
-		moonbeam:  &config.MOONBEAM_MAINNET,
-		oasis:     &config.OASIS_MAINNET,
-		optimism:  &config.OPTIMISM_MAINNET,
-		planq:     &config.PLANQ_MAINNET,
-		polygon:   &config.POLYGON_MAINNET,
-		solana:    &config.SOLANA_MAINNET,
-		terra:     &config.TERRA_MAINNET,*/
-
-		bsc:   &config.BSC_MAINNET,
-		planq: &config.PLANQ_MAINNET,
-
-		rateLimit: rateLimitConfig{
-			ankr:      cfg.AnkrRequestsPerSecond,
-			avalanche: cfg.AvalancheRequestsPerSecond,
-			aptos:     cfg.AptosRequestsPerSecond,
-			arbitrum:  cfg.ArbitrumRequestsPerSecond,
-			base:      cfg.BaseRequestsPerSecond,
-			bsc:       cfg.BscRequestsPerSecond,
-			celo:      cfg.CeloRequestsPerSecond,
-			ethereum:  cfg.EthereumRequestsPerSecond,
-			moonbeam:  cfg.MoonbeamRequestsPerSecond,
-			oasis:     cfg.OasisRequestsPerSecond,
-			optimism:  cfg.OptimismRequestsPerSecond,
-			planq:     cfg.PlanqRequestsPerSecond,
-			polygon:   cfg.PolygonRequestsPerSecond,
-			solana:    cfg.SolanaRequestsPerSecond,
-			terra:     cfg.TerraRequestsPerSecond,
-		},
+func parseChains(data []byte) (*config.ChainsConfiguration, error) {
+	chains, err := config.ParseChains(data)
+	if err != nil {
+		return nil, err
 	}
+	return chains, chains.ValidateEndpoints()
 }
 
-func newWatchersForTestnet(cfg *config.ServiceConfiguration) *watchersConfig {
-	return &watchersConfig{
-		ankr: []config.WatcherBlockchainAddresses{
-			config.BSC_TESTNET,
-			config.FANTOM_TESTNET,
-		},
-		aptos:     &config.APTOS_TESTNET,
-		arbitrum:  &config.ARBITRUM_TESTNET,
-		avalanche: &config.AVALANCHE_TESTNET,
-		celo:      &config.CELO_TESTNET,
-		base:      &config.BASE_TESTNET,
-		ethereum:  &config.ETHEREUM_TESTNET,
-		moonbeam:  &config.MOONBEAM_TESTNET,
-		oasis:     &config.OASIS_TESTNET,
-		optimism:  &config.OPTIMISM_TESTNET,
-		polygon:   &config.POLYGON_TESTNET,
-		solana:    &config.SOLANA_TESTNET,
-		rateLimit: rateLimitConfig{
-			ankr:      cfg.AnkrRequestsPerSecond,
-			avalanche: cfg.AvalancheRequestsPerSecond,
-			aptos:     cfg.AptosRequestsPerSecond,
-			arbitrum:  cfg.ArbitrumRequestsPerSecond,
-			base:      cfg.BaseRequestsPerSecond,
-			celo:      cfg.CeloRequestsPerSecond,
-			ethereum:  cfg.EthereumRequestsPerSecond,
-			moonbeam:  cfg.MoonbeamRequestsPerSecond,
-			oasis:     cfg.OasisRequestsPerSecond,
-			optimism:  cfg.OptimismRequestsPerSecond,
-			polygon:   cfg.PolygonRequestsPerSecond,
-			solana:    cfg.SolanaRequestsPerSecond,
-			terra:     cfg.TerraRequestsPerSecond,
-		},
+// watchChains reloads the chains configuration file when it changes and applies it to the processor.
+// An invalid configuration is discarded and the watchers keep running with the previous one.
+func watchChains(ctx context.Context, cfg *config.ServiceConfiguration, data []byte, processor *processor.Processor, logger *zap.Logger) {
+	if cfg.ChainsConfigPath == "" || cfg.ChainsConfigReloadSeconds <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(cfg.ChainsConfigReloadSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			newData, err := os.ReadFile(cfg.ChainsConfigPath)
+			if err != nil {
+				logger.Error("failed to read chains configuration", zap.String("path", cfg.ChainsConfigPath), zap.Error(err))
+				continue
+			}
+			if bytes.Equal(data, newData) {
+				continue
+			}
+			data = newData
+			chains, err := parseChains(newData)
+			if err != nil {
+				logger.Error("invalid chains configuration, keeping the current one", zap.String("path", cfg.ChainsConfigPath), zap.Error(err))
+				continue
+			}
+			logger.Info("Reloading chains configuration", zap.String("path", cfg.ChainsConfigPath))
+			if err := processor.Apply(ctx, chains.Chains); err != nil {
+				logger.Error("failed to apply chains configuration", zap.Error(err))
+			}
+		}
 	}
 }
 
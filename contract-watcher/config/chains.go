@@ -0,0 +1,276 @@
+package config
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	solana_go "github.com/gagliardetto/solana-go"
+	"gopkg.in/yaml.v3"
+)
+
+// Watcher types.
+const (
+	// WatcherTypeEvm watches an evm chain through its json-rpc api.
+	WatcherTypeEvm = "evm"
+	// WatcherTypeAnkr watches an evm chain through the ankr advanced api.
+	WatcherTypeAnkr   = "ankr"
+	WatcherTypeSolana = "solana"
+	WatcherTypeTerra  = "terra"
+	WatcherTypeAptos  = "aptos"
+)
+
+//go:embed chains/*.yaml
+var defaultChains embed.FS
+
+var (
+	evmAddressRegexp = regexp.MustCompile(`^0x[0-9a-f]{40}$`)
+	methodIDRegexp   = regexp.MustCompile(`^0x[0-9a-f]{8}$`)
+)
+
+// ChainsConfiguration is the configuration of the chains watched by the contract watcher.
+type ChainsConfiguration struct {
+	Chains []ChainConfig `yaml:"chains"`
+}
+
+// ChainConfig is the configuration of the watcher of a chain.
+type ChainConfig struct {
+	// Name identifies the watcher, it is the key of the last block processed in the database.
+	Name string `yaml:"name"`
+	// Chain is the name of the chain, e.g. ethereum.
+	Chain   string      `yaml:"chain"`
+	ChainID vaa.ChainID `yaml:"-"`
+	Type    string      `yaml:"type"`
+	Enabled bool        `yaml:"enabled"`
+
+	URL               string `yaml:"url"`
+	RequestsPerSecond int    `yaml:"requestsPerSecond"`
+	SizeBlocks        uint8  `yaml:"sizeBlocks"`
+	WaitSeconds       uint16 `yaml:"waitSeconds"`
+	// Initial block indicates for the supported contracts, the oldest block from which to start processing.
+	InitialBlock int64 `yaml:"initialBlock"`
+
+	// ConfirmationBlocks, BlockTag, DetectionMode and TokenBridgeAddress only apply to the evm watchers.
+	// The ankr watchers process up to the latest block, without reorg protection, so they reject them.
+	ConfirmationBlocks uint64 `yaml:"confirmationBlocks"`
+	BlockTag           string `yaml:"blockTag"`
+	DetectionMode      string `yaml:"detectionMode"`
+	TokenBridgeAddress string `yaml:"tokenBridgeAddress"`
+
+	// Address is the contract address watched in the solana, terra and aptos chains.
+	Address string `yaml:"address"`
+	// Contracts are the contracts and methods watched in the evm chains.
+	Contracts []ContractConfig `yaml:"contracts"`
+}
+
+// ContractConfig is a contract watched in an evm chain.
+type ContractConfig struct {
+	Address string             `yaml:"address"`
+	Methods []BlockchainMethod `yaml:"methods"`
+}
+
+// LoadChains loads the chains configuration from a yaml file.
+// The environment variables referenced in the file, e.g. ${ETHEREUM_URL}, are expanded.
+func LoadChains(path string) (*ChainsConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChains(data)
+}
+
+// LoadDefaultChains loads the chains configuration embedded for a p2p network.
+func LoadDefaultChains(p2pNetwork string) (*ChainsConfiguration, error) {
+	var file string
+	switch p2pNetwork {
+	case domain.P2pMainNet:
+		file = "chains/mainnet.yaml"
+	case domain.P2pTestNet:
+		file = "chains/testnet.yaml"
+	default:
+		return nil, fmt.Errorf("p2p network %s not supported", p2pNetwork)
+	}
+	data, err := defaultChains.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChains(data)
+}
+
+// ParseChains parses and validates a chains configuration.
+// The endpoints of the chains are not validated, see ValidateEndpoints.
+func ParseChains(data []byte) (*ChainsConfiguration, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(os.ExpandEnv(string(data)))))
+	decoder.KnownFields(true)
+
+	var cfg ChainsConfiguration
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid chains configuration: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate validates the chains configuration and resolves the chain IDs.
+// The contract addresses are normalized to lower case.
+func (c *ChainsConfiguration) Validate() error {
+	names := make(map[string]bool, len(c.Chains))
+	for i := range c.Chains {
+		chain := &c.Chains[i]
+		if chain.Name == "" {
+			return fmt.Errorf("chain %d: name is required", i)
+		}
+		if names[chain.Name] {
+			return fmt.Errorf("chain %s: duplicated name", chain.Name)
+		}
+		names[chain.Name] = true
+		if err := chain.validate(); err != nil {
+			return fmt.Errorf("chain %s: %w", chain.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *ChainConfig) validate() error {
+	chainID, err := vaa.ChainIDFromString(c.Chain)
+	if err != nil {
+		return err
+	}
+	c.ChainID = chainID
+
+	if c.WaitSeconds == 0 {
+		return errors.New("waitSeconds must be greater than 0")
+	}
+
+	switch c.Type {
+	case WatcherTypeEvm:
+		return c.validateEvm()
+	case WatcherTypeAnkr:
+		if c.ConfirmationBlocks != 0 || c.BlockTag != "" || c.DetectionMode != "" {
+			return errors.New("confirmationBlocks, blockTag and detectionMode are not supported by the ankr watcher")
+		}
+		return c.validateEvm()
+	case WatcherTypeSolana:
+		if _, err := solana_go.PublicKeyFromBase58(c.Address); err != nil {
+			return fmt.Errorf("invalid address %s: %w", c.Address, err)
+		}
+	case WatcherTypeTerra, WatcherTypeAptos:
+		if c.Address == "" {
+			return errors.New("address is required")
+		}
+	default:
+		return fmt.Errorf("unknown type %s", c.Type)
+	}
+	return nil
+}
+
+func (c *ChainConfig) validateEvm() error {
+	if c.SizeBlocks == 0 {
+		return errors.New("sizeBlocks must be greater than 0")
+	}
+	switch c.BlockTag {
+	case "", BlockTagFinalized, BlockTagSafe:
+	default:
+		return fmt.Errorf("unknown blockTag %s", c.BlockTag)
+	}
+
+	c.TokenBridgeAddress = strings.ToLower(c.TokenBridgeAddress)
+	switch c.DetectionMode {
+	case "", DetectionModeMethod:
+	case DetectionModeLogs:
+		if !evmAddressRegexp.MatchString(c.TokenBridgeAddress) {
+			return fmt.Errorf("invalid tokenBridgeAddress %s", c.TokenBridgeAddress)
+		}
+	default:
+		return fmt.Errorf("unknown detectionMode %s", c.DetectionMode)
+	}
+
+	if len(c.Contracts) == 0 {
+		return errors.New("at least one contract is required")
+	}
+	for i := range c.Contracts {
+		contract := &c.Contracts[i]
+		contract.Address = strings.ToLower(contract.Address)
+		if !evmAddressRegexp.MatchString(contract.Address) {
+			return fmt.Errorf("invalid contract address %s", contract.Address)
+		}
+		if len(contract.Methods) == 0 {
+			return fmt.Errorf("contract %s: at least one method is required", contract.Address)
+		}
+		for j := range contract.Methods {
+			method := &contract.Methods[j]
+			method.ID = strings.ToLower(method.ID)
+			if !methodIDRegexp.MatchString(method.ID) || method.Name == "" {
+				return fmt.Errorf("contract %s: invalid method %s %s", contract.Address, method.ID, method.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateEndpoints checks that the url and the rate limit of the enabled chains are set.
+// The url and the rate limit of the disabled chains may not be defined in the environment.
+func (c *ChainsConfiguration) ValidateEndpoints() error {
+	for _, chain := range c.Chains {
+		if !chain.Enabled {
+			continue
+		}
+		if chain.URL == "" {
+			return fmt.Errorf("chain %s: url is required", chain.Name)
+		}
+		if chain.RequestsPerSecond <= 0 {
+			return fmt.Errorf("chain %s: requestsPerSecond must be greater than 0", chain.Name)
+		}
+	}
+	return nil
+}
+
+// FindChain returns the configuration of a chain by watcher name or by chain name, e.g. ethereum.
+func (c *ChainsConfiguration) FindChain(name string) (*ChainConfig, bool) {
+	for i := range c.Chains {
+		if c.Chains[i].Name == name || c.Chains[i].ChainID.String() == name {
+			return &c.Chains[i], true
+		}
+	}
+	return nil, false
+}
+
+// WatcherBlockchainAddresses returns the settings of the evm watchers.
+func (c *ChainConfig) WatcherBlockchainAddresses() WatcherBlockchainAddresses {
+	methodsByAddress := make(map[string][]BlockchainMethod, len(c.Contracts))
+	for _, contract := range c.Contracts {
+		methodsByAddress[contract.Address] = append(methodsByAddress[contract.Address], contract.Methods...)
+	}
+	return WatcherBlockchainAddresses{
+		ChainID:            c.ChainID,
+		Name:               c.Name,
+		SizeBlocks:         c.SizeBlocks,
+		WaitSeconds:        c.WaitSeconds,
+		InitialBlock:       c.InitialBlock,
+		ConfirmationBlocks: c.ConfirmationBlocks,
+		BlockTag:           c.BlockTag,
+		DetectionMode:      c.DetectionMode,
+		TokenBridgeAddress: c.TokenBridgeAddress,
+		MethodsByAddress:   methodsByAddress,
+	}
+}
+
+// WatcherBlockchain returns the settings of the solana, terra and aptos watchers.
+func (c *ChainConfig) WatcherBlockchain() WatcherBlockchain {
+	return WatcherBlockchain{
+		ChainID:      c.ChainID,
+		Name:         c.Name,
+		Address:      c.Address,
+		SizeBlocks:   c.SizeBlocks,
+		WaitSeconds:  c.WaitSeconds,
+		InitialBlock: c.InitialBlock,
+	}
+}
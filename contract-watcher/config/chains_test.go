@@ -0,0 +1,143 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChains = `
+chains:
+  - name: eth
+    chain: ethereum
+    type: evm
+    enabled: true
+    url: ${TEST_ETHEREUM_URL}
+    requestsPerSecond: ${TEST_ETHEREUM_REQUESTS_PER_SECOND}
+    sizeBlocks: 100
+    waitSeconds: 10
+    initialBlock: 16820790
+    blockTag: safe
+    detectionMode: logs
+    tokenBridgeAddress: "0x3EE18B2214AFF97000D974CF647E7C347E8FA585"
+    contracts:
+      - address: "0x3EE18B2214AFF97000D974CF647E7C347E8FA585"
+        methods:
+          - id: "0xC6878519"
+            name: completeTransfer
+  - name: solana
+    chain: solana
+    type: solana
+    enabled: false
+    url: ${TEST_SOLANA_URL}
+    sizeBlocks: 50
+    waitSeconds: 10
+    initialBlock: 183675278
+    address: wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCgUb
+`
+
+func TestParseChains(t *testing.T) {
+	t.Setenv("TEST_ETHEREUM_URL", "http://localhost:8545")
+	t.Setenv("TEST_ETHEREUM_REQUESTS_PER_SECOND", "5")
+
+	chains, err := ParseChains([]byte(testChains))
+	require.NoError(t, err)
+	require.NoError(t, chains.ValidateEndpoints())
+	require.Len(t, chains.Chains, 2)
+
+	eth, ok := chains.FindChain("ethereum")
+	require.True(t, ok)
+	assert.Equal(t, vaa.ChainIDEthereum, eth.ChainID)
+	assert.Equal(t, "http://localhost:8545", eth.URL)
+	assert.Equal(t, 5, eth.RequestsPerSecond)
+
+	wb := eth.WatcherBlockchainAddresses()
+	assert.Equal(t, "0x3ee18b2214aff97000d974cf647e7c347e8fa585", wb.TokenBridgeAddress)
+	assert.Equal(t, []BlockchainMethod{{ID: MethodIDCompleteTransfer, Name: MethodCompleteTransfer}},
+		wb.MethodsByAddress["0x3ee18b2214aff97000d974cf647e7c347e8fa585"])
+
+	solana, ok := chains.FindChain("solana")
+	require.True(t, ok)
+	assert.False(t, solana.Enabled)
+	assert.Equal(t, "wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCgUb", solana.WatcherBlockchain().Address)
+}
+
+func TestParseChainsInvalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		chains string
+	}{
+		{
+			name:   "unknown field",
+			chains: "chains:\n  - name: eth\n    chain: ethereum\n    type: evm\n    waitSecs: 10\n",
+		},
+		{
+			name:   "unknown chain",
+			chains: "chains:\n  - name: eth\n    chain: unknown\n    type: evm\n    waitSeconds: 10\n",
+		},
+		{
+			name:   "unknown type",
+			chains: "chains:\n  - name: eth\n    chain: ethereum\n    type: unknown\n    waitSeconds: 10\n",
+		},
+		{
+			name:   "duplicated name",
+			chains: "chains:\n  - name: terra\n    chain: terra\n    type: terra\n    waitSeconds: 10\n    address: terra1\n  - name: terra\n    chain: terra\n    type: terra\n    waitSeconds: 10\n    address: terra1\n",
+		},
+		{
+			name:   "zero wait seconds",
+			chains: "chains:\n  - name: terra\n    chain: terra\n    type: terra\n    address: terra1\n",
+		},
+		{
+			name:   "evm without contracts",
+			chains: "chains:\n  - name: eth\n    chain: ethereum\n    type: evm\n    sizeBlocks: 100\n    waitSeconds: 10\n",
+		},
+		{
+			name:   "invalid method id",
+			chains: "chains:\n  - name: eth\n    chain: ethereum\n    type: evm\n    sizeBlocks: 100\n    waitSeconds: 10\n    contracts:\n      - address: \"0x3ee18b2214aff97000d974cf647e7c347e8fa585\"\n        methods:\n          - id: \"0xc68785\"\n            name: completeTransfer\n",
+		},
+		{
+			name:   "logs detection without token bridge",
+			chains: "chains:\n  - name: eth\n    chain: ethereum\n    type: evm\n    sizeBlocks: 100\n    waitSeconds: 10\n    detectionMode: logs\n    contracts:\n      - address: \"0x3ee18b2214aff97000d974cf647e7c347e8fa585\"\n        methods:\n          - id: \"0xc6878519\"\n            name: completeTransfer\n",
+		},
+		{
+			name:   "ankr with confirmation blocks",
+			chains: "chains:\n  - name: fantom\n    chain: fantom\n    type: ankr\n    sizeBlocks: 100\n    waitSeconds: 10\n    confirmationBlocks: 1\n    contracts:\n      - address: \"0x3ee18b2214aff97000d974cf647e7c347e8fa585\"\n        methods:\n          - id: \"0xc6878519\"\n            name: completeTransfer\n",
+		},
+		{
+			name:   "invalid solana address",
+			chains: "chains:\n  - name: solana\n    chain: solana\n    type: solana\n    waitSeconds: 10\n    address: \"0x1\"\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseChains([]byte(tc.chains))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidateEndpoints(t *testing.T) {
+	chains, err := ParseChains([]byte(testChains))
+	require.NoError(t, err)
+
+	// the url of the enabled chain is not defined in the environment.
+	assert.Error(t, chains.ValidateEndpoints())
+
+	// the endpoints of the disabled chains are not required.
+	chains.Chains[0].Enabled = false
+	assert.NoError(t, chains.ValidateEndpoints())
+}
+
+func TestLoadDefaultChains(t *testing.T) {
+	for _, network := range []string{domain.P2pMainNet, domain.P2pTestNet} {
+		chains, err := LoadDefaultChains(network)
+		require.NoError(t, err, network)
+		assert.NotEmpty(t, chains.Chains, network)
+	}
+
+	_, err := LoadDefaultChains("devnet")
+	assert.Error(t, err)
+}
@@ -19,36 +19,10 @@ type ServiceConfiguration struct {
 	AlertEnabled  bool   `env:"ALERT_ENABLED,required"`
 	AlertApiKey   string `env:"ALERT_API_KEY"`
 
-	AnkrUrl                    string `env:"ANKR_URL,required"`
-	AnkrRequestsPerSecond      int    `env:"ANKR_REQUESTS_PER_SECOND,required"`
-	AptosUrl                   string `env:"APTOS_URL,required"`
-	AptosRequestsPerSecond     int    `env:"APTOS_REQUESTS_PER_SECOND,required"`
-	ArbitrumUrl                string `env:"ARBITRUM_URL,required"`
-	ArbitrumRequestsPerSecond  int    `env:"ARBITRUM_REQUESTS_PER_SECOND,required"`
-	AvalancheUrl               string `env:"AVALANCHE_URL,required"`
-	AvalancheRequestsPerSecond int    `env:"AVALANCHE_REQUESTS_PER_SECOND,required"`
-	BaseUrl                    string `env:"BASE_URL,required"`
-	BaseRequestsPerSecond      int    `env:"BASE_REQUESTS_PER_SECOND,required"`
-	BscUrl                     string `env:"BSC_URL,required"`
-	BscRequestsPerSecond       int    `env:"BSC_REQUESTS_PER_SECOND,required"`
-	CeloUrl                    string `env:"CELO_URL,required"`
-	CeloRequestsPerSecond      int    `env:"CELO_REQUESTS_PER_SECOND,required"`
-	EthereumUrl                string `env:"ETHEREUM_URL,required"`
-	EthereumRequestsPerSecond  int    `env:"ETHEREUM_REQUESTS_PER_SECOND,required"`
-	MoonbeamUrl                string `env:"MOONBEAM_URL,required"`
-	MoonbeamRequestsPerSecond  int    `env:"MOONBEAM_REQUESTS_PER_SECOND,required"`
-	OptimismUrl                string `env:"OPTIMISM_URL,required"`
-	OptimismRequestsPerSecond  int    `env:"OPTIMISM_REQUESTS_PER_SECOND,required"`
-	OasisUrl                   string `env:"OASIS_URL,required"`
-	OasisRequestsPerSecond     int    `env:"OASIS_REQUESTS_PER_SECOND,required"`
-	PlanqUrl                   string `env:"PLANQ_URL,required"`
-	PlanqRequestsPerSecond     int    `env:"PLANQ_REQUESTS_PER_SECOND,required"`
-	PolygonUrl                 string `env:"POLYGON_URL,required"`
-	PolygonRequestsPerSecond   int    `env:"POLYGON_REQUESTS_PER_SECOND,required"`
-	SolanaUrl                  string `env:"SOLANA_URL,required"`
-	SolanaRequestsPerSecond    int    `env:"SOLANA_REQUESTS_PER_SECOND,required"`
-	TerraUrl                   string `env:"TERRA_URL,required"`
-	TerraRequestsPerSecond     int    `env:"TERRA_REQUESTS_PER_SECOND,required"`
+	// ChainsConfigPath is the path of the chains configuration file, when it is not set the configuration
+	// embedded for the p2p network is used. The file is reloaded when it changes.
+	ChainsConfigPath          string `env:"CHAINS_CONFIG_PATH"`
+	ChainsConfigReloadSeconds int    `env:"CHAINS_CONFIG_RELOAD_SECONDS,default=30"`
 }
 
 // BackfillerConfiguration represents the application configuration when running as backfiller.
@@ -64,6 +38,7 @@ type BackfillerConfiguration struct {
 	RateLimitPerSecond int    `env:"RATE_LIMIT_PER_SECOND,default=10"`
 	PageSize           uint64 `env:"PAGE_SIZE,default=100"`
 	PersistBlock       bool   `env:"PERSIST_BLOCK,default=false"`
+	ChainsConfigPath   string `env:"CHAINS_CONFIG_PATH"`
 }
 
 // New creates a configuration with the values from .env file and environment variables.
@@ -48,11 +48,35 @@ type WatcherBlockchainAddresses struct {
 	SizeBlocks  uint8
 	WaitSeconds uint16
 	// Initial block indicates for the supported contracts, the oldest block from which to start processing.
-	InitialBlock     int64
-	MethodsByAddress map[string][]BlockchainMethod
+	InitialBlock int64
+	// ConfirmationBlocks is the number of blocks behind the latest block that are considered final.
+	// It is ignored when BlockTag is set.
+	ConfirmationBlocks uint64
+	// BlockTag is the block tag of the last block to process (finalized or safe), the latest block is used if empty.
+	BlockTag string
+	// DetectionMode is how the redeems are detected, by the method of the transactions (default) or by the token bridge logs.
+	DetectionMode string
+	// TokenBridgeAddress is the address of the token bridge contract that emits the TransferRedeemed logs.
+	TokenBridgeAddress string
+	MethodsByAddress   map[string][]BlockchainMethod
 }
 
+// Redeem detection modes of the evm watchers.
+const (
+	// DetectionModeMethod detects the redeems by the method ID of the transactions sent to the watched contracts.
+	DetectionModeMethod = "method"
+	// DetectionModeLogs detects the redeems by the TransferRedeemed logs of the token bridge contract,
+	// including the redeems made through other contracts.
+	DetectionModeLogs = "logs"
+)
+
+// Block tags of the evm json-rpc api.
+const (
+	BlockTagFinalized = "finalized"
+	BlockTagSafe      = "safe"
+)
+
 type BlockchainMethod struct {
-	ID   string
-	Name string
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
 }
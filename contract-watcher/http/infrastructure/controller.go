@@ -4,19 +4,21 @@ import (
 	"fmt"
 
 	health "github.com/deltaswapio/deltaswap-explorer/common/health"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/processor"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
 // Controller definition.
 type Controller struct {
-	checks []health.Check
-	logger *zap.Logger
+	checks    []health.Check
+	processor *processor.Processor
+	logger    *zap.Logger
 }
 
 // NewController creates a Controller instance.
-func NewController(checks []health.Check, logger *zap.Logger) *Controller {
-	return &Controller{checks: checks, logger: logger}
+func NewController(checks []health.Check, processor *processor.Processor, logger *zap.Logger) *Controller {
+	return &Controller{checks: checks, processor: processor, logger: logger}
 }
 
 // HealthCheck handler for the endpoint /health.
@@ -44,3 +46,11 @@ func (c *Controller) ReadyCheck(ctx *fiber.Ctx) error {
 	}{Ready: "OK"})
 
 }
+
+// Watchers handler for the endpoint /admin/watchers.
+// It returns the watchers running with their current block and the lag to the last block of the chain.
+func (c *Controller) Watchers(ctx *fiber.Ctx) error {
+	return ctx.JSON(struct {
+		Watchers []processor.WatcherStatus `json:"watchers"`
+	}{Watchers: c.processor.Status()})
+}
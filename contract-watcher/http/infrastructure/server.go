@@ -3,6 +3,7 @@ package infrastructure
 import (
 	"github.com/ansrivas/fiberprometheus/v2"
 	health "github.com/deltaswapio/deltaswap-explorer/common/health"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/processor"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
 	"go.uber.org/zap"
@@ -14,7 +15,7 @@ type Server struct {
 	logger *zap.Logger
 }
 
-func NewServer(logger *zap.Logger, port string, pprofEnabled bool, checks ...health.Check) *Server {
+func NewServer(logger *zap.Logger, port string, pprofEnabled bool, processor *processor.Processor, checks ...health.Check) *Server {
 	app := fiber.New(fiber.Config{DisableStartupMessage: true})
 
 	// Configure middleware
@@ -27,10 +28,11 @@ func NewServer(logger *zap.Logger, port string, pprofEnabled bool, checks ...hea
 		app.Use(pprof.New())
 	}
 
-	ctrl := NewController(checks, logger)
+	ctrl := NewController(checks, processor, logger)
 	api := app.Group("/api")
 	api.Get("/health", ctrl.HealthCheck)
 	api.Get("/ready", ctrl.ReadyCheck)
+	api.Get("/admin/watchers", ctrl.Watchers)
 
 	return &Server{
 		app:    app,
@@ -58,6 +58,49 @@ func (s *EvmSDK) GetLatestBlock(ctx context.Context) (uint64, error) {
 	return utils.DecodeUint64(result.Result)
 }
 
+// GetBlockNumberByTag returns the number of the block identified by a block tag, e.g. finalized or safe.
+func (s *EvmSDK) GetBlockNumberByTag(ctx context.Context, tag string) (uint64, error) {
+	header, err := s.getBlockHeader(ctx, tag)
+	if err != nil {
+		return 0, err
+	}
+	return utils.DecodeUint64(header.Number)
+}
+
+// GetBlockHeader returns the header of a block, without the transactions.
+func (s *EvmSDK) GetBlockHeader(ctx context.Context, block uint64) (*BlockHeader, error) {
+	return s.getBlockHeader(ctx, utils.EncodeHex(block))
+}
+
+func (s *EvmSDK) getBlockHeader(ctx context.Context, block string) (*BlockHeader, error) {
+	s.rl.Take()
+	req := newEvmRequest("eth_getBlockByNumber", block, false)
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(req).
+		SetResult(&getBlockHeaderResponse{}).
+		Post("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-block-header", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*getBlockHeaderResponse)
+	if result == nil || result.Result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result.Result, nil
+}
+
 func (s *EvmSDK) GetBlock(ctx context.Context, block uint64) (*GetBlockResult, error) {
 	s.rl.Take()
 	req := newEvmRequest("eth_getBlockByNumber", utils.EncodeHex(block), true)
@@ -116,6 +159,72 @@ func (s *EvmSDK) GetTransactionReceipt(ctx context.Context, txHash string) (*Tra
 	return &result.Result, nil
 }
 
+// GetTransactionByHash returns a transaction.
+func (s *EvmSDK) GetTransactionByHash(ctx context.Context, txHash string) (*Transaction, error) {
+	s.rl.Take()
+	req := newEvmRequest("eth_getTransactionByHash", txHash)
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(req).
+		SetResult(&getTransactionResponse{}).
+		Post("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-transaction-by-hash", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*getTransactionResponse)
+	if result == nil || result.Result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result.Result, nil
+}
+
+// GetLogs returns the logs emitted by the given contracts with the given first topic in a range of blocks.
+func (s *EvmSDK) GetLogs(ctx context.Context, fromBlock, toBlock uint64, addresses []string, topic string) ([]Log, error) {
+	s.rl.Take()
+	filter := LogFilter{
+		FromBlock: utils.EncodeHex(fromBlock),
+		ToBlock:   utils.EncodeHex(toBlock),
+		Address:   addresses,
+		Topics:    [][]string{{topic}},
+	}
+	req := newEvmRequest("eth_getLogs", filter)
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(req).
+		SetResult(&getLogsResponse{}).
+		Post("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-logs", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*getLogsResponse)
+	if result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result.Result, nil
+}
+
 func newEvmRequest(method string, params ...any) EvmRequest {
 	return EvmRequest{
 		Jsonrpc: "2.0",
@@ -28,6 +28,7 @@ type Transaction struct {
 
 type GetBlockResult struct {
 	Hash         string        `json:"hash"`
+	ParentHash   string        `json:"parentHash"`
 	Number       string        `json:"number"`
 	Timestamp    string        `json:"timestamp"`
 	Transactions []Transaction `json:"transactions"`
@@ -37,6 +38,47 @@ type getBlockResponse struct {
 	Result GetBlockResult `json:"result"`
 }
 
+// BlockHeader is the header of a block, returned by eth_getBlockByNumber without the transactions.
+type BlockHeader struct {
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+	Number     string `json:"number"`
+	Timestamp  string `json:"timestamp"`
+}
+
+type getBlockHeaderResponse struct {
+	Result *BlockHeader `json:"result"`
+}
+
+type getTransactionResponse struct {
+	Result *Transaction `json:"result"`
+}
+
+// Log is an event log returned by eth_getLogs.
+type Log struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      string   `json:"blockNumber"`
+	BlockHash        string   `json:"blockHash"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+	LogIndex         string   `json:"logIndex"`
+	Removed          bool     `json:"removed"`
+}
+
+// LogFilter is the filter of eth_getLogs.
+type LogFilter struct {
+	FromBlock string     `json:"fromBlock"`
+	ToBlock   string     `json:"toBlock"`
+	Address   []string   `json:"address"`
+	Topics    [][]string `json:"topics"`
+}
+
+type getLogsResponse struct {
+	Result []Log `json:"result"`
+}
+
 type getTransactionReceiptResponse struct {
 	Result TransactionReceiptResult `json:"result"`
 }
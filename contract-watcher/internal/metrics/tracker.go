@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"sync"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// Blocks are the last block of a chain and the current block processed by its watcher.
+type Blocks struct {
+	Current uint64
+	Last    uint64
+}
+
+// BlockTracker is a Metrics implementation that keeps the blocks reported by the watchers
+// and delegates all the metrics to another Metrics implementation.
+type BlockTracker struct {
+	Metrics
+	mu     sync.RWMutex
+	blocks map[sdk.ChainID]Blocks
+}
+
+// NewBlockTracker returns a new instance of BlockTracker.
+func NewBlockTracker(metrics Metrics) *BlockTracker {
+	return &BlockTracker{Metrics: metrics, blocks: make(map[sdk.ChainID]Blocks)}
+}
+
+func (t *BlockTracker) SetLastBlock(chain sdk.ChainID, block uint64) {
+	t.mu.Lock()
+	b := t.blocks[chain]
+	b.Last = block
+	t.blocks[chain] = b
+	t.mu.Unlock()
+	t.Metrics.SetLastBlock(chain, block)
+}
+
+func (t *BlockTracker) SetCurrentBlock(chain sdk.ChainID, block uint64) {
+	t.mu.Lock()
+	b := t.blocks[chain]
+	b.Current = block
+	t.blocks[chain] = b
+	t.mu.Unlock()
+	t.Metrics.SetCurrentBlock(chain, block)
+}
+
+// GetBlocks returns the blocks reported for a chain.
+func (t *BlockTracker) GetBlocks(chain sdk.ChainID) (Blocks, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	b, ok := t.blocks[chain]
+	return b, ok
+}
@@ -2,28 +2,171 @@ package processor
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/config"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/metrics"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/watcher"
 	"go.uber.org/zap"
 )
 
+// WatcherFactory creates the watcher of a chain.
+type WatcherFactory func(chain config.ChainConfig) (watcher.ContractWatcher, error)
+
+// WatcherStatus is the status of a watcher.
+type WatcherStatus struct {
+	Name         string    `json:"name"`
+	ChainID      uint16    `json:"chainId"`
+	Type         string    `json:"type"`
+	Running      bool      `json:"running"`
+	CurrentBlock uint64    `json:"currentBlock"`
+	LastBlock    uint64    `json:"lastBlock"`
+	Lag          uint64    `json:"lag"`
+	StartedAt    time.Time `json:"startedAt"`
+}
+
+type runningWatcher struct {
+	chain     config.ChainConfig
+	cancel    context.CancelFunc
+	done      chan struct{}
+	startedAt time.Time
+}
+
+// Processor runs a watcher for each enabled chain of the configuration.
 type Processor struct {
-	watchers []watcher.ContractWatcher
+	factory  WatcherFactory
+	tracker  *metrics.BlockTracker
+	mu       sync.Mutex
+	watchers map[string]*runningWatcher
 	logger   *zap.Logger
 }
 
-func NewProcessor(watchers []watcher.ContractWatcher, logger *zap.Logger) *Processor {
-	return &Processor{watchers: watchers, logger: logger}
+// NewProcessor creates a new processor.
+// The tracker must be the metrics used by the watchers created by the factory.
+func NewProcessor(factory WatcherFactory, tracker *metrics.BlockTracker, logger *zap.Logger) *Processor {
+	return &Processor{
+		factory:  factory,
+		tracker:  tracker,
+		watchers: make(map[string]*runningWatcher),
+		logger:   logger,
+	}
 }
 
-func (p *Processor) Start(ctx context.Context) {
-	for _, watcher := range p.watchers {
-		go watcher.Start(ctx)
+// Apply starts and stops the watchers to match the enabled chains of a configuration.
+// The watchers of the chains whose configuration changed are restarted, the others keep running.
+func (p *Processor) Apply(ctx context.Context, chains []config.ChainConfig) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	enabled := make(map[string]config.ChainConfig)
+	for _, chain := range chains {
+		if chain.Enabled {
+			enabled[chain.Name] = chain
+		}
+	}
+
+	for name, w := range p.watchers {
+		chain, ok := enabled[name]
+		if ok && reflect.DeepEqual(chain, w.chain) {
+			continue
+		}
+		p.logger.Info("Stopping watcher", zap.String("blockchain", name))
+		w.stop()
+		delete(p.watchers, name)
+	}
+
+	var failed []string
+	for name, chain := range enabled {
+		if _, ok := p.watchers[name]; ok {
+			continue
+		}
+		contractWatcher, err := p.factory(chain)
+		if err != nil {
+			p.logger.Error("Failed to create watcher", zap.String("blockchain", name), zap.Error(err))
+			failed = append(failed, name)
+			continue
+		}
+		p.logger.Info("Starting watcher", zap.String("blockchain", name))
+		p.watchers[name] = p.start(ctx, chain, contractWatcher)
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("failed to create watchers %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (p *Processor) start(ctx context.Context, chain config.ChainConfig, contractWatcher watcher.ContractWatcher) *runningWatcher {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &runningWatcher{
+		chain:     chain,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		startedAt: time.Now(),
+	}
+	go func() {
+		defer close(w.done)
+		if err := contractWatcher.Start(ctx); err != nil {
+			p.logger.Error("Watcher stopped", zap.String("blockchain", chain.Name), zap.Error(err))
+		}
+	}()
+	return w
+}
+
+// stop cancels the context of the watcher and waits until it finishes processing the current blocks.
+func (w *runningWatcher) stop() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *runningWatcher) running() bool {
+	select {
+	case <-w.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Status returns the status of the watchers sorted by name.
+func (p *Processor) Status() []WatcherStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status := make([]WatcherStatus, 0, len(p.watchers))
+	for name, w := range p.watchers {
+		s := WatcherStatus{
+			Name:      name,
+			ChainID:   uint16(w.chain.ChainID),
+			Type:      w.chain.Type,
+			Running:   w.running(),
+			StartedAt: w.startedAt,
+		}
+		if blocks, ok := p.tracker.GetBlocks(w.chain.ChainID); ok {
+			s.CurrentBlock = blocks.Current
+			s.LastBlock = blocks.Last
+			if blocks.Last > blocks.Current {
+				s.Lag = blocks.Last - blocks.Current
+			}
+		}
+		status = append(status, s)
 	}
+	sort.Slice(status, func(i, j int) bool { return status[i].Name < status[j].Name })
+	return status
 }
 
+// Close stops all the watchers.
 func (p *Processor) Close() {
-	for _, watcher := range p.watchers {
-		watcher.Close()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for name, w := range p.watchers {
+		w.stop()
+		delete(p.watchers, name)
 	}
 }
@@ -0,0 +1,82 @@
+package processor
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/config"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/metrics"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/watcher"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeWatcher is a watcher that runs until its context is cancelled.
+type fakeWatcher struct{}
+
+func (w *fakeWatcher) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (w *fakeWatcher) Close() {}
+
+func (w *fakeWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+}
+
+type fakeFactory struct {
+	mu      sync.Mutex
+	created []string
+}
+
+func (f *fakeFactory) create(chain config.ChainConfig) (watcher.ContractWatcher, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created = append(f.created, chain.Name)
+	return &fakeWatcher{}, nil
+}
+
+func TestProcessorApply(t *testing.T) {
+	factory := &fakeFactory{}
+	tracker := metrics.NewBlockTracker(metrics.NewNoopMetrics())
+	p := NewProcessor(factory.create, tracker, zap.NewNop())
+	defer p.Close()
+
+	eth := config.ChainConfig{Name: "eth", ChainID: vaa.ChainIDEthereum, Type: config.WatcherTypeEvm, Enabled: true, WaitSeconds: 10}
+	bsc := config.ChainConfig{Name: "bsc", ChainID: vaa.ChainIDBSC, Type: config.WatcherTypeEvm, Enabled: true, WaitSeconds: 5}
+	solana := config.ChainConfig{Name: "solana", ChainID: vaa.ChainIDSolana, Type: config.WatcherTypeSolana, Enabled: false}
+
+	require.NoError(t, p.Apply(context.Background(), []config.ChainConfig{eth, bsc, solana}))
+	assert.ElementsMatch(t, []string{"eth", "bsc"}, factory.created)
+
+	tracker.SetLastBlock(vaa.ChainIDEthereum, 120)
+	tracker.SetCurrentBlock(vaa.ChainIDEthereum, 100)
+	status := p.Status()
+	require.Len(t, status, 2)
+	assert.Equal(t, "bsc", status[0].Name)
+	assert.Equal(t, "eth", status[1].Name)
+	assert.True(t, status[1].Running)
+	assert.Equal(t, uint64(100), status[1].CurrentBlock)
+	assert.Equal(t, uint64(120), status[1].LastBlock)
+	assert.Equal(t, uint64(20), status[1].Lag)
+
+	// the unchanged watchers keep running, the changed ones are restarted and the disabled ones are stopped.
+	factory.created = nil
+	eth.WaitSeconds = 20
+	bsc.Enabled = false
+	solana.Enabled = true
+	require.NoError(t, p.Apply(context.Background(), []config.ChainConfig{eth, bsc, solana}))
+	assert.ElementsMatch(t, []string{"eth", "solana"}, factory.created)
+
+	factory.created = nil
+	require.NoError(t, p.Apply(context.Background(), []config.ChainConfig{eth, bsc, solana}))
+	assert.Empty(t, factory.created)
+
+	status = p.Status()
+	require.Len(t, status, 2)
+	assert.Equal(t, "eth", status[0].Name)
+	assert.Equal(t, "solana", status[1].Name)
+}
@@ -43,7 +43,17 @@ func (t *TransactionUpdate) ToMap() map[string]string {
 }
 
 type WatcherBlock struct {
-	ID          string    `bson:"_id"`
-	BlockNumber int64     `bson:"blockNumber"`
-	UpdatedAt   time.Time `bson:"updatedAt"`
+	ID          string `bson:"_id"`
+	BlockNumber int64  `bson:"blockNumber"`
+	// BlockHash is the hash of the last block processed, it is used to detect the reorgs on restart.
+	BlockHash string `bson:"blockHash,omitempty"`
+	// BlockHashes are the hashes of the last blocks processed, they are used to find where a reorg starts on restart.
+	BlockHashes []BlockHash `bson:"blockHashes,omitempty"`
+	UpdatedAt   time.Time   `bson:"updatedAt"`
+}
+
+// BlockHash is the hash of a block processed by a watcher.
+type BlockHash struct {
+	BlockNumber int64  `bson:"blockNumber"`
+	Hash        string `bson:"hash"`
 }
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
@@ -83,6 +84,30 @@ func (s *Repository) GetGlobalTransactionByID(ctx context.Context, id string) (T
 	return tx, nil
 }
 
+// FindDestinationTxsByBlockRange finds the global transactions with a destination transaction
+// in the given range of blocks of a chain.
+func (s *Repository) FindDestinationTxsByBlockRange(ctx context.Context, chainID sdk.ChainID, fromBlock, toBlock uint64) ([]TransactionUpdate, error) {
+	// the block number of the destination transaction is stored as a decimal string.
+	blocks := make([]string, 0, toBlock-fromBlock+1)
+	for block := fromBlock; block <= toBlock; block++ {
+		blocks = append(blocks, strconv.FormatUint(block, 10))
+	}
+	filter := bson.M{
+		"destinationTx.chainId":     chainID,
+		"destinationTx.blockNumber": bson.M{"$in": blocks},
+	}
+	cur, err := s.collections.globalTransactions.Find(ctx, filter)
+	if err != nil {
+		s.log.Error("Error finding destination txs by block range", zap.Error(err))
+		return nil, err
+	}
+	var txs []TransactionUpdate
+	if err := cur.All(ctx, &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
 func (s *Repository) UpdateWatcherBlock(ctx context.Context, chainID sdk.ChainID, watcherBlock WatcherBlock) error {
 	update := bson.M{
 		"$set":         watcherBlock,
@@ -108,3 +133,16 @@ func (s *Repository) GetCurrentBlock(ctx context.Context, blockchain string, def
 	}
 	return block.BlockNumber, nil
 }
+
+// GetWatcherBlock returns the last block processed by the watcher of a blockchain.
+func (s *Repository) GetWatcherBlock(ctx context.Context, blockchain string) (*WatcherBlock, error) {
+	var block WatcherBlock
+	err := s.collections.watcherBlock.FindOne(ctx, bson.M{"_id": blockchain}).Decode(&block)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrDocNotFound
+		}
+		return nil, err
+	}
+	return &block, nil
+}
@@ -22,12 +22,16 @@ const (
 )
 
 type EVMParams struct {
-	ChainID          vaa.ChainID
-	Blockchain       string
-	SizeBlocks       uint8
-	WaitSeconds      uint16
-	InitialBlock     int64
-	MethodsByAddress map[string][]config.BlockchainMethod
+	ChainID            vaa.ChainID
+	Blockchain         string
+	SizeBlocks         uint8
+	WaitSeconds        uint16
+	InitialBlock       int64
+	ConfirmationBlocks uint64
+	BlockTag           string
+	DetectionMode      string
+	TokenBridgeAddress string
+	MethodsByAddress   map[string][]config.BlockchainMethod
 }
 
 type EVMAddressesParams struct {
@@ -70,6 +74,17 @@ func getMethodIDByInput(input string) string {
 	return input[0:10]
 }
 
+// get the name of the method called by a transaction to a watched contract, or unknown if the method is not watched.
+func getMethodName(methodsByAddress map[string][]config.BlockchainMethod, to string, input string) string {
+	methodID := getMethodIDByInput(input)
+	for _, method := range methodsByAddress[strings.ToLower(to)] {
+		if method.ID == methodID {
+			return method.Name
+		}
+	}
+	return config.MethodUnkown
+}
+
 // get the input and extract the method signature and VAA
 func parseInput(input string) (*vaa.VAA, error) {
 	// remove the first 64 characters plus 0x
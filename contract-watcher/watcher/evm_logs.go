@@ -0,0 +1,181 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/common/utils"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/evm"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/storage"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// transferRedeemedTopic is the topic of the token bridge event
+// TransferRedeemed(uint16 indexed emitterChainId, bytes32 indexed emitterAddress, uint64 indexed sequence).
+const transferRedeemedTopic = "0xcaf280c8cfeba144da67230d9b009c8f868a75bac9a528fa0474be1ba317c169"
+
+var ErrInvalidTransferRedeemedLog = errors.New("invalid TransferRedeemed log")
+
+// getVaaIDFromTransferRedeemed returns the vaa ID of a redeem from the topics of a TransferRedeemed log.
+func getVaaIDFromTransferRedeemed(log *evm.Log) (string, error) {
+	if len(log.Topics) != 4 || !strings.EqualFold(log.Topics[0], transferRedeemedTopic) {
+		return "", ErrInvalidTransferRedeemedLog
+	}
+	chainID, err := utils.DecodeUint64(log.Topics[1])
+	if err != nil || chainID > math.MaxUint16 {
+		return "", ErrInvalidTransferRedeemedLog
+	}
+	emitterAddress, err := vaa.StringToAddress(utils.Remove0x(log.Topics[2]))
+	if err != nil {
+		return "", ErrInvalidTransferRedeemedLog
+	}
+	sequence, err := utils.DecodeUint64(log.Topics[3])
+	if err != nil {
+		return "", ErrInvalidTransferRedeemedLog
+	}
+	return fmt.Sprintf("%d/%s/%d", chainID, emitterAddress, sequence), nil
+}
+
+// groupLogsByBlock groups the logs by block number, the logs removed by a reorg are discarded.
+func groupLogsByBlock(logs []evm.Log) (map[uint64][]evm.Log, error) {
+	logsByBlock := make(map[uint64][]evm.Log)
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		block, err := utils.DecodeUint64(l.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		logsByBlock[block] = append(logsByBlock[block], l)
+	}
+	return logsByBlock, nil
+}
+
+// processLogs detects the redeems of a range of blocks from the TransferRedeemed logs of the token bridge.
+//
+// Unlike the detection by method, the redeems made through other contracts (relayers, multicalls) are detected,
+// but the failed redeems are not, because the failed transactions don't emit logs.
+// When the logs of the range can't be fetched, an error is returned and none of its blocks is processed.
+// When a block can't be processed, an error is returned and the blocks from it to the end of the range are not processed.
+func (w *EvmStandardWatcher) processLogs(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) error {
+	var logsByBlock map[uint64][]evm.Log
+	err := retry.Do(
+		func() error {
+			logs, err := w.client.GetLogs(ctx, fromBlock, toBlock, []string{w.tokenBridgeAddress}, transferRedeemedTopic)
+			if err != nil {
+				w.logger.Error("cannot get logs", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+				return err
+			}
+			logsByBlock, err = groupLogsByBlock(logs)
+			return err
+		},
+		retry.Attempts(evmMaxRetries),
+		retry.Delay(evmRetryDelay),
+	)
+	if err != nil {
+		return err
+	}
+
+	for block := fromBlock; block <= toBlock; block++ {
+		blockLogs := logsByBlock[block]
+		// the headers are only needed to check the reorgs and get the timestamp of the redeems.
+		if len(blockLogs) == 0 && !updateWatcherBlock {
+			continue
+		}
+		w.logger.Debug("processing block logs", zap.Uint64("block", block), zap.Int("logs", len(blockLogs)))
+		err := retry.Do(
+			func() error {
+				header, err := w.client.GetBlockHeader(ctx, block)
+				if err != nil {
+					w.logger.Error("cannot get block header", zap.Uint64("block", block), zap.Error(err))
+					return err
+				}
+
+				if updateWatcherBlock {
+					if err := w.checkReorg(ctx, block, header.Hash, header.ParentHash); err != nil {
+						return err
+					}
+				}
+
+				// the logs of the range may belong to a block removed by a reorg.
+				if len(blockLogs) > 0 && !strings.EqualFold(blockLogs[0].BlockHash, header.Hash) {
+					logs, err := w.client.GetLogs(ctx, block, block, []string{w.tokenBridgeAddress}, transferRedeemedTopic)
+					if err != nil {
+						return err
+					}
+					logsByBlock, err := groupLogsByBlock(logs)
+					if err != nil {
+						return err
+					}
+					blockLogs = logsByBlock[block]
+				}
+
+				for i := range blockLogs {
+					if err := w.processRedeemLog(ctx, &blockLogs[i], header); err != nil {
+						return err
+					}
+				}
+
+				if updateWatcherBlock {
+					return w.updateWatcherBlock(ctx, block, header.Hash)
+				}
+				return nil
+			},
+			retry.Attempts(evmMaxRetries),
+			retry.Delay(evmRetryDelay),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processRedeemLog updates the destination tx of the vaa redeemed in a TransferRedeemed log.
+// The from and to of the destination tx are the ones of the transaction that emitted the log.
+// An error is returned when the transaction can't be fetched, so the block is processed again.
+func (w *EvmStandardWatcher) processRedeemLog(ctx context.Context, l *evm.Log, header *evm.BlockHeader) error {
+	log := w.logger.With(
+		zap.String("txHash", l.TransactionHash),
+		zap.String("block", l.BlockNumber))
+
+	vaaID, err := getVaaIDFromTransferRedeemed(l)
+	if err != nil {
+		log.Error("cannot get vaa id from log", zap.Strings("topics", l.Topics), zap.Error(err))
+		return nil
+	}
+
+	tx, err := w.client.GetTransactionByHash(ctx, l.TransactionHash)
+	if err != nil {
+		log.Error("cannot get transaction", zap.Error(err))
+		return err
+	}
+
+	updatedAt := time.Now()
+	globalTx := storage.TransactionUpdate{
+		ID: vaaID,
+		Destination: storage.DestinationTx{
+			ChainID: w.chainID,
+			// the TransferRedeemed log is only emitted by the successful redeems.
+			Status:      domain.DstTxStatusConfirmed,
+			Method:      getMethodName(w.methodsByAddress, tx.To, tx.Input),
+			TxHash:      utils.Remove0x(tx.Hash),
+			To:          tx.To,
+			From:        tx.From,
+			BlockNumber: getBlockNumber(l.BlockNumber, log),
+			Timestamp:   getTimestamp(header.Timestamp, log),
+			UpdatedAt:   &updatedAt,
+		},
+	}
+
+	updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
+	return nil
+}
@@ -0,0 +1,136 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/config"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/evm"
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/ratelimit"
+)
+
+// newFixtureServer creates a json-rpc server that replies with the responses recorded in testdata.
+func newFixtureServer(t *testing.T, fixtures map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req evm.EvmRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		key := req.Method
+		if req.Method == "eth_getTransactionByHash" {
+			key = key + ":" + req.Params[0].(string)
+		}
+		fixture, ok := fixtures[key]
+		if !ok {
+			t.Errorf("unexpected request %s", key)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := os.ReadFile(filepath.Join("testdata", fixture))
+		if err != nil {
+			t.Errorf("cannot read fixture %s: %v", fixture, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+}
+
+func TestTransferRedeemedLogs(t *testing.T) {
+	server := newFixtureServer(t, map[string]string{
+		"eth_getLogs": "eth_getLogs.json",
+		"eth_getTransactionByHash:0x6a1b0ce4c2d5b3e64e31a1a9ff5a3c0e2bc5e25f1c7b0aeae1d0b5a1a70c3f2d": "eth_getTransactionByHash_relayer.json",
+		"eth_getTransactionByHash:0x0f3e1d2c3b4a59687766554433221100ffeeddccbbaa99887766554433221100": "eth_getTransactionByHash_multicall.json",
+	})
+	defer server.Close()
+
+	chains, err := config.LoadDefaultChains(domain.P2pMainNet)
+	require.NoError(t, err)
+	ethereum, ok := chains.FindChain("eth")
+	require.True(t, ok)
+	wb := ethereum.WatcherBlockchainAddresses()
+
+	client := evm.NewEvmSDK(server.URL, ratelimit.NewUnlimited(), metrics.NewNoopMetrics())
+	tokenBridge := wb.TokenBridgeAddress
+	logs, err := client.GetLogs(context.Background(), 17132302, 17132304, []string{tokenBridge}, transferRedeemedTopic)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+
+	// the log removed by a reorg is discarded.
+	logsByBlock, err := groupLogsByBlock(logs)
+	require.NoError(t, err)
+	assert.Len(t, logsByBlock, 2)
+	assert.Empty(t, logsByBlock[17132303])
+
+	expected := []struct {
+		block  uint64
+		vaaID  string
+		from   string
+		to     string
+		method string
+	}{
+		{
+			// redeem through the relayer contract.
+			block:  17132302,
+			vaaID:  "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/276772",
+			from:   "0x8ea9b1b2a7c6e1f4d3a0b5c6d7e8f9a0b1c2d3e4",
+			to:     "0xcafd2f0a35a4459fa40c0517e17e6fa2939441ca",
+			method: config.MetehodCompleteTransferWithRelay,
+		},
+		{
+			// redeem through a multicall contract that is not watched.
+			block:  17132304,
+			vaaID:  "4/000000000000000000000000b6f6d86a8f9879a9c87f643768d9efc38c1da6e7/238066",
+			from:   "0x4b2e8f1d6c3a5b7e9f0d2c4a6b8e0f1d3c5a7b9e",
+			to:     "0xca11bde05977b3631167028862be2a173976ca11",
+			method: config.MethodUnkown,
+		},
+	}
+	for _, e := range expected {
+		blockLogs := logsByBlock[e.block]
+		require.Len(t, blockLogs, 1)
+
+		vaaID, err := getVaaIDFromTransferRedeemed(&blockLogs[0])
+		require.NoError(t, err)
+		assert.Equal(t, e.vaaID, vaaID)
+
+		tx, err := client.GetTransactionByHash(context.Background(), blockLogs[0].TransactionHash)
+		require.NoError(t, err)
+		assert.Equal(t, e.from, tx.From)
+		assert.Equal(t, e.to, tx.To)
+		assert.Equal(t, e.method, getMethodName(wb.MethodsByAddress, tx.To, tx.Input))
+	}
+}
+
+func TestGetVaaIDFromTransferRedeemedInvalid(t *testing.T) {
+	_, err := getVaaIDFromTransferRedeemed(&evm.Log{
+		Topics: []string{
+			"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+			"0x0000000000000000000000000000000000000000000000000000000000000001",
+			"0xec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5",
+			"0x0000000000000000000000000000000000000000000000000000000000043924",
+		},
+	})
+	assert.ErrorIs(t, err, ErrInvalidTransferRedeemedLog)
+
+	_, err = getVaaIDFromTransferRedeemed(&evm.Log{
+		Topics: []string{
+			transferRedeemedTopic,
+			"0x0000000000000000000000000000000000000000000000000000000000010001",
+			"0xec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5",
+			"0x0000000000000000000000000000000000000000000000000000000000043924",
+		},
+	})
+	assert.ErrorIs(t, err, ErrInvalidTransferRedeemedLog)
+}
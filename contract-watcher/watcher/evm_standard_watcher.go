@@ -2,11 +2,13 @@ package watcher
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/avast/retry-go"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/config"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/evm"
 	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/internal/metrics"
@@ -21,19 +23,24 @@ const (
 )
 
 type EvmStandardWatcher struct {
-	client           *evm.EvmSDK
-	chainID          vaa.ChainID
-	blockchain       string
-	contractAddress  []string
-	methodsByAddress map[string][]config.BlockchainMethod
-	maxBlocks        uint64
-	waitSeconds      uint16
-	initialBlock     int64
-	repository       *storage.Repository
-	logger           *zap.Logger
-	close            chan bool
-	wg               sync.WaitGroup
-	metrics          metrics.Metrics
+	client             *evm.EvmSDK
+	chainID            vaa.ChainID
+	blockchain         string
+	contractAddress    []string
+	methodsByAddress   map[string][]config.BlockchainMethod
+	maxBlocks          uint64
+	waitSeconds        uint16
+	initialBlock       int64
+	confirmationBlocks uint64
+	blockTag           string
+	detectionMode      string
+	tokenBridgeAddress string
+	hashes             *blockHashes
+	repository         *storage.Repository
+	logger             *zap.Logger
+	close              chan bool
+	wg                 sync.WaitGroup
+	metrics            metrics.Metrics
 }
 
 func NewEvmStandardWatcher(client *evm.EvmSDK, params EVMParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *EvmStandardWatcher {
@@ -42,28 +49,44 @@ func NewEvmStandardWatcher(client *evm.EvmSDK, params EVMParams, repo *storage.R
 		addresses = append(addresses, address)
 	}
 	return &EvmStandardWatcher{
-		client:           client,
-		chainID:          params.ChainID,
-		blockchain:       params.Blockchain,
-		contractAddress:  addresses,
-		methodsByAddress: params.MethodsByAddress,
-		maxBlocks:        uint64(params.SizeBlocks),
-		waitSeconds:      params.WaitSeconds,
-		initialBlock:     params.InitialBlock,
-		repository:       repo,
-		metrics:          metrics,
-		logger:           logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID))),
+		client:             client,
+		chainID:            params.ChainID,
+		blockchain:         params.Blockchain,
+		contractAddress:    addresses,
+		methodsByAddress:   params.MethodsByAddress,
+		maxBlocks:          uint64(params.SizeBlocks),
+		waitSeconds:        params.WaitSeconds,
+		initialBlock:       params.InitialBlock,
+		confirmationBlocks: params.ConfirmationBlocks,
+		blockTag:           params.BlockTag,
+		detectionMode:      params.DetectionMode,
+		tokenBridgeAddress: params.TokenBridgeAddress,
+		hashes:             newBlockHashes(maxReorgDepth),
+		repository:         repo,
+		metrics:            metrics,
+		logger:             logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID))),
 	}
 }
 
 func (w *EvmStandardWatcher) Start(ctx context.Context) error {
 	// get the current block for the chain.
-	cBlock, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
-	if err != nil {
+	currentBlock := uint64(w.initialBlock)
+	watcherBlock, err := w.repository.GetWatcherBlock(ctx, w.blockchain)
+	switch {
+	case err == nil:
+		currentBlock = uint64(watcherBlock.BlockNumber)
+		// the hashes of the last blocks processed are used to detect a reorg while the watcher was stopped.
+		for _, h := range watcherBlock.BlockHashes {
+			w.hashes.set(uint64(h.BlockNumber), h.Hash)
+		}
+		if watcherBlock.BlockHash != "" {
+			w.hashes.set(currentBlock, watcherBlock.BlockHash)
+		}
+	case errors.Is(err, storage.ErrDocNotFound):
+	default:
 		w.logger.Error("cannot get current block", zap.Error(err))
 		return err
 	}
-	currentBlock := uint64(cBlock)
 	w.wg.Add(1)
 	for {
 		select {
@@ -76,23 +99,29 @@ func (w *EvmStandardWatcher) Start(ctx context.Context) error {
 			w.wg.Done()
 			return nil
 		default:
-			// get the latest block for the chain.
-			lastBlock, err := w.client.GetLatestBlock(ctx)
+			// get the last block that can't be removed by a reorg.
+			lastBlock, err := w.getLastBlock(ctx)
 			if err != nil {
 				w.logger.Error("cannot get latest block", zap.Error(err))
 			}
 			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
 
+			// the next block to process, it is not advanced past a range that failed.
+			nextBlock := lastBlock
 			if currentBlock < lastBlock {
 				w.metrics.SetLastBlock(w.chainID, lastBlock)
+				// process all the blocks between current and last block.
 				totalBlocks := getTotalBlocks(lastBlock, currentBlock, w.maxBlocks)
 				for i := uint64(0); i < totalBlocks; i++ {
 					fromBlock, toBlock := getPage(currentBlock, i, w.maxBlocks, lastBlock)
 					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
-					w.processBlock(ctx, fromBlock, toBlock, true)
+					if err := w.processBlock(ctx, fromBlock, toBlock, true); err != nil {
+						w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+						nextBlock = fromBlock
+						break
+					}
 					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 				}
-				// process all the blocks between current and last block.
 			} else {
 				w.logger.Debug("waiting for new blocks")
 				select {
@@ -102,8 +131,8 @@ func (w *EvmStandardWatcher) Start(ctx context.Context) error {
 				case <-time.After(time.Duration(w.waitSeconds) * time.Second):
 				}
 			}
-			if lastBlock > currentBlock {
-				currentBlock = lastBlock
+			if nextBlock > currentBlock {
+				currentBlock = nextBlock
 			}
 		}
 	}
@@ -115,15 +144,23 @@ func (w *EvmStandardWatcher) Backfill(ctx context.Context, fromBlock uint64, toB
 	for i := uint64(0); i < totalBlocks; i++ {
 		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
 		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
-		w.processBlock(ctx, fromBlock, toBlock, persistBlock)
+		if err := w.processBlock(ctx, fromBlock, toBlock, persistBlock); err != nil {
+			// the backfill stops at the failed range, so it can be run again from it.
+			w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+			return
+		}
 		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 	}
 }
 
-func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) {
+// processBlock processes a range of blocks, it stops at the first block that can't be processed.
+func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) error {
+	if w.detectionMode == config.DetectionModeLogs {
+		return w.processLogs(ctx, fromBlock, toBlock, updateWatcherBlock)
+	}
 	for block := fromBlock; block <= toBlock; block++ {
 		w.logger.Debug("processing block", zap.Uint64("block", block))
-		retry.Do(
+		err := retry.Do(
 			func() error {
 				// get the transactions for the block.
 				blockResult, err := w.client.GetBlock(ctx, block)
@@ -135,6 +172,12 @@ func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64,
 					return nil
 				}
 
+				if updateWatcherBlock {
+					if err := w.checkReorg(ctx, block, blockResult.Hash, blockResult.ParentHash); err != nil {
+						return err
+					}
+				}
+
 				for _, tx := range blockResult.Transactions {
 
 					// only process transactions to the contract address.
@@ -180,20 +223,140 @@ func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64,
 				}
 
 				if updateWatcherBlock {
-					// update the last block number processed in the database.
-					watcherBlock := storage.WatcherBlock{
-						ID:          w.blockchain,
-						BlockNumber: int64(block),
-						UpdatedAt:   time.Now(),
-					}
-					return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+					return w.updateWatcherBlock(ctx, block, blockResult.Hash)
 				}
 				return nil
 			},
 			retry.Attempts(evmMaxRetries),
 			retry.Delay(evmRetryDelay),
 		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getLastBlock returns the last block to process, the blocks after it can still be removed by a reorg.
+func (w *EvmStandardWatcher) getLastBlock(ctx context.Context) (uint64, error) {
+	if w.blockTag != "" {
+		return w.client.GetBlockNumberByTag(ctx, w.blockTag)
+	}
+	latestBlock, err := w.client.GetLatestBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if latestBlock < w.confirmationBlocks {
+		return 0, nil
+	}
+	return latestBlock - w.confirmationBlocks, nil
+}
+
+// updateWatcherBlock records the last block processed.
+func (w *EvmStandardWatcher) updateWatcherBlock(ctx context.Context, block uint64, hash string) error {
+	w.hashes.set(block, hash)
+	// update the last block number processed in the database.
+	watcherBlock := storage.WatcherBlock{
+		ID:          w.blockchain,
+		BlockNumber: int64(block),
+		BlockHash:   hash,
+		BlockHashes: w.hashes.window(w.persistedHashes()),
+		UpdatedAt:   time.Now(),
+	}
+	return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+}
+
+// persistedHashes returns the number of hashes of the last blocks processed that are persisted.
+// The blocks are processed once confirmed, so the reorgs that reach them are bounded by the confirmations.
+func (w *EvmStandardWatcher) persistedHashes() uint64 {
+	switch {
+	case w.confirmationBlocks == 0:
+		return 1
+	case w.confirmationBlocks > maxReorgDepth:
+		return maxReorgDepth
+	default:
+		return w.confirmationBlocks
+	}
+}
+
+// checkReorg checks the block is a descendant of the blocks already processed, and handles the reorg otherwise.
+func (w *EvmStandardWatcher) checkReorg(ctx context.Context, block uint64, hash, parentHash string) error {
+	// the blocks left behind by a failed reorg are processed again before the block is checked.
+	if last, ok := w.hashes.last(); ok && last+1 < block {
+		if err := w.processBlock(ctx, last+1, block-1, true); err != nil {
+			return err
+		}
+	}
+	forkBlock, reorg, err := w.findForkBlock(ctx, block, hash, parentHash)
+	if err != nil {
+		w.logger.Error("cannot check chain reorg", zap.Uint64("block", block), zap.Error(err))
+		return err
+	}
+	if !reorg {
+		return nil
+	}
+	return w.handleReorg(ctx, forkBlock, block)
+}
+
+// findForkBlock checks if a block is a descendant of the blocks already processed.
+// When a reorg is detected, it returns the last processed block that is still in the chain.
+//
+// After a restart only the hashes persisted with the last block processed are known, see persistedHashes.
+func (w *EvmStandardWatcher) findForkBlock(ctx context.Context, block uint64, hash, parentHash string) (uint64, bool, error) {
+	if block == 0 {
+		return 0, false, nil
+	}
+	var reorg bool
+	if h, ok := w.hashes.get(block); ok && h != hash {
+		reorg = true
+	}
+	if h, ok := w.hashes.get(block - 1); ok && h != parentHash {
+		reorg = true
+	}
+	if !reorg {
+		return 0, false, nil
+	}
+
+	// walk back the processed blocks until one of them is still in the chain.
+	for n := block - 1; n > 0; n-- {
+		hash, ok := w.hashes.get(n)
+		if !ok {
+			// the hashes of the older blocks are unknown, the reorg is assumed to start after this block.
+			return n, true, nil
+		}
+		header, err := w.client.GetBlockHeader(ctx, n)
+		if err != nil {
+			return 0, false, err
+		}
+		if header.Hash == hash {
+			return n, true, nil
+		}
+	}
+	return 0, true, nil
+}
+
+// handleReorg downgrades the destination txs of the blocks removed by a reorg and
+// processes again the blocks of the new chain up to the given block.
+func (w *EvmStandardWatcher) handleReorg(ctx context.Context, forkBlock uint64, block uint64) error {
+	w.logger.Warn("chain reorg detected", zap.Uint64("forkBlock", forkBlock), zap.Uint64("block", block))
+
+	txs, err := w.repository.FindDestinationTxsByBlockRange(ctx, w.chainID, forkBlock+1, block)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		updatedAt := time.Now()
+		tx.Destination.Status = domain.DstTxStatusOrphaned
+		tx.Destination.UpdatedAt = &updatedAt
+		updateGlobalTransaction(ctx, w.chainID, tx, w.repository, w.logger)
+	}
+
+	// the txs of the new chain update the status of the orphaned txs.
+	w.hashes.truncate(forkBlock)
+	if forkBlock+1 < block {
+		return w.processBlock(ctx, forkBlock+1, block-1, true)
 	}
+	return nil
 }
 
 func (w *EvmStandardWatcher) Close() {
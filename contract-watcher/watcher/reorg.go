@@ -0,0 +1,74 @@
+package watcher
+
+import "github.com/deltaswapio/deltaswap-explorer/contract-watcher/storage"
+
+// maxReorgDepth is the number of processed blocks whose hashes are kept to find where a reorg starts.
+const maxReorgDepth = 256
+
+// blockHashes keeps the hashes of the last blocks processed by a watcher.
+type blockHashes struct {
+	hashes map[uint64]string
+	size   uint64
+}
+
+func newBlockHashes(size uint64) *blockHashes {
+	return &blockHashes{hashes: make(map[uint64]string, size), size: size}
+}
+
+// get returns the hash of a processed block.
+func (b *blockHashes) get(block uint64) (string, bool) {
+	hash, ok := b.hashes[block]
+	return hash, ok
+}
+
+// set records the hash of a processed block and discards the hashes older than the size of the window.
+func (b *blockHashes) set(block uint64, hash string) {
+	b.hashes[block] = hash
+	if uint64(len(b.hashes)) <= b.size {
+		return
+	}
+	for n := range b.hashes {
+		if n+b.size <= block {
+			delete(b.hashes, n)
+		}
+	}
+}
+
+// last returns the last block processed.
+func (b *blockHashes) last() (uint64, bool) {
+	var last uint64
+	for n := range b.hashes {
+		if n > last {
+			last = n
+		}
+	}
+	return last, len(b.hashes) > 0
+}
+
+// window returns the hashes of the last blocks processed, up to the given number of blocks, sorted by block.
+func (b *blockHashes) window(size uint64) []storage.BlockHash {
+	last, ok := b.last()
+	if !ok {
+		return nil
+	}
+	var from uint64
+	if last+1 > size {
+		from = last + 1 - size
+	}
+	var hashes []storage.BlockHash
+	for n := from; n <= last; n++ {
+		if hash, ok := b.hashes[n]; ok {
+			hashes = append(hashes, storage.BlockHash{BlockNumber: int64(n), Hash: hash})
+		}
+	}
+	return hashes
+}
+
+// truncate discards the hashes of the blocks after the given block.
+func (b *blockHashes) truncate(block uint64) {
+	for n := range b.hashes {
+		if n > block {
+			delete(b.hashes, n)
+		}
+	}
+}
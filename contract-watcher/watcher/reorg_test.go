@@ -0,0 +1,50 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/deltaswapio/deltaswap-explorer/contract-watcher/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockHashes(t *testing.T) {
+	hashes := newBlockHashes(3)
+	for block, hash := range []string{"a", "b", "c", "d", "e"} {
+		hashes.set(uint64(block), hash)
+	}
+
+	// only the hashes of the last 3 blocks are kept.
+	_, ok := hashes.get(1)
+	assert.False(t, ok)
+	hash, ok := hashes.get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "c", hash)
+
+	last, ok := hashes.last()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), last)
+
+	hashes.truncate(2)
+	_, ok = hashes.get(3)
+	assert.False(t, ok)
+	hash, ok = hashes.get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "c", hash)
+	last, ok = hashes.last()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), last)
+
+	_, ok = newBlockHashes(3).last()
+	assert.False(t, ok)
+}
+
+func TestBlockHashesWindow(t *testing.T) {
+	hashes := newBlockHashes(10)
+	for block, hash := range []string{"a", "b", "c", "d", "e"} {
+		hashes.set(uint64(block), hash)
+	}
+
+	assert.Equal(t, []storage.BlockHash{{BlockNumber: 3, Hash: "d"}, {BlockNumber: 4, Hash: "e"}}, hashes.window(2))
+	assert.Len(t, hashes.window(20), 5)
+	assert.Nil(t, newBlockHashes(10).window(2))
+}
@@ -11,9 +11,10 @@ import (
 )
 
 var (
-	ErrTxfailedCannotBeUpdated = errors.New("tx with status failed can not be updated because exists a confirmed tx for the same vaa ID")
-	ErrTxUnknowCannotBeUpdated = errors.New("tx with status unknown can not be updated because exists a tx (confirmed|failed) for the same vaa ID")
-	ErrInvalidTxStatus         = errors.New("invalid tx status")
+	ErrTxfailedCannotBeUpdated   = errors.New("tx with status failed can not be updated because exists a confirmed tx for the same vaa ID")
+	ErrTxUnknowCannotBeUpdated   = errors.New("tx with status unknown can not be updated because exists a tx (confirmed|failed) for the same vaa ID")
+	ErrTxOrphanedCannotBeUpdated = errors.New("tx with status orphaned can not be updated because the destination tx of the vaa ID is a different tx")
+	ErrInvalidTxStatus           = errors.New("invalid tx status")
 )
 
 type FuncGetGlobalTransactionById func(ctx context.Context, id string) (storage.TransactionUpdate, error)
@@ -64,6 +65,17 @@ func checkTxShouldBeUpdated(ctx context.Context, tx storage.TransactionUpdate, g
 			return false, ErrTxUnknowCannotBeUpdated
 		}
 		return true, nil
+	case domain.DstTxStatusOrphaned:
+		// check if the transaction exists from the same vaa ID.
+		oldTx, err := getGlobalTransactionByIDFunc(ctx, tx.ID)
+		if err != nil {
+			return false, err
+		}
+		// only the tx removed by the reorg is downgraded, a redeem of the same vaa in another tx is kept.
+		if oldTx.Destination.TxHash != tx.Destination.TxHash {
+			return false, ErrTxOrphanedCannotBeUpdated
+		}
+		return true, nil
 	default:
 		return false, ErrInvalidTxStatus
 	}
@@ -189,6 +189,55 @@ func TestCheckTxShouldBeUpdated(t *testing.T) {
 			expectedUpdate: true,
 			expectedError:  nil,
 		},
+		{
+			name: "tx with status orphaned and already exist the same transaction with status completed",
+			inputTx: storage.TransactionUpdate{
+				Destination: storage.DestinationTx{
+					Status: domain.DstTxStatusOrphaned,
+					TxHash: "a1",
+				}},
+			inputGetGlobalTransactionByIDFunc: func(ctx context.Context, id string) (storage.TransactionUpdate, error) {
+				return storage.TransactionUpdate{
+					Destination: storage.DestinationTx{
+						Status: domain.DstTxStatusConfirmed,
+						TxHash: "a1",
+					}}, nil
+			},
+			expectedUpdate: true,
+			expectedError:  nil,
+		},
+		{
+			name: "tx with status orphaned and already exist a different transaction with the same vaa ID with status completed",
+			inputTx: storage.TransactionUpdate{
+				Destination: storage.DestinationTx{
+					Status: domain.DstTxStatusOrphaned,
+					TxHash: "a1",
+				}},
+			inputGetGlobalTransactionByIDFunc: func(ctx context.Context, id string) (storage.TransactionUpdate, error) {
+				return storage.TransactionUpdate{
+					Destination: storage.DestinationTx{
+						Status: domain.DstTxStatusConfirmed,
+						TxHash: "b2",
+					}}, nil
+			},
+			expectedUpdate: false,
+			expectedError:  ErrTxOrphanedCannotBeUpdated,
+		},
+		{
+			name: "tx with status failed and already exist a transaction with the same vaa ID with status orphaned",
+			inputTx: storage.TransactionUpdate{
+				Destination: storage.DestinationTx{
+					Status: domain.DstTxStatusFailedToProcess,
+				}},
+			inputGetGlobalTransactionByIDFunc: func(ctx context.Context, id string) (storage.TransactionUpdate, error) {
+				return storage.TransactionUpdate{
+					Destination: storage.DestinationTx{
+						Status: domain.DstTxStatusOrphaned,
+					}}, nil
+			},
+			expectedUpdate: true,
+			expectedError:  nil,
+		},
 		{
 			name: "tx with invalid status",
 			inputTx: storage.TransactionUpdate{
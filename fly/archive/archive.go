@@ -0,0 +1,294 @@
+// Package archive reads and writes the VAA archives used to bootstrap new environments.
+//
+// An archive is a directory with a manifest and compressed chunk files. Each chunk contains the VAAs of
+// a collection, one VAA per line with the format `<vaa id>,<hex encoded vaa>`, the same format read by
+// the vaa backfiller.
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// ManifestFile is the name of the manifest file in the archive directory.
+const ManifestFile = "manifest.json"
+
+// manifestVersion is the version of the archive format.
+const manifestVersion = 1
+
+var (
+	ErrChecksumMismatch = errors.New("chunk checksum mismatch")
+	ErrCountMismatch    = errors.New("chunk vaa count mismatch")
+	ErrInvalidRecord    = errors.New("invalid chunk record")
+)
+
+// Manifest describes the chunks of an archive.
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Chunks    []*Chunk  `json:"chunks"`
+}
+
+// Count returns the number of VAAs in the archive.
+func (m *Manifest) Count() int {
+	var count int
+	for _, c := range m.Chunks {
+		count += c.Count
+	}
+	return count
+}
+
+// Chunk describes a compressed chunk file.
+type Chunk struct {
+	File       string `json:"file"`
+	Collection string `json:"collection"`
+	Count      int    `json:"count"`
+	// SHA256 is the hex encoded checksum of the compressed file.
+	SHA256   string          `json:"sha256"`
+	Emitters []*EmitterRange `json:"emitters"`
+}
+
+// EmitterRange is the range of sequences of an emitter found in a chunk.
+type EmitterRange struct {
+	EmitterChain uint16 `json:"emitterChain"`
+	EmitterAddr  string `json:"emitterAddr"`
+	MinSequence  uint64 `json:"minSequence"`
+	MaxSequence  uint64 `json:"maxSequence"`
+	Count        int    `json:"count"`
+}
+
+// Writer writes the VAAs to chunk files of an archive directory.
+type Writer struct {
+	dir       string
+	chunkSize int
+	manifest  Manifest
+	current   *chunkWriter
+}
+
+// chunkWriter compresses the records of a chunk and computes the checksum of the compressed file.
+type chunkWriter struct {
+	chunk    *Chunk
+	file     *os.File
+	hash     hash.Hash
+	gz       *gzip.Writer
+	emitters map[string]*EmitterRange
+}
+
+// NewWriter creates a Writer, the archive directory is created if it doesn't exist.
+// Each chunk contains up to chunkSize VAAs of the same collection.
+func NewWriter(dir string, chunkSize int) (*Writer, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("invalid chunk size %d", chunkSize)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		dir:       dir,
+		chunkSize: chunkSize,
+		manifest:  Manifest{Version: manifestVersion, CreatedAt: time.Now().UTC()},
+	}, nil
+}
+
+// Write adds a VAA of the given collection to the archive.
+func (w *Writer) Write(collection string, v *vaa.VAA, serializedVaa []byte) error {
+	if w.current == nil || w.current.chunk.Collection != collection || w.current.chunk.Count >= w.chunkSize {
+		if err := w.finishChunk(); err != nil {
+			return err
+		}
+		if err := w.startChunk(collection); err != nil {
+			return err
+		}
+	}
+
+	c := w.current
+	if _, err := fmt.Fprintf(c.gz, "%s,%s\n", v.MessageID(), hex.EncodeToString(serializedVaa)); err != nil {
+		return err
+	}
+	c.chunk.Count++
+
+	key := fmt.Sprintf("%d/%s", v.EmitterChain, v.EmitterAddress.String())
+	r, ok := c.emitters[key]
+	if !ok {
+		r = &EmitterRange{
+			EmitterChain: uint16(v.EmitterChain),
+			EmitterAddr:  v.EmitterAddress.String(),
+			MinSequence:  v.Sequence,
+			MaxSequence:  v.Sequence,
+		}
+		c.emitters[key] = r
+	}
+	if v.Sequence < r.MinSequence {
+		r.MinSequence = v.Sequence
+	}
+	if v.Sequence > r.MaxSequence {
+		r.MaxSequence = v.Sequence
+	}
+	r.Count++
+	return nil
+}
+
+// Close finishes the last chunk and writes the manifest.
+func (w *Writer) Close() (*Manifest, error) {
+	if err := w.finishChunk(); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(&w.manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(w.dir, ManifestFile), data, 0o644); err != nil {
+		return nil, err
+	}
+	return &w.manifest, nil
+}
+
+func (w *Writer) startChunk(collection string) error {
+	name := fmt.Sprintf("%s-%06d.gz", collection, len(w.manifest.Chunks))
+	file, err := os.Create(filepath.Join(w.dir, name))
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	w.current = &chunkWriter{
+		chunk:    &Chunk{File: name, Collection: collection},
+		file:     file,
+		hash:     h,
+		gz:       gzip.NewWriter(io.MultiWriter(file, h)),
+		emitters: make(map[string]*EmitterRange),
+	}
+	return nil
+}
+
+func (w *Writer) finishChunk() error {
+	c := w.current
+	if c == nil {
+		return nil
+	}
+	w.current = nil
+
+	if err := c.gz.Close(); err != nil {
+		c.file.Close()
+		return err
+	}
+	if err := c.file.Close(); err != nil {
+		return err
+	}
+
+	c.chunk.SHA256 = hex.EncodeToString(c.hash.Sum(nil))
+	for _, r := range c.emitters {
+		c.chunk.Emitters = append(c.chunk.Emitters, r)
+	}
+	sort.Slice(c.chunk.Emitters, func(i, j int) bool {
+		a, b := c.chunk.Emitters[i], c.chunk.Emitters[j]
+		if a.EmitterChain != b.EmitterChain {
+			return a.EmitterChain < b.EmitterChain
+		}
+		return a.EmitterAddr < b.EmitterAddr
+	})
+	w.manifest.Chunks = append(w.manifest.Chunks, c.chunk)
+	return nil
+}
+
+// ReadManifest reads the manifest of an archive directory.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", manifest.Version)
+	}
+	return &manifest, nil
+}
+
+// ReadChunk verifies the checksum of a chunk file and calls fn with each VAA of the chunk.
+// The VAA ID of each record is checked against the decoded VAA.
+func ReadChunk(dir string, chunk *Chunk, fn func(v *vaa.VAA, serializedVaa []byte) error) error {
+	file, err := os.Open(filepath.Join(dir, chunk.File))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// the checksum is verified before reading the records, so a corrupted chunk is not partially imported.
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != chunk.SHA256 {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, chunk.File)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	r := bufio.NewReader(gz)
+	var count int
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF && line == "" {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		v, serializedVaa, err := parseRecord(strings.TrimSuffix(line, "\n"))
+		if err != nil {
+			return fmt.Errorf("%s line %d: %w", chunk.File, count+1, err)
+		}
+		count++
+		if err := fn(v, serializedVaa); err != nil {
+			return err
+		}
+	}
+
+	if count != chunk.Count {
+		return fmt.Errorf("%w: %s has %d vaas, expected %d", ErrCountMismatch, chunk.File, count, chunk.Count)
+	}
+	return nil
+}
+
+func parseRecord(line string) (*vaa.VAA, []byte, error) {
+	id, data, ok := strings.Cut(line, ",")
+	if !ok {
+		return nil, nil, ErrInvalidRecord
+	}
+	serializedVaa, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+	v, err := vaa.Unmarshal(serializedVaa)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+	if v.MessageID() != id {
+		return nil, nil, fmt.Errorf("%w: vaa id %s doesn't match %s", ErrInvalidRecord, v.MessageID(), id)
+	}
+	return v, serializedVaa, nil
+}
@@ -0,0 +1,91 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+)
+
+func newVaa(t *testing.T, chain vaa.ChainID, sequence uint64) (*vaa.VAA, []byte) {
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		Timestamp:        time.Unix(1672531200, 0),
+		EmitterChain:     chain,
+		EmitterAddress:   vaa.Address{1},
+		Sequence:         sequence,
+		ConsistencyLevel: 1,
+		Payload:          []byte{1, 2, 3},
+	}
+	data, err := v.Marshal()
+	assert.NoError(t, err)
+	return v, data
+}
+
+func TestWriteAndRead(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir, 2)
+	assert.NoError(t, err)
+
+	for _, seq := range []uint64{5, 3, 9} {
+		v, data := newVaa(t, vaa.ChainIDEthereum, seq)
+		assert.NoError(t, w.Write("vaas", v, data))
+	}
+	v, data := newVaa(t, vaa.ChainIDPythNet, 1)
+	assert.NoError(t, w.Write("vaasPythnet", v, data))
+
+	manifest, err := w.Close()
+	assert.NoError(t, err)
+	// the chunks are split by size and by collection.
+	assert.Len(t, manifest.Chunks, 3)
+	assert.Equal(t, 4, manifest.Count())
+	assert.Equal(t, &EmitterRange{
+		EmitterChain: uint16(vaa.ChainIDEthereum),
+		EmitterAddr:  vaa.Address{1}.String(),
+		MinSequence:  3,
+		MaxSequence:  5,
+		Count:        2,
+	}, manifest.Chunks[0].Emitters[0])
+	assert.Equal(t, "vaasPythnet", manifest.Chunks[2].Collection)
+
+	read, err := ReadManifest(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Chunks, read.Chunks)
+
+	var sequences []uint64
+	for _, chunk := range read.Chunks {
+		err := ReadChunk(dir, chunk, func(v *vaa.VAA, _ []byte) error {
+			sequences = append(sequences, v.Sequence)
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []uint64{5, 3, 9, 1}, sequences)
+}
+
+func TestReadChunk_ChecksumMismatch(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir, 10)
+	assert.NoError(t, err)
+	v, data := newVaa(t, vaa.ChainIDEthereum, 1)
+	assert.NoError(t, w.Write("vaas", v, data))
+	manifest, err := w.Close()
+	assert.NoError(t, err)
+
+	file := filepath.Join(dir, manifest.Chunks[0].File)
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	content[len(content)-1] ^= 0xff
+	assert.NoError(t, os.WriteFile(file, content, 0o644))
+
+	called := false
+	err = ReadChunk(dir, manifest.Chunks[0], func(*vaa.VAA, []byte) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.False(t, called)
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
+	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
+	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	commonRepo "github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/fly/archive"
+	"github.com/deltaswapio/deltaswap-explorer/fly/internal/metrics"
+	"github.com/deltaswapio/deltaswap-explorer/fly/phylaxsets"
+	"github.com/deltaswapio/deltaswap-explorer/fly/producer"
+	"github.com/deltaswapio/deltaswap-explorer/fly/storage"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	vaasCollection        = "vaas"
+	vaasPythnetCollection = "vaasPythnet"
+)
+
+type ArchiveExportConfig struct {
+	LogLevel       string
+	MongoURI       string
+	MongoDatabase  string
+	Output         string
+	ChunkSize      int
+	IncludePythnet bool
+}
+
+type ArchiveImportConfig struct {
+	LogLevel      string
+	MongoURI      string
+	MongoDatabase string
+	Input         string
+	P2pNetwork    string
+	// Worker is used to configure the notifications of the imported VAAs.
+	Worker WorkerConfiguration
+}
+
+// RunArchiveExport streams the stored VAAs to the chunk files of an archive.
+func RunArchiveExport(cfg ArchiveExportConfig) {
+	ctx := context.Background()
+	logger := logger.New("deltaswap-fly", logger.WithLevel(cfg.LogLevel))
+
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("could not connect to DB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	repository := storage.NewRepository(
+		alert.NewDummyClient(),
+		metrics.NewDummyMetrics(),
+		db.Database,
+		producer.NewVAAInMemory(logger).Push,
+		logger)
+
+	w, err := archive.NewWriter(cfg.Output, cfg.ChunkSize)
+	if err != nil {
+		logger.Fatal("could not create archive", zap.Error(err))
+	}
+
+	collections := []string{vaasCollection}
+	if cfg.IncludePythnet {
+		collections = append(collections, vaasPythnetCollection)
+	}
+	for _, collection := range collections {
+		var count int
+		err := repository.IterateVaas(ctx, collection == vaasPythnetCollection, func(doc *storage.VaaUpdate) error {
+			v, err := vaa.Unmarshal(doc.Vaa)
+			if err != nil {
+				logger.Warn("Skipping invalid vaa", zap.String("id", doc.ID), zap.Error(err))
+				return nil
+			}
+			count++
+			if count%100000 == 0 {
+				logger.Info("Exporting vaas", zap.String("collection", collection), zap.Int("count", count))
+			}
+			return w.Write(collection, v, doc.Vaa)
+		})
+		if err != nil {
+			logger.Fatal("could not export vaas", zap.String("collection", collection), zap.Error(err))
+		}
+		logger.Info("Exported vaas", zap.String("collection", collection), zap.Int("count", count))
+	}
+
+	manifest, err := w.Close()
+	if err != nil {
+		logger.Fatal("could not write archive manifest", zap.Error(err))
+	}
+	logger.Info("Archive created",
+		zap.String("output", cfg.Output),
+		zap.Int("chunks", len(manifest.Chunks)),
+		zap.Int("vaas", manifest.Count()))
+}
+
+// RunArchiveImport verifies the signatures of the VAAs of an archive against the phylax set history
+// and stores the valid VAAs. The VAAs with invalid signatures are skipped and logged.
+//
+// The phylax set upgrades found in the archive are applied before importing, so the VAAs signed
+// by phylax sets missing in the database can be verified.
+func RunArchiveImport(cfg ArchiveImportConfig) {
+	ctx := context.Background()
+	logger := logger.New("deltaswap-fly", logger.WithLevel(cfg.LogLevel))
+
+	manifest, err := archive.ReadManifest(cfg.Input)
+	if err != nil {
+		logger.Fatal("could not read archive manifest", zap.Error(err))
+	}
+
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("could not connect to DB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	alertClient := alert.NewDummyClient()
+	metricsClient := metrics.NewDummyMetrics()
+	producerFunc, err := newVAATopicProducerFunc(ctx, cfg.Worker, alertClient, metricsClient, logger)
+	if err != nil {
+		logger.Fatal("could not create vaa topic producer", zap.Error(err))
+	}
+	repository := storage.NewRepository(alertClient, metricsClient, db.Database, producerFunc, logger)
+
+	phylaxSetRepository := commonRepo.NewPhylaxSetRepository(db.Database, logger)
+	history, err := phylaxsets.Load(ctx, cfg.P2pNetwork, phylaxSetRepository, alertClient, logger)
+	if err != nil {
+		logger.Fatal("could not load phylax set history", zap.Error(err))
+	}
+	upgradeProcessor := phylaxsets.NewUpgradeProcessor(history, phylaxSetRepository, nil, logger)
+	if err := applyPhylaxSetUpgrades(ctx, cfg.Input, manifest, history, upgradeProcessor, logger); err != nil {
+		logger.Fatal("could not apply phylax set upgrades", zap.Error(err))
+	}
+
+	var imported, rejected int
+	for index, chunk := range manifest.Chunks {
+		chunkImported, chunkRejected, err := importChunk(ctx, cfg.Input, chunk, history, repository.UpsertVaa, logger)
+		imported += chunkImported
+		rejected += chunkRejected
+		if err != nil {
+			logger.Fatal("could not import chunk, run the import again to finish it",
+				zap.String("file", chunk.File),
+				zap.Error(err))
+		}
+		logger.Info("Imported chunk",
+			zap.String("file", chunk.File),
+			zap.Int("index", index+1),
+			zap.Int("count", len(manifest.Chunks)))
+	}
+
+	logger.Info("Archive imported", zap.Int("imported", imported), zap.Int("rejected", rejected))
+}
+
+// importChunk stores the VAAs of a chunk signed by a quorum of their phylax set.
+// The VAAs without quorum or with invalid signatures are skipped and counted as rejected.
+func importChunk(
+	ctx context.Context,
+	dir string,
+	chunk *archive.Chunk,
+	history *phylaxsets.PhylaxSetHistory,
+	upsertVaa func(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error,
+	logger *zap.Logger,
+) (imported int, rejected int, err error) {
+
+	err = archive.ReadChunk(dir, chunk, func(v *vaa.VAA, serializedVaa []byte) error {
+		if err := history.Verify(ctx, v); err != nil {
+			logger.Warn("Skipping vaa with invalid signatures", zap.String("id", v.MessageID()), zap.Error(err))
+			rejected++
+			return nil
+		}
+		if err := upsertVaa(ctx, v, serializedVaa); err != nil {
+			return err
+		}
+		imported++
+		return nil
+	})
+	return imported, rejected, err
+}
+
+// applyPhylaxSetUpgrades applies the phylax set upgrade VAAs of the archive ordered by the new phylax set index.
+// Only the chunks with VAAs of the governance emitter are read.
+func applyPhylaxSetUpgrades(
+	ctx context.Context,
+	dir string,
+	manifest *archive.Manifest,
+	history *phylaxsets.PhylaxSetHistory,
+	upgradeProcessor *phylaxsets.UpgradeProcessor,
+	logger *zap.Logger,
+) error {
+
+	var upgrades []*vaa.VAA
+	for _, chunk := range manifest.Chunks {
+		if !hasGovernanceEmitter(chunk) {
+			continue
+		}
+		err := archive.ReadChunk(dir, chunk, func(v *vaa.VAA, _ []byte) error {
+			if phylaxsets.IsPhylaxSetUpgrade(v) {
+				upgrades = append(upgrades, v)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	newIndex := func(v *vaa.VAA) uint32 {
+		upgrade, err := phylaxsets.ParsePhylaxSetUpgrade(v)
+		if err != nil {
+			return 0
+		}
+		return upgrade.NewIndex
+	}
+	sort.Slice(upgrades, func(i, j int) bool {
+		return newIndex(upgrades[i]) < newIndex(upgrades[j])
+	})
+
+	for _, v := range upgrades {
+		if err := history.Verify(ctx, v); err != nil {
+			logger.Warn("Skipping phylax set upgrade with invalid signatures", zap.String("id", v.MessageID()), zap.Error(err))
+			continue
+		}
+		if err := upgradeProcessor.Process(ctx, v, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasGovernanceEmitter(chunk *archive.Chunk) bool {
+	for _, e := range chunk.Emitters {
+		if e.EmitterChain == uint16(vaa.GovernanceChain) && e.EmitterAddr == vaa.GovernanceEmitter.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/fly/archive"
+	"github.com/deltaswapio/deltaswap-explorer/fly/phylaxsets"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestImportChunk_RejectsVaaWithoutQuorum(t *testing.T) {
+	data, err := os.ReadFile("../../phylaxsets/validVaa.bin")
+	require.NoError(t, err)
+
+	valid, err := vaa.Unmarshal(data)
+	require.NoError(t, err)
+
+	// the same VAA with a single valid signature of its phylax set.
+	subQuorum, err := vaa.Unmarshal(data)
+	require.NoError(t, err)
+	subQuorum.Signatures = subQuorum.Signatures[:1]
+	subQuorumData, err := subQuorum.Marshal()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	w, err := archive.NewWriter(dir, 10)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(vaasCollection, valid, data))
+	require.NoError(t, w.Write(vaasCollection, subQuorum, subQuorumData))
+	manifest, err := w.Close()
+	require.NoError(t, err)
+	require.Len(t, manifest.Chunks, 1)
+
+	var stored []*vaa.VAA
+	upsertVaa := func(_ context.Context, v *vaa.VAA, _ []byte) error {
+		stored = append(stored, v)
+		return nil
+	}
+	history := phylaxsets.GetByEnv(domain.P2pMainNet, alert.NewDummyClient())
+
+	imported, rejected, err := importChunk(context.Background(), dir, manifest.Chunks[0], history, upsertVaa, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+	assert.Equal(t, 1, rejected)
+	require.Len(t, stored, 1)
+	assert.Equal(t, len(valid.Signatures), len(stored[0].Signatures))
+}
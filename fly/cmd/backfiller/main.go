@@ -24,6 +24,7 @@ func execute() error {
 	addVaaBackfillerCommand(root)
 	addTxHashCommand(root)
 	addTxHashEncodingCommand(root)
+	addArchiveCommand(root)
 
 	return root.Execute()
 }
@@ -147,3 +148,93 @@ func addTxHashEncodingCommand(root *cobra.Command) {
 
 	root.AddCommand(txHashFixEncodingCommand)
 }
+
+func addArchiveCommand(root *cobra.Command) {
+	archiveCommand := &cobra.Command{
+		Use:   "archive",
+		Short: "Export and import vaa archives",
+	}
+	addArchiveExportCommand(archiveCommand)
+	addArchiveImportCommand(archiveCommand)
+	root.AddCommand(archiveCommand)
+}
+
+func addArchiveExportCommand(parent *cobra.Command) {
+	var logLevel, mongoUri, mongoDb, output string
+	var chunkSize int
+	var includePythnet bool
+	exportCommand := &cobra.Command{
+		Use:   "export",
+		Short: "Export the vaas to a compressed and chunked archive",
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := ArchiveExportConfig{
+				LogLevel:       logLevel,
+				MongoURI:       mongoUri,
+				MongoDatabase:  mongoDb,
+				Output:         output,
+				ChunkSize:      chunkSize,
+				IncludePythnet: includePythnet,
+			}
+			RunArchiveExport(cfg)
+		},
+	}
+
+	exportCommand.Flags().StringVar(&logLevel, "log-level", "info", "Log level")
+	exportCommand.Flags().StringVar(&mongoUri, "mongo-uri", "", "Mongo connection")
+	exportCommand.Flags().StringVar(&mongoDb, "mongo-database", "", "Mongo database")
+	exportCommand.Flags().StringVar(&output, "output", "", "archive directory")
+	exportCommand.Flags().IntVar(&chunkSize, "chunk-size", 100000, "number of vaas per chunk")
+	exportCommand.Flags().BoolVar(&includePythnet, "include-pythnet", false, "export the pythnet vaas")
+
+	exportCommand.MarkFlagRequired("mongo-uri")
+	exportCommand.MarkFlagRequired("mongo-database")
+	exportCommand.MarkFlagRequired("output")
+
+	parent.AddCommand(exportCommand)
+}
+
+func addArchiveImportCommand(parent *cobra.Command) {
+	var logLevel, mongoUri, mongoDb, input, p2pNetwork, awsRegion, awsAccessKeyId, awsSecretKey, AwsEndpoint, AwsSnsURL string
+	var notifyEnabled bool
+	importCommand := &cobra.Command{
+		Use:   "import",
+		Short: "Verify the vaas of an archive and import them",
+		Run: func(_ *cobra.Command, _ []string) {
+			cfg := ArchiveImportConfig{
+				LogLevel:      logLevel,
+				MongoURI:      mongoUri,
+				MongoDatabase: mongoDb,
+				Input:         input,
+				P2pNetwork:    p2pNetwork,
+				Worker: WorkerConfiguration{
+					NotifyEnabled:  notifyEnabled,
+					AwsRegion:      awsRegion,
+					AwsAccessKeyId: awsAccessKeyId,
+					AwsSecretKey:   awsSecretKey,
+					AwsEndpoint:    AwsEndpoint,
+					AwsSnsURL:      AwsSnsURL,
+				},
+			}
+			RunArchiveImport(cfg)
+		},
+	}
+
+	importCommand.Flags().StringVar(&logLevel, "log-level", "info", "Log level")
+	importCommand.Flags().StringVar(&mongoUri, "mongo-uri", "", "Mongo connection")
+	importCommand.Flags().StringVar(&mongoDb, "mongo-database", "", "Mongo database")
+	importCommand.Flags().StringVar(&input, "input", "", "archive directory")
+	importCommand.Flags().StringVar(&p2pNetwork, "p2p-network", "", "P2P network of the phylax sets (mainnet, testnet, devnet)")
+	importCommand.Flags().BoolVar(&notifyEnabled, "notify-enabled", false, "backfiller notify pipeline")
+	importCommand.Flags().StringVar(&awsRegion, "aws-region", "", "AWS region")
+	importCommand.Flags().StringVar(&awsAccessKeyId, "aws-access-key-id", "", "AWS access key id")
+	importCommand.Flags().StringVar(&awsSecretKey, "aws-secret-access-key", "", "AWS secret access key")
+	importCommand.Flags().StringVar(&AwsEndpoint, "aws-endpoint", "", "AWS endpoint")
+	importCommand.Flags().StringVar(&AwsSnsURL, "aws-sns-url", "", "AWS SNS URL")
+
+	importCommand.MarkFlagRequired("mongo-uri")
+	importCommand.MarkFlagRequired("mongo-database")
+	importCommand.MarkFlagRequired("input")
+	importCommand.MarkFlagRequired("p2p-network")
+
+	parent.AddCommand(importCommand)
+}
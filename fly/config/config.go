@@ -130,6 +130,16 @@ type Configuration struct {
 	GovernorStatusChannelSize int  `env:"GOVERNOR_STATUS_CHANNEL_SIZE,required"`
 	ApiPort                   uint `env:"API_PORT,required"`
 	P2pPort                   uint `env:"P2P_PORT,required"`
+	// HeartbeatHistorySampleSeconds is the minimum time between two stored snapshots of the heartbeat of a phylax.
+	HeartbeatHistorySampleSeconds int `env:"HEARTBEAT_HISTORY_SAMPLE_SECONDS,default=60"`
+	// HeartbeatHistoryRetentionDays is the number of days the heartbeat snapshots are kept.
+	HeartbeatHistoryRetentionDays int `env:"HEARTBEAT_HISTORY_RETENTION_DAYS,default=30"`
+	// ObservationQuorumStuckSeconds is the time after the first observation of a message to alert it as stuck below the quorum.
+	ObservationQuorumStuckSeconds int `env:"OBSERVATION_QUORUM_STUCK_SECONDS,default=600"`
+	// ObservationQuorumRetentionDays is the number of days the quorum progress of the messages is kept.
+	ObservationQuorumRetentionDays int `env:"OBSERVATION_QUORUM_RETENTION_DAYS,default=30"`
+	// VaaStorePath is the BoltDB file where the VAAs are stored in local mode, mongo is not used when it is set.
+	VaaStorePath string `env:"VAA_STORE_PATH"`
 }
 
 // New creates a configuration with the values from .env file and environment variables.
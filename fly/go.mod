@@ -21,6 +21,7 @@ require (
 	github.com/stretchr/testify v1.8.4
 	github.com/test-go/testify v1.1.4
 	github.com/deltaswapio/deltaswap/sdk v0.0.0-20231121162544-d3c011362ea5
+	go.etcd.io/bbolt v1.3.6
 	go.mongodb.org/mongo-driver v1.11.2
 	go.uber.org/zap v1.25.0
 	google.golang.org/protobuf v1.31.0
@@ -246,7 +247,6 @@ require (
 	github.com/youmark/pkcs8 v0.0.0-20181117223130-1be2e3e5546d // indirect
 	github.com/zondax/hid v0.9.1 // indirect
 	github.com/zondax/ledger-go v0.14.0 // indirect
-	go.etcd.io/bbolt v1.3.6 // indirect
 	go.opencensus.io v0.24.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/crypto v0.12.0 // indirect
@@ -19,6 +19,8 @@ const (
 	// warning alerts
 	PhylaxSetUnknown         = "GUARDIAN_SET_UNKNOWN"
 	ObservationWithoutTxHash = "OBSERVATION_WITHOUT_TX_HASH"
+	GovernorMessageRejected  = "GOVERNOR_MESSAGE_REJECTED"
+	ObservationQuorumStuck   = "OBSERVATION_QUORUM_STUCK"
 )
 
 func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
@@ -91,6 +93,24 @@ func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
 		Entity:      "fly",
 		Priority:    alert.INFORMATIONAL,
 	}
+	alerts[GovernorMessageRejected] = alert.Alert{
+		Alias:       GovernorMessageRejected,
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Governor message rejected"),
+		Description: "A governor config or status from the gossip network has an invalid signature or was not signed by a phylax of the current phylax set.",
+		Actions:     []string{"check the phylax address of the message"},
+		Tags:        []string{cfg.Environment, "fly", "governor", "p2p"},
+		Entity:      "fly",
+		Priority:    alert.MODERATE,
+	}
+	alerts[ObservationQuorumStuck] = alert.Alert{
+		Alias:       ObservationQuorumStuck,
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Observation quorum stuck"),
+		Description: "A message is still below the quorum of the phylax set after the stuck threshold.",
+		Actions:     []string{"check the missing signers of the message in the observationQuorums collection"},
+		Tags:        []string{cfg.Environment, "fly", "observations", "quorum"},
+		Entity:      "fly",
+		Priority:    alert.MODERATE,
+	}
 	alerts[ErrorPhylaxNoActivity] = alert.Alert{
 		Alias:       ErrorPhylaxNoActivity,
 		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Phylax no activity from gossip network"),
@@ -56,11 +56,17 @@ func (d *DummyMetrics) IncGovernorConfigFromGossipNetwork(phylaxName string) {}
 // IncGovernorConfigInserted increases the number of phylax config inserted in database.
 func (d *DummyMetrics) IncGovernorConfigInserted(phylaxName string) {}
 
+// IncGovernorConfigRejected increases the number of phylax config rejected by the signature verification.
+func (d *DummyMetrics) IncGovernorConfigRejected(phylaxName string) {}
+
 // IncGovernorStatusFromGossipNetwork increases the number of phylax status received by phylax from Gossip network.
 func (d *DummyMetrics) IncGovernorStatusFromGossipNetwork(phylaxName string) {}
 
 // IncGovernorStatusInserted increases the number of phylax status inserted in database.
 func (d *DummyMetrics) IncGovernorStatusInserted(phylaxName string) {}
 
+// IncGovernorStatusRejected increases the number of phylax status rejected by the signature verification.
+func (d *DummyMetrics) IncGovernorStatusRejected(phylaxName string) {}
+
 // IncMaxSequenceCacheError increases the number of errors when updating max sequence cache.
 func (d *DummyMetrics) IncMaxSequenceCacheError(chain sdk.ChainID) {}
@@ -27,10 +27,12 @@ type Metrics interface {
 	// governor config metrics
 	IncGovernorConfigFromGossipNetwork(phylaxName string)
 	IncGovernorConfigInserted(phylaxName string)
+	IncGovernorConfigRejected(phylaxName string)
 
 	// governor status metrics
 	IncGovernorStatusFromGossipNetwork(phylaxName string)
 	IncGovernorStatusInserted(phylaxName string)
+	IncGovernorStatusRejected(phylaxName string)
 
 	// max sequence cache metrics
 	IncMaxSequenceCacheError(chain sdk.ChainID)
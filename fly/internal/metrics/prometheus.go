@@ -185,6 +185,11 @@ func (m *PrometheusMetrics) IncGovernorConfigInserted(phylaxName string) {
 	m.governorConfigReceivedCount.WithLabelValues(phylaxName, "inserted").Inc()
 }
 
+// IncGovernorConfigRejected increases the number of phylax config rejected by the signature verification.
+func (m *PrometheusMetrics) IncGovernorConfigRejected(phylaxName string) {
+	m.governorConfigReceivedCount.WithLabelValues(phylaxName, "rejected").Inc()
+}
+
 // IncGovernorStatusFromGossipNetwork increases the number of phylax status received by phylax from Gossip network.
 func (m *PrometheusMetrics) IncGovernorStatusFromGossipNetwork(phylaxName string) {
 	m.governorStatusReceivedCount.WithLabelValues(phylaxName, "gossip").Inc()
@@ -195,6 +200,11 @@ func (m *PrometheusMetrics) IncGovernorStatusInserted(phylaxName string) {
 	m.governorStatusReceivedCount.WithLabelValues(phylaxName, "inserted").Inc()
 }
 
+// IncGovernorStatusRejected increases the number of phylax status rejected by the signature verification.
+func (m *PrometheusMetrics) IncGovernorStatusRejected(phylaxName string) {
+	m.governorStatusReceivedCount.WithLabelValues(phylaxName, "rejected").Inc()
+}
+
 // IncMaxSequenceCacheError increases the number of errors when updating max sequence cache.
 func (m *PrometheusMetrics) IncMaxSequenceCacheError(chain sdk.ChainID) {
 	m.maxSequenceCacheCount.WithLabelValues(chain.String()).Inc()
@@ -18,6 +18,7 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
 	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	commonRepo "github.com/deltaswapio/deltaswap-explorer/common/repository"
 	"github.com/deltaswapio/deltaswap-explorer/fly/config"
 	"github.com/deltaswapio/deltaswap-explorer/fly/deduplicator"
 	flyAlert "github.com/deltaswapio/deltaswap-explorer/fly/internal/alert"
@@ -242,6 +243,25 @@ func newVAARedisProducerFunc(ctx context.Context, isLocal bool, logger *zap.Logg
 	return producer.NewRedisProducer(client, channel).Push, nil
 }
 
+// newMongoSession connects to the database configured by the MONGODB_URI and MONGODB_DATABASE variables.
+func newMongoSession(ctx context.Context, logger *zap.Logger) *dbutil.Session {
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		logger.Fatal("You must set your 'MONGODB_URI' environmental variable. See\n\t https://www.mongodb.com/docs/drivers/go/current/usage-examples/#environment-variable")
+	}
+
+	databaseName := os.Getenv("MONGODB_DATABASE")
+	if databaseName == "" {
+		logger.Fatal("You must set your 'MONGODB_DATABASE' environmental variable. See\n\t https://www.mongodb.com/docs/drivers/go/current/usage-examples/#environment-variable")
+	}
+
+	db, err := dbutil.Connect(ctx, logger, uri, databaseName, false)
+	if err != nil {
+		logger.Fatal("could not connect to DB", zap.Error(err))
+	}
+	return db
+}
+
 func main() {
 	//TODO: use a configuration structure to obtain the configuration
 	_ = godotenv.Load()
@@ -289,28 +309,6 @@ func main() {
 	// new metrics client
 	metrics := newMetrics(config.GetEnvironment())
 
-	// Setup DB
-	uri := os.Getenv("MONGODB_URI")
-	if uri == "" {
-		logger.Fatal("You must set your 'MONGODB_URI' environmental variable. See\n\t https://www.mongodb.com/docs/drivers/go/current/usage-examples/#environment-variable")
-	}
-
-	databaseName := os.Getenv("MONGODB_DATABASE")
-	if databaseName == "" {
-		logger.Fatal("You must set your 'MONGODB_DATABASE' environmental variable. See\n\t https://www.mongodb.com/docs/drivers/go/current/usage-examples/#environment-variable")
-	}
-
-	db, err := dbutil.Connect(rootCtx, logger, uri, databaseName, false)
-	if err != nil {
-		logger.Fatal("could not connect to DB", zap.Error(err))
-	}
-
-	// Run the database migration.
-	err = migration.Run(db.Database)
-	if err != nil {
-		logger.Fatal("error running migration", zap.Error(err))
-	}
-
 	// Creates a callback to publish VAA messages to a redis pubsub
 	vaaRedisProducerFunc, err := newVAARedisProducerFunc(rootCtx, *isLocal, logger)
 	if err != nil {
@@ -320,9 +318,43 @@ func main() {
 	// Creates a composite callback to publish VAA messages to a redis pubsub
 	producerFunc := producer.NewComposite(vaaRedisProducerFunc)
 
-	// Creates a callback to publish VAA messages to a redis pubsub
+	// Setup storage
+	// In local mode the VAAs can be stored in an embedded store, so fly runs without mongo.
+	// In that case the observations, heartbeats and governor messages are not stored.
+	var (
+		db               *dbutil.Session
+		boltStore        *storage.BoltStore
+		repository       *storage.Repository
+		heartbeatHistory *storage.HeartbeatHistory
+		quorumTracker    *storage.QuorumTracker
+		vaaStore         storage.VaaStore
+		phylaxSetStorage phylaxsets.Storage
+	)
+	if *isLocal && cfg.VaaStorePath != "" {
+		boltStore, err = storage.NewBoltStore(cfg.VaaStorePath, metrics, producerFunc)
+		if err != nil {
+			logger.Fatal("could not open vaa store", zap.Error(err))
+		}
+		logger.Info("using embedded vaa store", zap.String("path", cfg.VaaStorePath))
+		vaaStore = boltStore
+		phylaxSetStorage = boltStore
+	} else {
+		db = newMongoSession(rootCtx, logger)
+
+		// Run the database migration.
+		err = migration.Run(db.Database,
+			time.Duration(cfg.HeartbeatHistoryRetentionDays)*24*time.Hour,
+			time.Duration(cfg.ObservationQuorumRetentionDays)*24*time.Hour)
+		if err != nil {
+			logger.Fatal("error running migration", zap.Error(err))
+		}
 
-	repository := storage.NewRepository(alertClient, metrics, db.Database, producerFunc, logger)
+		repository = storage.NewRepository(alertClient, metrics, db.Database, producerFunc, logger)
+		heartbeatHistory = storage.NewHeartbeatHistory(db.Database, time.Duration(cfg.HeartbeatHistorySampleSeconds)*time.Second, logger)
+		quorumTracker = storage.NewQuorumTracker(alertClient, db.Database, time.Duration(cfg.ObservationQuorumStuckSeconds)*time.Second, logger)
+		vaaStore = repository
+		phylaxSetStorage = commonRepo.NewPhylaxSetRepository(db.Database, logger)
+	}
 
 	// Outbound gossip message queue
 	sendC := make(chan []byte)
@@ -349,11 +381,16 @@ func main() {
 	govStatusC := make(chan *gossipv1.SignedChainGovernorStatus, cfg.GovernorStatusChannelSize)
 
 	// Bootstrap phylax set, otherwise heartbeats would be skipped
-	// TODO: fetch this and probably figure out how to update it live
-	phylaxSetHistory := phylaxsets.GetByEnv(p2pNetworkConfig.Enviroment, alertClient)
+	phylaxSetHistory, err := phylaxsets.Load(rootCtx, p2pNetworkConfig.Enviroment, phylaxSetStorage, alertClient, logger)
+	if err != nil {
+		logger.Fatal("could not load phylax set history", zap.Error(err))
+	}
 	gsLastet := phylaxSetHistory.GetLatest()
 	gst.Set(&gsLastet)
 
+	// Applies phylax set upgrade governance VAAs to the phylax set history
+	phylaxSetUpgrade := phylaxsets.NewUpgradeProcessor(phylaxSetHistory, phylaxSetStorage, gst, logger)
+
 	// Ignore observation requests
 	// Note: without this, the whole program hangs on observation requests
 	discardMessages(rootCtx, obsvReqC)
@@ -370,7 +407,10 @@ func main() {
 				o := m.Msg
 				phylaxCheck.Ping(rootCtx)
 				metrics.IncObservationTotal()
-				ok := verifyObservation(logger, o, gst.Get())
+				// the same phylax set is used to verify and to track the observation,
+				// so a phylax set upgrade in between doesn't change the quorum of the message.
+				gs := gst.Get()
+				ok := verifyObservation(logger, o, gs)
 				if !ok {
 					logger.Error("Could not verify observation", zap.String("id", o.MessageId))
 					continue
@@ -391,14 +431,29 @@ func main() {
 
 				metrics.IncObservationUnfiltered(chainID)
 
+				// the observations are not stored with the embedded vaa store.
+				if repository == nil {
+					continue
+				}
+
 				err = repository.UpsertObservation(o)
 				if err != nil {
 					logger.Error("Error inserting observation", zap.Error(err))
+					continue
+				}
+
+				if err := quorumTracker.Track(rootCtx, o, gs); err != nil {
+					logger.Error("Error tracking observation quorum", zap.Error(err))
 				}
 			}
 		}
 	}()
 
+	// Alert the messages stuck below the quorum.
+	if quorumTracker != nil {
+		go quorumTracker.RunStuckCheck(rootCtx, time.Minute)
+	}
+
 	// Log signed VAAs
 	cache, err := newCache()
 	if err != nil {
@@ -415,9 +470,9 @@ func main() {
 	// When recive a message, the message filter by deduplicator
 	// if VAA is from pyhnet should be saved directly to repository
 	// if VAA is from non pyhnet should be publish with nonPythVaaPublish
-	vaaGossipConsumer := processor.NewVAAGossipConsumer(&phylaxSetHistory, deduplicator, nonPythVaaPublish, repository.UpsertVaa, metrics, logger)
+	vaaGossipConsumer := processor.NewVAAGossipConsumer(phylaxSetHistory, phylaxSetUpgrade.Process, deduplicator, nonPythVaaPublish, vaaStore.UpsertVaa, metrics, logger)
 	// Creates a instance to consume VAA messages (non pyth) from a queue and store in a storage
-	vaaQueueConsumer := processor.NewVAAQueueConsumer(vaaQueueConsume, repository, notifierFunc, metrics, logger)
+	vaaQueueConsumer := processor.NewVAAQueueConsumer(vaaQueueConsume, vaaStore, notifierFunc, metrics, logger)
 	// Creates a wrapper that splits the incoming VAAs into 2 channels (pyth to non pyth) in order
 	// to be able to process them in a differentiated way
 	vaaGossipConsumerSplitter := processor.NewVAAGossipSplitterConsumer(vaaGossipConsumer.Push, logger)
@@ -426,7 +481,7 @@ func main() {
 
 	// start fly http server.
 	pprofEnabled := config.GetPprofEnabled()
-	server := server.NewServer(cfg.ApiPort, phylaxCheck, logger, repository, sqsConsumer, *isLocal, pprofEnabled, alertClient)
+	server := server.NewServer(cfg.ApiPort, phylaxCheck, logger, vaaStore, sqsConsumer, *isLocal, pprofEnabled, alertClient)
 	server.Start()
 
 	go func() {
@@ -466,12 +521,18 @@ func main() {
 			case hb := <-heartbeatC:
 				phylaxCheck.Ping(rootCtx)
 				metrics.IncHeartbeatFromGossipNetwork(hb.NodeName)
+				if repository == nil {
+					continue
+				}
 				err := repository.UpsertHeartbeat(hb)
 				if err != nil {
 					logger.Error("Error inserting heartbeat", zap.Error(err))
 				} else {
 					metrics.IncHeartbeatInserted(hb.NodeName)
 				}
+				if err := heartbeatHistory.Add(rootCtx, hb); err != nil {
+					logger.Error("Error inserting heartbeat sample", zap.Error(err))
+				}
 			}
 		}
 	}(phylaxCheck)
@@ -491,7 +552,17 @@ func main() {
 				}
 				metrics.IncGovernorConfigFromGossipNetwork(nodeName)
 
-				err = repository.UpsertGovernorConfig(govConfig)
+				phylaxSetIndex, err := phylaxSetHistory.VerifyGovernorConfig(rootCtx, govConfig)
+				if err != nil {
+					logger.Error("Could not verify gov config", zap.String("nodeName", nodeName), zap.Error(err))
+					metrics.IncGovernorConfigRejected(nodeName)
+					continue
+				}
+
+				if repository == nil {
+					continue
+				}
+				err = repository.UpsertGovernorConfig(govConfig, phylaxSetIndex)
 				if err != nil {
 					logger.Error("Error inserting gov config", zap.Error(err))
 				} else {
@@ -515,7 +586,18 @@ func main() {
 					continue
 				}
 				metrics.IncGovernorStatusFromGossipNetwork(nodeName)
-				err = repository.UpsertGovernorStatus(govStatus)
+
+				phylaxSetIndex, err := phylaxSetHistory.VerifyGovernorStatus(rootCtx, govStatus)
+				if err != nil {
+					logger.Error("Could not verify gov status", zap.String("nodeName", nodeName), zap.Error(err))
+					metrics.IncGovernorStatusRejected(nodeName)
+					continue
+				}
+
+				if repository == nil {
+					continue
+				}
+				err = repository.UpsertGovernorStatus(govStatus, phylaxSetIndex)
 				if err != nil {
 					logger.Error("Error inserting gov status", zap.Error(err))
 				} else {
@@ -582,8 +664,14 @@ func main() {
 	vaaGossipConsumerSplitter.Close()
 	server.Stop()
 
-	logger.Info("Closing MongoDB connection...")
-	db.DisconnectWithTimeout(10 * time.Second)
+	if boltStore != nil {
+		logger.Info("Closing vaa store...")
+		boltStore.Close()
+	}
+	if db != nil {
+		logger.Info("Closing MongoDB connection...")
+		db.DisconnectWithTimeout(10 * time.Second)
+	}
 }
 
 // getGovernorConfigNodeName get node name from governor config.
@@ -3,6 +3,7 @@ package migration
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -10,7 +11,7 @@ import (
 )
 
 // TODO: move this to migration tool that support mongodb.
-func Run(db *mongo.Database) error {
+func Run(db *mongo.Database, heartbeatHistoryRetention, observationQuorumRetention time.Duration) error {
 	// Created governorConfig collection.
 	err := db.CreateCollection(context.TODO(), "governorConfig")
 	if err != nil && isNotAlreadyExistsError(err) {
@@ -23,18 +24,48 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// Created governorEvents collection.
+	err = db.CreateCollection(context.TODO(), "governorEvents")
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// Created governorNotionalHistory collection.
+	err = db.CreateCollection(context.TODO(), "governorNotionalHistory")
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// Created heartbeats collection.
 	err = db.CreateCollection(context.TODO(), "heartbeats")
 	if err != nil && isNotAlreadyExistsError(err) {
 		return err
 	}
 
+	// Created heartbeatHistory time-series collection, the samples are removed after the retention period.
+	heartbeatHistoryOptions := options.CreateCollection().
+		SetTimeSeriesOptions(options.TimeSeries().
+			SetTimeField("timestamp").
+			SetMetaField("phylaxAddr").
+			SetGranularity("minutes")).
+		SetExpireAfterSeconds(int64(heartbeatHistoryRetention.Seconds()))
+	err = db.CreateCollection(context.TODO(), "heartbeatHistory", heartbeatHistoryOptions)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// Created observations collection.
 	err = db.CreateCollection(context.TODO(), "observations")
 	if err != nil && isNotAlreadyExistsError(err) {
 		return err
 	}
 
+	// Created observationQuorums collection.
+	err = db.CreateCollection(context.TODO(), "observationQuorums")
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// Created vaaCounts collection.
 	err = db.CreateCollection(context.TODO(), "vaaCounts")
 	if err != nil && isNotAlreadyExistsError(err) {
@@ -65,6 +96,18 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// Create phylaxSets collection.
+	err = db.CreateCollection(context.TODO(), "phylaxSets")
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// Create historicalPrices collection.
+	err = db.CreateCollection(context.TODO(), "historicalPrices")
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// create index in vaas collection by vaa key (emitterchain, emitterAddr, sequence)
 	indexVaaByKey := mongo.IndexModel{
 		Keys: bson.D{
@@ -88,6 +131,13 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in vaas collection by indexedAt, used by the pipeline to catch up the vaas indexed after a checkpoint.
+	indexVaaByIndexedAt := mongo.IndexModel{Keys: bson.D{{Key: "indexedAt", Value: 1}}}
+	_, err = db.Collection("vaas").Indexes().CreateOne(context.TODO(), indexVaaByIndexedAt)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// create index in observations collection by indexedAt.
 	indexObservationsByIndexedAt := mongo.IndexModel{Keys: bson.D{{Key: "indexedAt", Value: 1}}}
 	_, err = db.Collection("observations").Indexes().CreateOne(context.TODO(), indexObservationsByIndexedAt)
@@ -106,6 +156,25 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in observationQuorums collection by quorumAt and firstObservedAt to find the stuck messages.
+	indexObservationQuorumsByFirstObservedAt := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "quorumAt", Value: 1},
+			{Key: "firstObservedAt", Value: 1}}}
+	_, err = db.Collection("observationQuorums").Indexes().CreateOne(context.TODO(), indexObservationQuorumsByFirstObservedAt)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create TTL index in observationQuorums collection, the quorum progress is removed after the retention period.
+	indexObservationQuorumsByTTL := mongo.IndexModel{
+		Keys:    bson.D{{Key: "firstObservedAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(observationQuorumRetention.Seconds()))}
+	_, err = db.Collection("observationQuorums").Indexes().CreateOne(context.TODO(), indexObservationQuorumsByTTL)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// create index in vaaIdTxHash collect.
 	indexVaaIdTxHashByTxHash := mongo.IndexModel{
 		Keys: bson.D{{Key: "txHash", Value: 1}}}
@@ -114,6 +183,56 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in governorEvents collection by vaaId and timestamp.
+	indexGovernorEventsByVaaID := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "vaaId", Value: 1},
+			{Key: "timestamp", Value: 1}}}
+	_, err = db.Collection("governorEvents").Indexes().CreateOne(context.TODO(), indexGovernorEventsByVaaID)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in governorNotionalHistory collection by chainId and timestamp.
+	indexGovernorNotionalHistoryByChainID := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "chainId", Value: 1},
+			{Key: "timestamp", Value: 1}}}
+	_, err = db.Collection("governorNotionalHistory").Indexes().CreateOne(context.TODO(), indexGovernorNotionalHistoryByChainID)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in heartbeatHistory collection by phylaxAddr and timestamp.
+	indexHeartbeatHistoryByPhylaxAddrAndTimestamp := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "phylaxAddr", Value: 1},
+			{Key: "timestamp", Value: 1}}}
+	_, err = db.Collection("heartbeatHistory").Indexes().CreateOne(context.TODO(), indexHeartbeatHistoryByPhylaxAddrAndTimestamp)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in historicalPrices collection by coingeckoId and day to find the latest price of a token.
+	indexHistoricalPricesByCoingeckoID := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "coingeckoId", Value: 1},
+			{Key: "day", Value: -1}}}
+	_, err = db.Collection("historicalPrices").Indexes().CreateOne(context.TODO(), indexHistoricalPricesByCoingeckoID)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in globalTransactions collection by destination chain and block to find the txs removed by a reorg.
+	indexGlobalTransactionsByDestinationBlock := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "destinationTx.chainId", Value: 1},
+			{Key: "destinationTx.blockNumber", Value: 1}}}
+	_, err = db.Collection("globalTransactions").Indexes().CreateOne(context.TODO(), indexGlobalTransactionsByDestinationBlock)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,79 @@
+package phylaxsets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
+	flyAlert "github.com/deltaswapio/deltaswap-explorer/fly/internal/alert"
+	gossipv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/gossip/v1"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// The phylaxs sign the governor messages with a prefix, so the signatures can't be reused for other messages.
+var (
+	governorConfigPrefix = []byte("governor_config_000000")
+	governorStatusPrefix = []byte("governor_status_000000")
+)
+
+var (
+	// ErrInvalidGovernorSignature is returned when the signature of a governor message
+	// doesn't match the phylax address of the message.
+	ErrInvalidGovernorSignature = errors.New("invalid governor message signature")
+	// ErrGovernorSignerNotInPhylaxSet is returned when a governor message is signed by
+	// an address that is not in the current phylax set.
+	ErrGovernorSignerNotInPhylaxSet = errors.New("governor message signer not in phylax set")
+)
+
+// VerifyGovernorConfig validates the signature of a governor config against the latest phylax set.
+// It returns the index of the phylax set that verified the message.
+func (h *PhylaxSetHistory) VerifyGovernorConfig(ctx context.Context, m *gossipv1.SignedChainGovernorConfig) (uint32, error) {
+	return h.verifyGovernorMessage(ctx, "config", governorConfigPrefix, m.Config, m.Signature, m.PhylaxAddr)
+}
+
+// VerifyGovernorStatus validates the signature of a governor status against the latest phylax set.
+// It returns the index of the phylax set that verified the message.
+func (h *PhylaxSetHistory) VerifyGovernorStatus(ctx context.Context, m *gossipv1.SignedChainGovernorStatus) (uint32, error) {
+	return h.verifyGovernorMessage(ctx, "status", governorStatusPrefix, m.Status, m.Signature, m.PhylaxAddr)
+}
+
+func (h *PhylaxSetHistory) verifyGovernorMessage(ctx context.Context, messageType string, prefix, data, signature, phylaxAddr []byte) (uint32, error) {
+	ps := h.GetLatest()
+	addr := eth_common.BytesToAddress(phylaxAddr)
+
+	err := verifyGovernorSignature(prefix, data, signature, addr)
+	if err == nil {
+		if _, ok := ps.KeyIndex(addr); !ok {
+			err = ErrGovernorSignerNotInPhylaxSet
+		}
+	}
+	if err != nil {
+		alertContext := alert.AlertContext{
+			Details: map[string]string{
+				"messageType":    messageType,
+				"phylaxAddr":     addr.Hex(),
+				"phylaxSetIndex": fmt.Sprint(ps.Index),
+			},
+			Error: err,
+		}
+		_ = h.alertClient.CreateAndSend(ctx, flyAlert.GovernorMessageRejected, alertContext)
+		return 0, err
+	}
+	return ps.Index, nil
+}
+
+// verifyGovernorSignature checks that the message was signed by the given address.
+func verifyGovernorSignature(prefix, data, signature []byte, addr eth_common.Address) error {
+	digest := eth_crypto.Keccak256Hash(append(append([]byte{}, prefix...), data...))
+	pk, err := eth_crypto.Ecrecover(digest.Bytes(), signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidGovernorSignature, err)
+	}
+	signer := eth_common.BytesToAddress(eth_crypto.Keccak256(pk[1:])[12:])
+	if signer != addr {
+		return ErrInvalidGovernorSignature
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package phylaxsets
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
+	"github.com/deltaswapio/deltaswap/node/pkg/common"
+	gossipv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/gossip/v1"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func newGovernorTestHistory(keys ...eth_common.Address) *PhylaxSetHistory {
+	expiration := time.Now()
+	return &PhylaxSetHistory{
+		phylaxSetsByIndex:      []common.PhylaxSet{{Index: 0}, {Index: 1, Keys: keys}},
+		expirationTimesByIndex: []*time.Time{&expiration, nil},
+		alertClient:            alert.NewDummyClient(),
+	}
+}
+
+func signGovernorStatus(t *testing.T, key *ecdsa.PrivateKey, status []byte) *gossipv1.SignedChainGovernorStatus {
+	digest := eth_crypto.Keccak256Hash(append(append([]byte{}, governorStatusPrefix...), status...))
+	sig, err := eth_crypto.Sign(digest.Bytes(), key)
+	if err != nil {
+		t.Fatalf("Failed to sign governor status: %v", err)
+	}
+	return &gossipv1.SignedChainGovernorStatus{
+		Status:     status,
+		Signature:  sig,
+		PhylaxAddr: eth_crypto.PubkeyToAddress(key.PublicKey).Bytes(),
+	}
+}
+
+// TestVerifyGovernorStatus exercises the method `PhylaxSetHistory.VerifyGovernorStatus()`
+func TestVerifyGovernorStatus(t *testing.T) {
+	key, _ := eth_crypto.GenerateKey()
+	other, _ := eth_crypto.GenerateKey()
+	h := newGovernorTestHistory(eth_crypto.PubkeyToAddress(key.PublicKey))
+
+	// a message signed by a phylax of the latest phylax set is verified by it.
+	m := signGovernorStatus(t, key, []byte("status"))
+	index, err := h.VerifyGovernorStatus(context.TODO(), m)
+	if err != nil {
+		t.Fatalf("Failed to verify governor status: %v", err)
+	}
+	if index != 1 {
+		t.Fatalf("Expected phylax set index 1, got %d", index)
+	}
+
+	// the message was modified after signing it.
+	m.Status = []byte("spoofed")
+	_, err = h.VerifyGovernorStatus(context.TODO(), m)
+	if !errors.Is(err, ErrInvalidGovernorSignature) {
+		t.Fatalf("Expected invalid signature error, got %v", err)
+	}
+
+	// the signature is valid but the signer is not a phylax.
+	m = signGovernorStatus(t, other, []byte("status"))
+	_, err = h.VerifyGovernorStatus(context.TODO(), m)
+	if !errors.Is(err, ErrGovernorSignerNotInPhylaxSet) {
+		t.Fatalf("Expected signer not in phylax set error, got %v", err)
+	}
+
+	// a status signature can't be used as a config signature.
+	m = signGovernorStatus(t, key, []byte("status"))
+	_, err = h.VerifyGovernorConfig(context.TODO(), &gossipv1.SignedChainGovernorConfig{
+		Config:     m.Status,
+		Signature:  m.Signature,
+		PhylaxAddr: m.PhylaxAddr,
+	})
+	if !errors.Is(err, ErrInvalidGovernorSignature) {
+		t.Fatalf("Expected invalid signature error, got %v", err)
+	}
+}
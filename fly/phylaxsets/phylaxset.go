@@ -2,8 +2,8 @@ package phylaxsets
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
@@ -15,14 +15,18 @@ import (
 )
 
 // PhylaxSetHistory contains information about all phylax sets for the current network (past and present).
+// The expiration time is nil for the current phylax set and for the phylax sets with an unknown expiration.
 type PhylaxSetHistory struct {
+	mu                     sync.RWMutex
 	phylaxSetsByIndex      []common.PhylaxSet
-	expirationTimesByIndex []time.Time
+	expirationTimesByIndex []*time.Time
 	alertClient            alert.AlertClient
 }
 
-// Verify takes a VAA as input and validates its phylax signatures.
+// Verify takes a VAA as input and validates its phylax signatures reach the quorum of its phylax set.
 func (h *PhylaxSetHistory) Verify(ctx context.Context, vaa *sdk.VAA) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 
 	idx := vaa.PhylaxSetIndex
 
@@ -42,21 +46,79 @@ func (h *PhylaxSetHistory) Verify(ctx context.Context, vaa *sdk.VAA) error {
 		)
 	}
 
-	// Verify phylax signatures
-	if vaa.VerifySignatures(h.phylaxSetsByIndex[idx].Keys) {
-		return nil
-	} else {
-		return errors.New("VAA contains invalid signatures")
+	// Verify the VAA has a quorum of valid phylax signatures
+	if err := vaa.Verify(h.phylaxSetsByIndex[idx].Keys); err != nil {
+		return fmt.Errorf("invalid VAA signatures: %w", err)
 	}
+	return nil
 }
 
 // GetLatest returns the lastest phylax set.
-func (h PhylaxSetHistory) GetLatest() common.PhylaxSet {
+func (h *PhylaxSetHistory) GetLatest() common.PhylaxSet {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	return h.phylaxSetsByIndex[len(h.phylaxSetsByIndex)-1]
 }
 
+// Upgrade appends a new phylax set to the history and sets the expiration time of the previous one.
+// The new phylax set index must be the next index of the latest phylax set.
+func (h *PhylaxSetHistory) Upgrade(ps common.PhylaxSet, expirationTime time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	latest := len(h.phylaxSetsByIndex) - 1
+	if err := h.appendLocked(ps); err != nil {
+		return err
+	}
+	h.expirationTimesByIndex[latest] = &expirationTime
+	return nil
+}
+
+// add appends a new phylax set to the history without changing the expiration time of the previous one.
+func (h *PhylaxSetHistory) add(ps common.PhylaxSet) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.appendLocked(ps)
+}
+
+func (h *PhylaxSetHistory) appendLocked(ps common.PhylaxSet) error {
+	next := uint32(len(h.phylaxSetsByIndex))
+	if ps.Index != next {
+		return fmt.Errorf("invalid phylax set index: got %d, expected %d", ps.Index, next)
+	}
+	h.phylaxSetsByIndex = append(h.phylaxSetsByIndex, ps)
+	h.expirationTimesByIndex = append(h.expirationTimesByIndex, nil)
+	return nil
+}
+
+// Len returns the number of phylax sets in the history.
+func (h *PhylaxSetHistory) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.phylaxSetsByIndex)
+}
+
+// setExpiration sets the expiration time of the phylax set with the given index.
+func (h *PhylaxSetHistory) setExpiration(index uint32, expirationTime time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if index < uint32(len(h.expirationTimesByIndex)) {
+		h.expirationTimesByIndex[index] = &expirationTime
+	}
+}
+
+// get returns the phylax set and its expiration time by index.
+func (h *PhylaxSetHistory) get(index uint32) (common.PhylaxSet, *time.Time, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if index >= uint32(len(h.phylaxSetsByIndex)) {
+		return common.PhylaxSet{}, nil, false
+	}
+	return h.phylaxSetsByIndex[index], h.expirationTimesByIndex[index], true
+}
+
 // Get get phylaxset config by enviroment.
-func GetByEnv(enviroment string, alertClient alert.AlertClient) PhylaxSetHistory {
+func GetByEnv(enviroment string, alertClient alert.AlertClient) *PhylaxSetHistory {
 	switch enviroment {
 	case domain.P2pTestNet:
 		return getTestnetPhylaxSet(alertClient)
@@ -65,23 +127,21 @@ func GetByEnv(enviroment string, alertClient alert.AlertClient) PhylaxSetHistory
 	}
 }
 
-func getTestnetPhylaxSet(alertClient alert.AlertClient) PhylaxSetHistory {
-	const tenYears = time.Hour * 24 * 365 * 10
-	gs0TestValidUntil := time.Now().Add(tenYears)
+func getTestnetPhylaxSet(alertClient alert.AlertClient) *PhylaxSetHistory {
 	gstest0 := common.PhylaxSet{
 		Index: 0,
 		Keys: []eth_common.Address{
 			eth_common.HexToAddress("0x13947Bd48b18E53fdAeEe77F3473391aC727C638"), //
 		},
 	}
-	return PhylaxSetHistory{
+	return &PhylaxSetHistory{
 		phylaxSetsByIndex:      []common.PhylaxSet{gstest0},
-		expirationTimesByIndex: []time.Time{gs0TestValidUntil},
+		expirationTimesByIndex: []*time.Time{nil},
 		alertClient:            alertClient,
 	}
 }
 
-func getMainnetPhylaxSet(alertClient alert.AlertClient) PhylaxSetHistory {
+func getMainnetPhylaxSet(alertClient alert.AlertClient) *PhylaxSetHistory {
 	gs0ValidUntil := time.Unix(1628599904, 0) // Tue Aug 10 2021 12:51:44 GMT+0000
 	gs0 := common.PhylaxSet{
 		Index: 0,
@@ -116,8 +176,6 @@ func getMainnetPhylaxSet(alertClient alert.AlertClient) PhylaxSetHistory {
 		},
 	}
 
-	const tenYears = time.Hour * 24 * 365 * 10
-	gs2ValidUntil := time.Now().Add(tenYears) // still valid so we add 10 years
 	gs2 := common.PhylaxSet{
 		Index: 2,
 		Keys: []eth_common.Address{
@@ -145,7 +203,6 @@ func getMainnetPhylaxSet(alertClient alert.AlertClient) PhylaxSetHistory {
 		},
 	}
 
-	gs3ValidUntil := time.Now().Add(tenYears) // still valid so we add 10 years
 	gs3 := common.PhylaxSet{
 		Index: 3,
 		Keys: []eth_common.Address{
@@ -172,9 +229,10 @@ func getMainnetPhylaxSet(alertClient alert.AlertClient) PhylaxSetHistory {
 		},
 	}
 
-	return PhylaxSetHistory{
+	// the expiration of gs2 is not known, it is set from the storage when the gs3 upgrade was processed.
+	return &PhylaxSetHistory{
 		phylaxSetsByIndex:      []common.PhylaxSet{gs0, gs1, gs2, gs3},
-		expirationTimesByIndex: []time.Time{gs0ValidUntil, gs1ValidUntil, gs2ValidUntil, gs3ValidUntil},
+		expirationTimesByIndex: []*time.Time{&gs0ValidUntil, &gs1ValidUntil, nil, nil},
 		alertClient:            alertClient,
 	}
 }
@@ -3,10 +3,18 @@ package phylaxsets
 import (
 	"context"
 	_ "embed"
+	"encoding/binary"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap/node/pkg/common"
 	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
 )
 
 //go:embed validVaa.bin
@@ -54,3 +62,178 @@ func TestInvalidSignatures(t *testing.T) {
 	}
 
 }
+
+// TestParsePhylaxSetUpgrade exercises the function `ParsePhylaxSetUpgrade()`
+func TestParsePhylaxSetUpgrade(t *testing.T) {
+
+	keys := []eth_common.Address{
+		eth_common.HexToAddress("0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5"),
+		eth_common.HexToAddress("0xfF6CB952589BDE862c25Ef4392132fb9D4A42157"),
+	}
+
+	payload := append([]byte{}, sdk.CoreModule...)
+	payload = append(payload, actionPhylaxSetUpgrade, 0, 0, 0, 0, 0, 4, byte(len(keys)))
+	for _, k := range keys {
+		payload = append(payload, k.Bytes()...)
+	}
+
+	vaa := sdk.VAA{
+		EmitterChain:   sdk.GovernanceChain,
+		EmitterAddress: sdk.GovernanceEmitter,
+		Payload:        payload,
+	}
+
+	upgrade, err := ParsePhylaxSetUpgrade(&vaa)
+	if err != nil {
+		t.Fatalf("Failed to parse phylax set upgrade: %v", err)
+	}
+	if upgrade.NewIndex != 4 {
+		t.Fatalf("Expected new index 4, got %d", upgrade.NewIndex)
+	}
+	if len(upgrade.Keys) != len(keys) || upgrade.Keys[0] != keys[0] || upgrade.Keys[1] != keys[1] {
+		t.Fatalf("Unexpected keys: %v", upgrade.Keys)
+	}
+
+	// an upgrade of the phylax set of the network chain is accepted
+	binary.BigEndian.PutUint16(vaa.Payload[33:35], uint16(sdk.ChainIDDeltachain))
+	if _, err := ParsePhylaxSetUpgrade(&vaa); err != nil {
+		t.Fatalf("Failed to parse phylax set upgrade of the network chain: %v", err)
+	}
+
+	// an upgrade of the phylax set of another chain is rejected
+	binary.BigEndian.PutUint16(vaa.Payload[33:35], uint16(sdk.ChainIDEthereum))
+	if _, err := ParsePhylaxSetUpgrade(&vaa); !errors.Is(err, ErrInvalidUpgradePayload) {
+		t.Fatalf("Expected error %v, got %v", ErrInvalidUpgradePayload, err)
+	}
+
+	// a payload from another emitter is not a phylax set upgrade
+	vaa.EmitterChain = sdk.ChainIDEthereum
+	if IsPhylaxSetUpgrade(&vaa) {
+		t.Fatal("Expected VAA not to be a phylax set upgrade")
+	}
+
+}
+
+// TestUpgrade exercises the method `PhylaxSetHistory.Upgrade()`
+func TestUpgrade(t *testing.T) {
+
+	h := getMainnetPhylaxSet(alert.NewDummyClient())
+	latest := h.GetLatest()
+	expiration := time.Now().Add(phylaxSetExpiration)
+
+	// an upgrade must use the next phylax set index
+	err := h.Upgrade(common.PhylaxSet{Index: latest.Index + 2, Keys: latest.Keys}, expiration)
+	if err == nil {
+		t.Fatal("Expected upgrade with an invalid index to fail")
+	}
+
+	err = h.Upgrade(common.PhylaxSet{Index: latest.Index + 1, Keys: latest.Keys}, expiration)
+	if err != nil {
+		t.Fatalf("Failed to upgrade phylax set: %v", err)
+	}
+	if h.GetLatest().Index != latest.Index+1 {
+		t.Fatalf("Expected latest phylax set %d, got %d", latest.Index+1, h.GetLatest().Index)
+	}
+	_, previousExpiration, _ := h.get(latest.Index)
+	if previousExpiration == nil || !previousExpiration.Equal(expiration) {
+		t.Fatalf("Expected previous phylax set to expire at %v, got %v", expiration, previousExpiration)
+	}
+
+}
+
+type failingStorage struct{}
+
+func (s *failingStorage) FindAll(ctx context.Context) ([]*repository.PhylaxSetDoc, error) {
+	return nil, nil
+}
+
+func (s *failingStorage) Upsert(ctx context.Context, doc *repository.PhylaxSetDoc) error {
+	return errors.New("storage unavailable")
+}
+
+// TestUpgradeProcessorStorageError exercises the method `UpgradeProcessor.Process()` when the storage fails
+func TestUpgradeProcessorStorageError(t *testing.T) {
+
+	h := getMainnetPhylaxSet(alert.NewDummyClient())
+	latest := h.GetLatest()
+	gst := common.NewPhylaxSetState(nil)
+	gst.Set(&latest)
+
+	payload := append([]byte{}, sdk.CoreModule...)
+	payload = append(payload, actionPhylaxSetUpgrade, 0, 0)
+	payload = binary.BigEndian.AppendUint32(payload, latest.Index+1)
+	payload = append(payload, byte(len(latest.Keys)))
+	for _, k := range latest.Keys {
+		payload = append(payload, k.Bytes()...)
+	}
+	vaa := sdk.VAA{
+		EmitterChain:   sdk.GovernanceChain,
+		EmitterAddress: sdk.GovernanceEmitter,
+		PhylaxSetIndex: latest.Index,
+		Signatures:     make([]*sdk.Signature, sdk.CalculateQuorum(len(latest.Keys))),
+		Timestamp:      time.Now(),
+		Payload:        payload,
+	}
+
+	// the phylax set is not switched when it can't be persisted
+	p := NewUpgradeProcessor(h, &failingStorage{}, gst, zap.NewNop())
+	if err := p.Process(context.TODO(), &vaa, nil); err == nil {
+		t.Fatal("Expected upgrade to fail")
+	}
+	if h.GetLatest().Index != latest.Index {
+		t.Fatalf("Expected latest phylax set %d, got %d", latest.Index, h.GetLatest().Index)
+	}
+	if gst.Get().Index != latest.Index {
+		t.Fatalf("Expected gossip phylax set %d, got %d", latest.Index, gst.Get().Index)
+	}
+
+}
+
+type memoryStorage struct {
+	docs map[uint32]*repository.PhylaxSetDoc
+}
+
+func (s *memoryStorage) FindAll(ctx context.Context) ([]*repository.PhylaxSetDoc, error) {
+	docs := make([]*repository.PhylaxSetDoc, 0, len(s.docs))
+	for i := uint32(0); i < uint32(len(s.docs)); i++ {
+		docs = append(docs, s.docs[i])
+	}
+	return docs, nil
+}
+
+func (s *memoryStorage) Upsert(ctx context.Context, doc *repository.PhylaxSetDoc) error {
+	s.docs[doc.Index] = doc
+	return nil
+}
+
+// TestLoadExpirations exercises the function `Load()` with the expiration times of the hardcoded phylax sets
+func TestLoadExpirations(t *testing.T) {
+
+	s := &memoryStorage{docs: make(map[uint32]*repository.PhylaxSetDoc)}
+	if _, err := Load(context.Background(), domain.P2pMainNet, s, alert.NewDummyClient(), zap.NewNop()); err != nil {
+		t.Fatalf("Failed to load phylax sets: %v", err)
+	}
+	if len(s.docs) != 4 {
+		t.Fatalf("Expected 4 persisted phylax sets, got %d", len(s.docs))
+	}
+	if e := s.docs[1].ExpirationTime; e == nil || !e.Equal(time.Unix(1650566103, 0)) {
+		t.Fatalf("Unexpected expiration of phylax set 1: %v", e)
+	}
+	// the expiration of gs2 is unknown and gs3 is the current phylax set.
+	for _, index := range []uint32{2, 3} {
+		if e := s.docs[index].ExpirationTime; e != nil {
+			t.Fatalf("Expected no expiration for phylax set %d, got %v", index, e)
+		}
+	}
+
+	// the expiration stored by the upgrade is kept.
+	expiration := time.Unix(1673913600, 0)
+	s.docs[2].ExpirationTime = &expiration
+	history, err := Load(context.Background(), domain.P2pMainNet, s, alert.NewDummyClient(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to load phylax sets: %v", err)
+	}
+	if _, e, _ := history.get(2); e == nil || !e.Equal(expiration) {
+		t.Fatalf("Unexpected expiration of phylax set 2: %v", e)
+	}
+}
@@ -0,0 +1,98 @@
+package phylaxsets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap/node/pkg/common"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// Storage represents the persistence of the phylax set history.
+type Storage interface {
+	FindAll(ctx context.Context) ([]*repository.PhylaxSetDoc, error)
+	Upsert(ctx context.Context, doc *repository.PhylaxSetDoc) error
+}
+
+// Load returns the phylax set history for the enviroment. The hardcoded phylax sets are extended with
+// the phylax sets found in the storage, and the hardcoded phylax sets missing in the storage are persisted.
+func Load(ctx context.Context, enviroment string, s Storage, alertClient alert.AlertClient, logger *zap.Logger) (*PhylaxSetHistory, error) {
+	history := GetByEnv(enviroment, alertClient)
+
+	docs, err := s.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	persisted := make(map[uint32]bool, len(docs))
+	for _, doc := range docs {
+		persisted[doc.Index] = true
+
+		switch {
+		case doc.Index < uint32(history.Len()):
+			if doc.ExpirationTime != nil {
+				history.setExpiration(doc.Index, *doc.ExpirationTime)
+			}
+		case doc.Index == uint32(history.Len()):
+			ps, err := toPhylaxSet(doc)
+			if err != nil {
+				return nil, err
+			}
+			if err := history.add(*ps); err != nil {
+				return nil, err
+			}
+			if doc.ExpirationTime != nil {
+				history.setExpiration(doc.Index, *doc.ExpirationTime)
+			}
+		default:
+			logger.Warn("Skipping phylax set, previous phylax set is missing",
+				zap.Uint32("index", doc.Index),
+				zap.Int("expected", history.Len()))
+		}
+	}
+
+	// persist the hardcoded phylax sets.
+	latest := history.GetLatest()
+	for i := 0; i < history.Len(); i++ {
+		index := uint32(i)
+		if persisted[index] {
+			continue
+		}
+		// the expiration is nil for the current phylax set and for the phylax sets with an unknown expiration.
+		ps, expiration, _ := history.get(index)
+		if err := s.Upsert(ctx, toPhylaxSetDoc(ps, expiration, "")); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("Phylax set history loaded", zap.Uint32("latest", latest.Index))
+	return history, nil
+}
+
+func toPhylaxSet(doc *repository.PhylaxSetDoc) (*common.PhylaxSet, error) {
+	keys := make([]eth_common.Address, 0, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if !eth_common.IsHexAddress(k) {
+			return nil, fmt.Errorf("invalid key %s in phylax set %d", k, doc.Index)
+		}
+		keys = append(keys, eth_common.HexToAddress(k))
+	}
+	return &common.PhylaxSet{Index: doc.Index, Keys: keys}, nil
+}
+
+func toPhylaxSetDoc(ps common.PhylaxSet, expirationTime *time.Time, vaaID string) *repository.PhylaxSetDoc {
+	keys := make([]string, 0, len(ps.Keys))
+	for _, k := range ps.Keys {
+		keys = append(keys, k.Hex())
+	}
+	return &repository.PhylaxSetDoc{
+		Index:          ps.Index,
+		Keys:           keys,
+		VaaID:          vaaID,
+		ExpirationTime: expirationTime,
+	}
+}
@@ -0,0 +1,162 @@
+package phylaxsets
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/deltaswapio/deltaswap/node/pkg/common"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+const (
+	// actionPhylaxSetUpgrade is the core bridge governance action for a phylax set upgrade.
+	actionPhylaxSetUpgrade uint8 = 2
+
+	// phylaxSetExpiration is the time a phylax set remains valid after being replaced,
+	// it matches the expiration used by the core bridge contracts.
+	phylaxSetExpiration = 24 * time.Hour
+
+	// minUpgradePayloadLength is the length of the payload header: module (32), action (1),
+	// chain (2), new phylax set index (4) and number of keys (1).
+	minUpgradePayloadLength = 40
+
+	// networkChainID is the chain that holds the phylax set of the gossip network. The upgrades that target
+	// another chain only change the phylax set of the core bridge contract of that chain.
+	networkChainID = sdk.ChainIDDeltachain
+)
+
+var (
+	ErrInvalidUpgradePayload = errors.New("invalid phylax set upgrade payload")
+	ErrNoQuorum              = errors.New("phylax set upgrade without quorum")
+)
+
+// PhylaxSetUpgrade represents a core bridge phylax set upgrade governance payload.
+type PhylaxSetUpgrade struct {
+	VaaID    string
+	NewIndex uint32
+	Keys     []eth_common.Address
+}
+
+// IsPhylaxSetUpgrade returns true if the VAA is a core bridge phylax set upgrade governance VAA.
+func IsPhylaxSetUpgrade(v *sdk.VAA) bool {
+	if v.EmitterChain != sdk.GovernanceChain || v.EmitterAddress != sdk.GovernanceEmitter {
+		return false
+	}
+	if len(v.Payload) < minUpgradePayloadLength {
+		return false
+	}
+	return bytes.Equal(v.Payload[:32], sdk.CoreModule) && v.Payload[32] == actionPhylaxSetUpgrade
+}
+
+// ParsePhylaxSetUpgrade decodes the payload of a phylax set upgrade governance VAA.
+func ParsePhylaxSetUpgrade(v *sdk.VAA) (*PhylaxSetUpgrade, error) {
+	if !IsPhylaxSetUpgrade(v) {
+		return nil, ErrInvalidUpgradePayload
+	}
+
+	payload := v.Payload
+	targetChain := sdk.ChainID(binary.BigEndian.Uint16(payload[33:35]))
+	if targetChain != sdk.ChainIDUnset && targetChain != networkChainID {
+		return nil, fmt.Errorf("%w: target chain %s", ErrInvalidUpgradePayload, targetChain)
+	}
+	newIndex := binary.BigEndian.Uint32(payload[35:39])
+	numKeys := int(payload[39])
+	if numKeys == 0 || len(payload) != minUpgradePayloadLength+numKeys*eth_common.AddressLength {
+		return nil, fmt.Errorf("%w: %d keys in %d bytes", ErrInvalidUpgradePayload, numKeys, len(payload))
+	}
+
+	keys := make([]eth_common.Address, 0, numKeys)
+	for i := 0; i < numKeys; i++ {
+		offset := minUpgradePayloadLength + i*eth_common.AddressLength
+		keys = append(keys, eth_common.BytesToAddress(payload[offset:offset+eth_common.AddressLength]))
+	}
+
+	return &PhylaxSetUpgrade{
+		VaaID:    v.MessageID(),
+		NewIndex: newIndex,
+		Keys:     keys,
+	}, nil
+}
+
+// UpgradeProcessor applies phylax set upgrade governance VAAs to the phylax set history.
+type UpgradeProcessor struct {
+	history *PhylaxSetHistory
+	storage Storage
+	gst     *common.PhylaxSetState
+	logger  *zap.Logger
+}
+
+// NewUpgradeProcessor creates a new phylax set upgrade processor.
+func NewUpgradeProcessor(history *PhylaxSetHistory, storage Storage, gst *common.PhylaxSetState, logger *zap.Logger) *UpgradeProcessor {
+	return &UpgradeProcessor{
+		history: history,
+		storage: storage,
+		gst:     gst,
+		logger:  logger,
+	}
+}
+
+// Process checks if the VAA is a phylax set upgrade and, in that case, persists the new phylax set,
+// adds it to the history and updates the phylax set used by the gossip network.
+// The VAA signatures must be verified before calling this method.
+func (p *UpgradeProcessor) Process(ctx context.Context, v *sdk.VAA, _ []byte) error {
+	if !IsPhylaxSetUpgrade(v) {
+		return nil
+	}
+
+	upgrade, err := ParsePhylaxSetUpgrade(v)
+	if err != nil {
+		return err
+	}
+
+	latest := p.history.GetLatest()
+	if upgrade.NewIndex <= latest.Index {
+		// the upgrade was already applied.
+		return nil
+	}
+	if upgrade.NewIndex != latest.Index+1 {
+		return fmt.Errorf("phylax set upgrade to %d, expected %d", upgrade.NewIndex, latest.Index+1)
+	}
+
+	// the upgrade must be signed by a quorum of the current phylax set.
+	if v.PhylaxSetIndex != latest.Index {
+		return fmt.Errorf("phylax set upgrade signed by phylax set %d, current is %d", v.PhylaxSetIndex, latest.Index)
+	}
+	if len(v.Signatures) < sdk.CalculateQuorum(len(latest.Keys)) {
+		return ErrNoQuorum
+	}
+
+	newPhylaxSet := common.PhylaxSet{
+		Index: upgrade.NewIndex,
+		Keys:  upgrade.Keys,
+	}
+	expirationTime := v.Timestamp.Add(phylaxSetExpiration)
+
+	// persist the expiration of the previous phylax set and the new phylax set before switching to it,
+	// so a failed upgrade is applied again when the VAA is received again or after a restart.
+	if err := p.storage.Upsert(ctx, toPhylaxSetDoc(latest, &expirationTime, "")); err != nil {
+		return err
+	}
+	if err := p.storage.Upsert(ctx, toPhylaxSetDoc(newPhylaxSet, nil, upgrade.VaaID)); err != nil {
+		return err
+	}
+
+	if err := p.history.Upgrade(newPhylaxSet, expirationTime); err != nil {
+		return err
+	}
+	if p.gst != nil {
+		p.gst.Set(&newPhylaxSet)
+	}
+
+	p.logger.Info("Phylax set upgraded",
+		zap.String("vaaId", upgrade.VaaID),
+		zap.Uint32("index", upgrade.NewIndex),
+		zap.Int("keys", len(upgrade.Keys)))
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 
 type vaaGossipConsumer struct {
 	phylaxSetHistory *phylaxsets.PhylaxSetHistory
+	phylaxSetUpgrade VAAPushFunc
 	nonPythProcess   VAAPushFunc
 	pythProcess      VAAPushFunc
 	logger           *zap.Logger
@@ -23,6 +24,7 @@ type vaaGossipConsumer struct {
 // NewVAAGossipConsumer creates a new processor instances.
 func NewVAAGossipConsumer(
 	phylaxSetHistory *phylaxsets.PhylaxSetHistory,
+	phylaxSetUpgrade VAAPushFunc,
 	deduplicator *deduplicator.Deduplicator,
 	nonPythPublish VAAPushFunc,
 	pythPublish VAAPushFunc,
@@ -32,6 +34,7 @@ func NewVAAGossipConsumer(
 
 	return &vaaGossipConsumer{
 		phylaxSetHistory: phylaxSetHistory,
+		phylaxSetUpgrade: phylaxSetUpgrade,
 		deduplicator:     deduplicator,
 		nonPythProcess:   nonPythPublish,
 		pythProcess:      pythPublish,
@@ -48,6 +51,13 @@ func (p *vaaGossipConsumer) Push(ctx context.Context, v *vaa.VAA, serializedVaa
 		return err
 	}
 
+	// apply phylax set upgrades before any other VAA signed by the new phylax set arrives.
+	if err := p.phylaxSetUpgrade(ctx, v, serializedVaa); err != nil {
+		p.logger.Error("Error applying phylax set upgrade",
+			zap.String("id", v.MessageID()),
+			zap.Error(err))
+	}
+
 	err := p.deduplicator.Apply(ctx, v.MessageID(), func() error {
 		p.metrics.IncVaaUnfiltered(v.EmitterChain)
 		if vaa.ChainIDPythNet == v.EmitterChain {
@@ -17,7 +17,7 @@ type VAAQueueConsumeFunc func(context.Context) <-chan queue.Message
 // VAAQueueConsumer represents a VAA queue consumer.
 type VAAQueueConsumer struct {
 	consume    VAAQueueConsumeFunc
-	repository *storage.Repository
+	vaaStore   storage.VaaStore
 	notifyFunc VAANotifyFunc
 	metrics    metrics.Metrics
 	logger     *zap.Logger
@@ -26,20 +26,20 @@ type VAAQueueConsumer struct {
 // NewVAAQueueConsumer creates a new VAA queue consumer instances.
 func NewVAAQueueConsumer(
 	consume VAAQueueConsumeFunc,
-	repository *storage.Repository,
+	vaaStore storage.VaaStore,
 	notifyFunc VAANotifyFunc,
 	metrics metrics.Metrics,
 	logger *zap.Logger) *VAAQueueConsumer {
 	return &VAAQueueConsumer{
 		consume:    consume,
-		repository: repository,
+		vaaStore:   vaaStore,
 		notifyFunc: notifyFunc,
 		metrics:    metrics,
 		logger:     logger,
 	}
 }
 
-// Start consumes messages from VAA queue and store those messages in a repository.
+// Start consumes messages from VAA queue and store those messages in a vaa store.
 func (c *VAAQueueConsumer) Start(ctx context.Context) {
 	go func() {
 		for msg := range c.consume(ctx) {
@@ -58,7 +58,7 @@ func (c *VAAQueueConsumer) Start(ctx context.Context) {
 
 			c.metrics.IncVaaConsumedFromQueue(v.EmitterChain)
 
-			err = c.repository.UpsertVaa(ctx, v, msg.Data())
+			err = c.vaaStore.UpsertVaa(ctx, v, msg.Data())
 			if err != nil {
 				c.logger.Error("Error inserting vaa in repository",
 					zap.String("id", v.MessageID()),
@@ -16,7 +16,7 @@ import (
 // Controller definition.
 type Controller struct {
 	phylaxCheck *health.PhylaxCheck
-	repository  *storage.Repository
+	vaaStore    storage.VaaStore
 	consumer    *sqs.Consumer
 	isLocal     bool
 	logger      *zap.Logger
@@ -24,8 +24,8 @@ type Controller struct {
 }
 
 // NewController creates a Controller instance.
-func NewController(gCheck *health.PhylaxCheck, repo *storage.Repository, consumer *sqs.Consumer, isLocal bool, alertClient alert.AlertClient, logger *zap.Logger) *Controller {
-	return &Controller{phylaxCheck: gCheck, repository: repo, consumer: consumer, isLocal: isLocal, alertClient: alertClient, logger: logger}
+func NewController(gCheck *health.PhylaxCheck, vaaStore storage.VaaStore, consumer *sqs.Consumer, isLocal bool, alertClient alert.AlertClient, logger *zap.Logger) *Controller {
+	return &Controller{phylaxCheck: gCheck, vaaStore: vaaStore, consumer: consumer, isLocal: isLocal, alertClient: alertClient, logger: logger}
 }
 
 // HealthCheck handler for the endpoint /health.
@@ -51,14 +51,14 @@ func (c *Controller) HealthCheck(ctx *fiber.Ctx) error {
 
 // ReadyCheck handler for the endpoint /ready
 func (c *Controller) ReadyCheck(ctx *fiber.Ctx) error {
-	// check mongo db is ready.
-	mongoErr := c.checkMongoStatus(ctx.Context())
-	if mongoErr != nil {
-		c.logger.Error("Ready check failed", zap.Error(mongoErr))
+	// check vaa store is ready.
+	storeErr := c.vaaStore.Ready(ctx.Context())
+	if storeErr != nil {
+		c.logger.Error("Ready check failed", zap.Error(storeErr))
 		return ctx.Status(fiber.StatusInternalServerError).JSON(struct {
 			Ready string `json:"ready"`
 			Error string `json:"error"`
-		}{Ready: "NO", Error: mongoErr.Error()})
+		}{Ready: "NO", Error: storeErr.Error()})
 	}
 	// check aws SQS is ready.
 	queueErr := c.checkQueueStatus(ctx.Context())
@@ -75,25 +75,6 @@ func (c *Controller) ReadyCheck(ctx *fiber.Ctx) error {
 	}{Ready: "OK"})
 }
 
-func (c *Controller) checkMongoStatus(ctx context.Context) error {
-	mongoStatus, err := c.repository.GetMongoStatus(ctx)
-	if err != nil {
-		return err
-	}
-
-	// check mongo server status
-	mongoStatusCheck := (mongoStatus.Ok == 1 && mongoStatus.Pid > 0 && mongoStatus.Uptime > 0)
-	if !mongoStatusCheck {
-		return errors.New("mongo invalid status")
-	}
-
-	// check mongo connections
-	if mongoStatus.Connections.Available <= 0 {
-		return errors.New("mongo hasn't available connections")
-	}
-	return nil
-}
-
 func (c *Controller) checkQueueStatus(ctx context.Context) error {
 	// vaa queue handle in memory [local enviroment]
 	if c.isLocal {
@@ -19,8 +19,8 @@ type Server struct {
 	logger *zap.Logger
 }
 
-func NewServer(port uint, phylaxCheck *health.PhylaxCheck, logger *zap.Logger, repository *storage.Repository, consumer *sqs.Consumer, isLocal, pprofEnabled bool, alertClient alert.AlertClient) *Server {
-	ctrl := NewController(phylaxCheck, repository, consumer, isLocal, alertClient, logger)
+func NewServer(port uint, phylaxCheck *health.PhylaxCheck, logger *zap.Logger, vaaStore storage.VaaStore, consumer *sqs.Consumer, isLocal, pprofEnabled bool, alertClient alert.AlertClient) *Server {
+	ctrl := NewController(phylaxCheck, vaaStore, consumer, isLocal, alertClient, logger)
 	app := fiber.New(fiber.Config{DisableStartupMessage: true})
 
 	// Configure middleware
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/fly/internal/metrics"
+	"github.com/deltaswapio/deltaswap-explorer/fly/producer"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	vaasBucket        = []byte("vaas")
+	vaasPythnetBucket = []byte("vaasPythnet")
+	phylaxSetsBucket  = []byte("phylaxSets")
+)
+
+// ErrVaaNotFound is returned when a VAA is not found in the store.
+var ErrVaaNotFound = errors.New("vaa not found")
+
+// BoltStore is an embedded VaaStore backed by a BoltDB file, it doesn't need any external service.
+//
+// The serialized VAAs are stored by VAA ID, the pythnet VAAs in a separate bucket as in mongo.
+// The store also persists the phylax set history, so it can be used by fly in local mode without mongo.
+type BoltStore struct {
+	db          *bolt.DB
+	metrics     metrics.Metrics
+	afterUpdate producer.PushFunc
+}
+
+// NewBoltStore opens or creates the BoltDB file at the given path.
+func NewBoltStore(path string, metrics metrics.Metrics, afterUpdate producer.PushFunc) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt store %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{vaasBucket, vaasPythnetBucket, phylaxSetsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create bolt store buckets: %w", err)
+	}
+
+	return &BoltStore{db: db, metrics: metrics, afterUpdate: afterUpdate}, nil
+}
+
+// UpsertVaa stores a signed VAA. When the VAA was not stored before, a signed VAA event is pushed.
+func (s *BoltStore) UpsertVaa(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error {
+	bucket := vaasBucket
+	if v.EmitterChain == vaa.ChainIDPythNet {
+		bucket = vaasPythnetBucket
+	}
+
+	id := []byte(v.MessageID())
+	var isNew bool
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		isNew = b.Get(id) == nil
+		return b.Put(id, serializedVaa)
+	})
+	if err != nil {
+		return err
+	}
+
+	if isNew {
+		s.metrics.IncVaaInserted(v.EmitterChain)
+		// the tx hashes of the observations are not stored, the event is pushed without them.
+		return s.afterUpdate(ctx, newSignedVaaEvent(v, serializedVaa, ""))
+	}
+	return nil
+}
+
+// FindVaa returns the serialized VAA with the given ID.
+func (s *BoltStore) FindVaa(_ context.Context, id string) ([]byte, error) {
+	var serializedVaa []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{vaasBucket, vaasPythnetBucket} {
+			if data := tx.Bucket(bucket).Get([]byte(id)); data != nil {
+				// the value is only valid inside the transaction.
+				serializedVaa = append([]byte(nil), data...)
+				return nil
+			}
+		}
+		return ErrVaaNotFound
+	})
+	return serializedVaa, err
+}
+
+// Ready checks the BoltDB file is open.
+func (s *BoltStore) Ready(_ context.Context) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(vaasBucket) == nil {
+			return errors.New("bolt store hasn't been initialized")
+		}
+		return nil
+	})
+}
+
+// FindAll returns the phylax sets stored, ordered by index.
+func (s *BoltStore) FindAll(_ context.Context) ([]*repository.PhylaxSetDoc, error) {
+	var docs []*repository.PhylaxSetDoc
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(phylaxSetsBucket).ForEach(func(_, value []byte) error {
+			var doc repository.PhylaxSetDoc
+			if err := json.Unmarshal(value, &doc); err != nil {
+				return err
+			}
+			doc.ID = doc.Index
+			docs = append(docs, &doc)
+			return nil
+		})
+	})
+	return docs, err
+}
+
+// Upsert stores a phylax set.
+func (s *BoltStore) Upsert(_ context.Context, doc *repository.PhylaxSetDoc) error {
+	value, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	// the keys are big endian so the phylax sets are iterated by index.
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, doc.Index)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(phylaxSetsBucket).Put(key, value)
+	})
+}
+
+// Close closes the BoltDB file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
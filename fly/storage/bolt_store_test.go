@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/fly/internal/metrics"
+	"github.com/deltaswapio/deltaswap-explorer/fly/producer"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoltStore_UpsertVaa(t *testing.T) {
+	var events []*producer.NotificationEvent
+	afterUpdate := func(_ context.Context, e *producer.NotificationEvent) error {
+		events = append(events, e)
+		return nil
+	}
+
+	s, err := NewBoltStore(filepath.Join(t.TempDir(), "fly.db"), metrics.NewDummyMetrics(), afterUpdate)
+	assert.NoError(t, err)
+	defer s.Close()
+
+	ctx := context.Background()
+	assert.NoError(t, s.Ready(ctx))
+
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		Timestamp:        time.Unix(1672531200, 0),
+		EmitterChain:     vaa.ChainIDEthereum,
+		EmitterAddress:   vaa.Address{1},
+		Sequence:         7,
+		ConsistencyLevel: 1,
+		Payload:          []byte{1, 2, 3},
+	}
+	serializedVaa, err := v.Marshal()
+	assert.NoError(t, err)
+
+	// the event is pushed only the first time the vaa is stored.
+	assert.NoError(t, s.UpsertVaa(ctx, v, serializedVaa))
+	assert.NoError(t, s.UpsertVaa(ctx, v, serializedVaa))
+	assert.Len(t, events, 1)
+	assert.Equal(t, v.MessageID(), events[0].Payload.ID)
+
+	stored, err := s.FindVaa(ctx, v.MessageID())
+	assert.NoError(t, err)
+	assert.Equal(t, serializedVaa, stored)
+
+	// the pythnet vaas are stored in their own bucket.
+	v.EmitterChain = vaa.ChainIDPythNet
+	assert.NoError(t, s.UpsertVaa(ctx, v, serializedVaa))
+	_, err = s.FindVaa(ctx, v.MessageID())
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	_, err = s.FindVaa(ctx, "2/0000000000000000000000000000000000000000000000000000000000000001/8")
+	assert.ErrorIs(t, err, ErrVaaNotFound)
+
+	assert.NoError(t, s.Close())
+	assert.Error(t, s.Ready(ctx))
+}
+
+func TestBoltStore_PhylaxSets(t *testing.T) {
+	s, err := NewBoltStore(filepath.Join(t.TempDir(), "fly.db"), metrics.NewDummyMetrics(), nil)
+	assert.NoError(t, err)
+	defer s.Close()
+
+	ctx := context.Background()
+	expiration := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, s.Upsert(ctx, &repository.PhylaxSetDoc{Index: 1, Keys: []string{"0x02"}}))
+	assert.NoError(t, s.Upsert(ctx, &repository.PhylaxSetDoc{Index: 0, Keys: []string{"0x01"}, ExpirationTime: &expiration}))
+
+	docs, err := s.FindAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, uint32(0), docs[0].Index)
+	assert.True(t, expiration.Equal(*docs[0].ExpirationTime))
+	assert.Equal(t, uint32(1), docs[1].Index)
+	assert.Equal(t, []string{"0x02"}, docs[1].Keys)
+	assert.Nil(t, docs[1].ExpirationTime)
+}
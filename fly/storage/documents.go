@@ -142,3 +142,69 @@ type ChainGovernorConfigToken struct {
 	OriginAddress string
 	Price         float32
 }
+
+// HeartbeatSampleDoc is a snapshot of the heartbeat of a phylax stored in the heartbeatHistory time-series collection.
+type HeartbeatSampleDoc struct {
+	Timestamp     time.Time                   `bson:"timestamp"`
+	PhylaxAddr    string                      `bson:"phylaxAddr"`
+	NodeName      string                      `bson:"nodeName"`
+	Counter       int64                       `bson:"counter"`
+	BootTimestamp int64                       `bson:"bootTimestamp"`
+	Version       string                      `bson:"version"`
+	Networks      []HeartbeatNetworkSampleDoc `bson:"networks"`
+}
+
+// HeartbeatNetworkSampleDoc is the status of a chain in a heartbeat snapshot.
+type HeartbeatNetworkSampleDoc struct {
+	ChainID    vaa.ChainID `bson:"chainId"`
+	Height     int64       `bson:"height"`
+	ErrorCount uint64      `bson:"errorCount"`
+}
+
+// Governor event types.
+const (
+	GovernorEventEnqueued = "enqueued"
+	GovernorEventReleased = "released"
+	GovernorEventDropped  = "dropped"
+)
+
+// GovernorEventDoc is a change of the VAAs enqueued by the governor of a phylax.
+type GovernorEventDoc struct {
+	VaaID          string    `bson:"vaaId"`
+	PhylaxAddr     string    `bson:"phylaxAddr"`
+	NodeName       string    `bson:"nodeName"`
+	Type           string    `bson:"type"`
+	ChainID        uint32    `bson:"chainId"`
+	EmitterAddress string    `bson:"emitterAddress"`
+	Sequence       string    `bson:"sequence"`
+	ReleaseTime    uint32    `bson:"releaseTime"`
+	NotionalValue  Uint64    `bson:"notionalValue"`
+	TxHash         string    `bson:"txHash"`
+	Timestamp      time.Time `bson:"timestamp"`
+}
+
+// GovernorNotionalDoc is a change of the remaining available notional of a chain reported by the governor of a phylax.
+type GovernorNotionalDoc struct {
+	PhylaxAddr                 string    `bson:"phylaxAddr"`
+	NodeName                   string    `bson:"nodeName"`
+	ChainID                    uint32    `bson:"chainId"`
+	RemainingAvailableNotional Uint64    `bson:"remainingAvailableNotional"`
+	Timestamp                  time.Time `bson:"timestamp"`
+}
+
+// ObservationQuorumDoc is the progress of the phylax signatures of a message towards the quorum of the phylax set.
+type ObservationQuorumDoc struct {
+	ID              string      `bson:"_id"`
+	EmitterChain    vaa.ChainID `bson:"emitterChain"`
+	EmitterAddr     string      `bson:"emitterAddr"`
+	Sequence        string      `bson:"sequence"`
+	PhylaxSetIndex  uint32      `bson:"phylaxSetIndex"`
+	Quorum          int         `bson:"quorum"`
+	Signers         []string    `bson:"signers"`
+	SignerCount     int         `bson:"signerCount"`
+	MissingSigners  []string    `bson:"missingSigners"`
+	FirstObservedAt *time.Time  `bson:"firstObservedAt"`
+	QuorumAt        *time.Time  `bson:"quorumAt,omitempty"`
+	StuckAlertedAt  *time.Time  `bson:"stuckAlertedAt,omitempty"`
+	UpdatedAt       *time.Time  `bson:"updatedAt"`
+}
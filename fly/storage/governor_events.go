@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// governorStatusChanges compares two governor statuses of a phylax and returns the changes of
+// the enqueued VAAs and of the remaining available notional of each chain.
+//
+// prev is nil for the first status of the phylax. A VAA removed from the queue is released when
+// its release time has passed or when isSigned reports that the VAA was signed, otherwise it was dropped.
+func governorStatusChanges(
+	phylaxAddr string,
+	prev, curr *GovernorStatusUpdate,
+	now time.Time,
+	isSigned func(vaaID string) bool,
+) ([]*GovernorEventDoc, []*GovernorNotionalDoc) {
+
+	prevVaas, prevNotionals := indexGovernorStatus(prev)
+	currVaas, currNotionals := indexGovernorStatus(curr)
+
+	var events []*GovernorEventDoc
+	for id, v := range currVaas {
+		if _, ok := prevVaas[id]; !ok {
+			events = append(events, newGovernorEventDoc(phylaxAddr, curr.NodeName, GovernorEventEnqueued, v, now))
+		}
+	}
+	for id, v := range prevVaas {
+		if _, ok := currVaas[id]; ok {
+			continue
+		}
+		eventType := GovernorEventDropped
+		if now.Unix() >= int64(v.vaa.ReleaseTime) || isSigned(id) {
+			eventType = GovernorEventReleased
+		}
+		events = append(events, newGovernorEventDoc(phylaxAddr, curr.NodeName, eventType, v, now))
+	}
+
+	var notionals []*GovernorNotionalDoc
+	for chainID, notional := range currNotionals {
+		if prevNotional, ok := prevNotionals[chainID]; ok && prevNotional == notional {
+			continue
+		}
+		notionals = append(notionals, &GovernorNotionalDoc{
+			PhylaxAddr:                 phylaxAddr,
+			NodeName:                   curr.NodeName,
+			ChainID:                    chainID,
+			RemainingAvailableNotional: notional,
+			Timestamp:                  now,
+		})
+	}
+
+	return events, notionals
+}
+
+// enqueuedVaa is a VAA enqueued by the governor with its chain and emitter.
+type enqueuedVaa struct {
+	chainID        uint32
+	emitterAddress string
+	vaa            *ChainGovernorStatusEnqueuedVAA
+}
+
+// indexGovernorStatus returns the enqueued VAAs by VAA ID and the remaining available notional by chain.
+func indexGovernorStatus(s *GovernorStatusUpdate) (map[string]*enqueuedVaa, map[uint32]Uint64) {
+	vaas := make(map[string]*enqueuedVaa)
+	notionals := make(map[uint32]Uint64)
+	if s == nil {
+		return vaas, notionals
+	}
+	for _, c := range s.Chains {
+		notionals[c.ChainId] = c.RemainingAvailableNotional
+		for _, e := range c.Emitters {
+			for _, v := range e.EnqueuedVaas {
+				vaas[governorVaaID(c.ChainId, e.EmitterAddress, v.Sequence)] = &enqueuedVaa{
+					chainID:        c.ChainId,
+					emitterAddress: e.EmitterAddress,
+					vaa:            v,
+				}
+			}
+		}
+	}
+	return vaas, notionals
+}
+
+// governorVaaID returns the ID of an enqueued VAA in the format of the vaas collection.
+// The governor reports the emitter addresses with the 0x prefix.
+func governorVaaID(chainID uint32, emitterAddress, sequence string) string {
+	return fmt.Sprintf("%d/%s/%s", chainID, strings.ToLower(strings.TrimPrefix(emitterAddress, "0x")), sequence)
+}
+
+func newGovernorEventDoc(phylaxAddr, nodeName, eventType string, v *enqueuedVaa, now time.Time) *GovernorEventDoc {
+	return &GovernorEventDoc{
+		VaaID:          governorVaaID(v.chainID, v.emitterAddress, v.vaa.Sequence),
+		PhylaxAddr:     phylaxAddr,
+		NodeName:       nodeName,
+		Type:           eventType,
+		ChainID:        v.chainID,
+		EmitterAddress: v.emitterAddress,
+		Sequence:       v.vaa.Sequence,
+		ReleaseTime:    v.vaa.ReleaseTime,
+		NotionalValue:  v.vaa.NotionalValue,
+		TxHash:         v.vaa.TxHash,
+		Timestamp:      now,
+	}
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const governorTestEmitter = "0x0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585"
+
+func newGovernorStatus(notional Uint64, vaas ...*ChainGovernorStatusEnqueuedVAA) *GovernorStatusUpdate {
+	return &GovernorStatusUpdate{
+		NodeName: "phylax-0",
+		Chains: []*ChainGovernorStatusChain{{
+			ChainId:                    2,
+			RemainingAvailableNotional: notional,
+			Emitters: []*ChainGovernorStatusEmitter{{
+				EmitterAddress: governorTestEmitter,
+				EnqueuedVaas:   vaas,
+			}},
+		}},
+	}
+}
+
+func TestGovernorStatusChanges(t *testing.T) {
+	now := time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC)
+	pending := &ChainGovernorStatusEnqueuedVAA{Sequence: "1", ReleaseTime: uint32(now.Add(time.Hour).Unix())}
+	due := &ChainGovernorStatusEnqueuedVAA{Sequence: "2", ReleaseTime: uint32(now.Add(-time.Minute).Unix())}
+	signed := &ChainGovernorStatusEnqueuedVAA{Sequence: "3", ReleaseTime: uint32(now.Add(time.Hour).Unix())}
+	added := &ChainGovernorStatusEnqueuedVAA{Sequence: "4", ReleaseTime: uint32(now.Add(24 * time.Hour).Unix())}
+	isSigned := func(vaaID string) bool {
+		return vaaID == "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/3"
+	}
+
+	t.Run("first status", func(t *testing.T) {
+		events, notionals := governorStatusChanges("addr", nil, newGovernorStatus(100, pending), now, isSigned)
+		assert.Len(t, events, 1)
+		assert.Equal(t, GovernorEventEnqueued, events[0].Type)
+		assert.Equal(t, "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1", events[0].VaaID)
+		assert.Len(t, notionals, 1)
+		assert.Equal(t, Uint64(100), notionals[0].RemainingAvailableNotional)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		events, notionals := governorStatusChanges("addr", newGovernorStatus(100, pending), newGovernorStatus(100, pending), now, isSigned)
+		assert.Empty(t, events)
+		assert.Empty(t, notionals)
+	})
+
+	t.Run("enqueued, released and dropped", func(t *testing.T) {
+		prev := newGovernorStatus(100, pending, due, signed)
+		curr := newGovernorStatus(80, added)
+		events, notionals := governorStatusChanges("addr", prev, curr, now, isSigned)
+
+		types := make(map[string]string)
+		for _, e := range events {
+			types[e.Sequence] = e.Type
+		}
+		assert.Equal(t, map[string]string{
+			"1": GovernorEventDropped,
+			"2": GovernorEventReleased,
+			"3": GovernorEventReleased,
+			"4": GovernorEventEnqueued,
+		}, types)
+		assert.Len(t, notionals, 1)
+		assert.Equal(t, Uint64(80), notionals[0].RemainingAvailableNotional)
+	})
+}
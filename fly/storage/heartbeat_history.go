@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	gossipv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/gossip/v1"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// HeartbeatHistory stores sampled heartbeats in the heartbeatHistory time-series collection.
+//
+// A phylax sends a heartbeat every few seconds, so only one snapshot per sample interval is stored.
+// A snapshot is always stored when the boot timestamp or the version of the phylax changes,
+// so restarts and upgrades are never lost by the sampling.
+// It is not safe for concurrent use.
+type HeartbeatHistory struct {
+	collection *mongo.Collection
+	interval   time.Duration
+	last       map[string]*HeartbeatSampleDoc
+	log        *zap.Logger
+}
+
+// NewHeartbeatHistory creates a new HeartbeatHistory.
+func NewHeartbeatHistory(db *mongo.Database, interval time.Duration, log *zap.Logger) *HeartbeatHistory {
+	return &HeartbeatHistory{
+		collection: db.Collection("heartbeatHistory"),
+		interval:   interval,
+		last:       make(map[string]*HeartbeatSampleDoc),
+		log:        log,
+	}
+}
+
+// Add stores a snapshot of the heartbeat if it is due.
+func (h *HeartbeatHistory) Add(ctx context.Context, hb *gossipv1.Heartbeat) error {
+	sample := newHeartbeatSampleDoc(hb, time.Now())
+	if !h.isDue(sample) {
+		return nil
+	}
+	if _, err := h.collection.InsertOne(ctx, sample); err != nil {
+		h.log.Error("Error inserting heartbeat sample", zap.Error(err), zap.String("phylaxAddr", sample.PhylaxAddr))
+		return err
+	}
+	h.last[sample.PhylaxAddr] = sample
+	return nil
+}
+
+func (h *HeartbeatHistory) isDue(sample *HeartbeatSampleDoc) bool {
+	last, ok := h.last[sample.PhylaxAddr]
+	if !ok {
+		return true
+	}
+	return sample.Timestamp.Sub(last.Timestamp) >= h.interval ||
+		sample.BootTimestamp != last.BootTimestamp ||
+		sample.Version != last.Version
+}
+
+func newHeartbeatSampleDoc(hb *gossipv1.Heartbeat, now time.Time) *HeartbeatSampleDoc {
+	networks := make([]HeartbeatNetworkSampleDoc, 0, len(hb.Networks))
+	for _, n := range hb.Networks {
+		networks = append(networks, HeartbeatNetworkSampleDoc{
+			ChainID:    vaa.ChainID(n.Id),
+			Height:     n.Height,
+			ErrorCount: n.ErrorCount,
+		})
+	}
+	// phylax addresses are stored as 40 hex digits, the same format used by the governor collections.
+	phylaxAddr := hex.EncodeToString(eth_common.HexToAddress(hb.PhylaxAddr).Bytes())
+	return &HeartbeatSampleDoc{
+		Timestamp:     now,
+		PhylaxAddr:    phylaxAddr,
+		NodeName:      hb.NodeName,
+		Counter:       hb.Counter,
+		BootTimestamp: hb.BootTimestamp,
+		Version:       hb.Version,
+		Networks:      networks,
+	}
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	gossipv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/gossip/v1"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeatHistory_isDue(t *testing.T) {
+	h := &HeartbeatHistory{interval: time.Minute, last: make(map[string]*HeartbeatSampleDoc)}
+	t0 := time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC)
+	hb := &gossipv1.Heartbeat{
+		PhylaxAddr:    "0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5",
+		BootTimestamp: 1,
+		Version:       "v1",
+	}
+
+	first := newHeartbeatSampleDoc(hb, t0)
+	assert.True(t, h.isDue(first))
+	h.last[first.PhylaxAddr] = first
+
+	assert.False(t, h.isDue(newHeartbeatSampleDoc(hb, t0.Add(30*time.Second))))
+	assert.True(t, h.isDue(newHeartbeatSampleDoc(hb, t0.Add(time.Minute))))
+
+	// a restart or an upgrade is always sampled.
+	restarted := &gossipv1.Heartbeat{PhylaxAddr: hb.PhylaxAddr, BootTimestamp: 2, Version: "v1"}
+	assert.True(t, h.isDue(newHeartbeatSampleDoc(restarted, t0.Add(time.Second))))
+	upgraded := &gossipv1.Heartbeat{PhylaxAddr: hb.PhylaxAddr, BootTimestamp: 1, Version: "v2"}
+	assert.True(t, h.isDue(newHeartbeatSampleDoc(upgraded, t0.Add(time.Second))))
+}
+
+func TestNewHeartbeatSampleDoc(t *testing.T) {
+	t0 := time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC)
+	hb := &gossipv1.Heartbeat{
+		NodeName:   "phylax-0",
+		PhylaxAddr: "0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5",
+		Networks:   []*gossipv1.Heartbeat_Network{{Id: uint32(vaa.ChainIDEthereum), Height: 100, ErrorCount: 1}},
+	}
+
+	doc := newHeartbeatSampleDoc(hb, t0)
+	assert.Equal(t, "58cc3ae5c097b213ce3c81979e1b9f9570746aa5", doc.PhylaxAddr)
+	assert.Equal(t, t0, doc.Timestamp)
+	assert.Equal(t, []HeartbeatNetworkSampleDoc{{ChainID: vaa.ChainIDEthereum, Height: 100, ErrorCount: 1}}, doc.Networks)
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
+	flyAlert "github.com/deltaswapio/deltaswap-explorer/fly/internal/alert"
+	"github.com/deltaswapio/deltaswap/node/pkg/common"
+	gossipv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/gossip/v1"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// maxStuckQuorumAlerts is the maximum number of stuck messages alerted in each check.
+const maxStuckQuorumAlerts = 100
+
+// QuorumTracker stores in the observationQuorums collection the distinct phylax signatures of each
+// message, the time of its first observation, the time it reached the quorum and the missing signers.
+//
+// The observations must be verified against the phylax set before being tracked.
+type QuorumTracker struct {
+	alertClient    alert.AlertClient
+	collection     *mongo.Collection
+	stuckThreshold time.Duration
+	log            *zap.Logger
+}
+
+// NewQuorumTracker creates a new QuorumTracker.
+// The messages that don't reach the quorum after stuckThreshold are alerted by CheckStuck.
+func NewQuorumTracker(alertClient alert.AlertClient, db *mongo.Database, stuckThreshold time.Duration, log *zap.Logger) *QuorumTracker {
+	return &QuorumTracker{
+		alertClient:    alertClient,
+		collection:     db.Collection("observationQuorums"),
+		stuckThreshold: stuckThreshold,
+		log:            log,
+	}
+}
+
+// Track adds the signer of the observation to the quorum progress of its message.
+func (t *QuorumTracker) Track(ctx context.Context, o *gossipv1.SignedObservation, gs *common.PhylaxSet) error {
+	vaaID := strings.Split(o.MessageId, "/")
+	if len(vaaID) != 3 {
+		return nil
+	}
+	chainID, err := strconv.ParseUint(vaaID[0], 10, 16)
+	if err != nil {
+		t.log.Error("Error parsing chainId", zap.Error(err))
+		return err
+	}
+	// pyth observations are not stored.
+	if vaa.ChainID(chainID) == vaa.ChainIDPythNet {
+		return nil
+	}
+
+	signer := eth_common.BytesToAddress(o.GetAddr()).Hex()
+	update := trackPipeline(vaa.ChainID(chainID), vaaID[1], vaaID[2], signer, gs, time.Now())
+	opts := options.Update().SetUpsert(true)
+	_, err = t.collection.UpdateByID(ctx, o.MessageId, update, opts)
+	if err != nil {
+		t.log.Error("Error updating observation quorum", zap.Error(err), zap.String("id", o.MessageId))
+		return err
+	}
+	return nil
+}
+
+// trackPipeline returns the update that adds the signer to a message and computes its quorum progress
+// from the stored signers, so concurrent observations of the message are tracked in a single write each.
+// The signers are matched with the keys of the phylax set regardless of the case.
+func trackPipeline(chainID vaa.ChainID, emitterAddr, sequence, signer string, gs *common.PhylaxSet, now time.Time) mongo.Pipeline {
+	keys := make(bson.A, 0, len(gs.Keys))
+	for _, k := range gs.Keys {
+		keys = append(keys, k.Hex())
+	}
+	quorum := vaa.CalculateQuorum(len(gs.Keys))
+	signers := bson.M{"$map": bson.M{"input": "$signers", "as": "s", "in": bson.M{"$toLower": "$$s"}}}
+	missing := bson.M{"$filter": bson.M{
+		"input": keys,
+		"as":    "k",
+		"cond":  bson.M{"$not": bson.A{bson.M{"$in": bson.A{bson.M{"$toLower": "$$k"}, signers}}}},
+	}}
+
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{
+			{Key: "signers", Value: bson.M{"$setUnion": bson.A{bson.M{"$ifNull": bson.A{"$signers", bson.A{}}}, bson.A{signer}}}},
+			{Key: "phylaxSetIndex", Value: gs.Index},
+			{Key: "quorum", Value: quorum},
+			{Key: "updatedAt", Value: now},
+			{Key: "emitterChain", Value: bson.M{"$ifNull": bson.A{"$emitterChain", chainID}}},
+			{Key: "emitterAddr", Value: bson.M{"$ifNull": bson.A{"$emitterAddr", emitterAddr}}},
+			{Key: "sequence", Value: bson.M{"$ifNull": bson.A{"$sequence", sequence}}},
+			{Key: "firstObservedAt", Value: bson.M{"$ifNull": bson.A{"$firstObservedAt", now}}},
+		}}},
+		{{Key: "$set", Value: bson.D{
+			{Key: "missingSigners", Value: missing},
+		}}},
+		{{Key: "$set", Value: bson.D{
+			{Key: "signerCount", Value: bson.M{"$subtract": bson.A{len(keys), bson.M{"$size": "$missingSigners"}}}},
+		}}},
+		// the first time the quorum was reached is kept.
+		{{Key: "$set", Value: bson.D{
+			{Key: "quorumAt", Value: bson.M{"$ifNull": bson.A{
+				"$quorumAt",
+				bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$signerCount", quorum}}, now, "$$REMOVE"}},
+			}}},
+		}}},
+	}
+}
+
+// RunStuckCheck calls CheckStuck every interval until the context is cancelled.
+func (t *QuorumTracker) RunStuckCheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.CheckStuck(ctx); err != nil {
+				t.log.Error("Error checking stuck observation quorums", zap.Error(err))
+			}
+		}
+	}
+}
+
+// CheckStuck sends an alert for each message below the quorum after the stuck threshold.
+// Each message is alerted only once.
+func (t *QuorumTracker) CheckStuck(ctx context.Context) error {
+	filter := bson.M{
+		"quorumAt":        bson.M{"$exists": false},
+		"stuckAlertedAt":  bson.M{"$exists": false},
+		"firstObservedAt": bson.M{"$lte": time.Now().Add(-t.stuckThreshold)},
+	}
+	opts := options.Find().SetLimit(maxStuckQuorumAlerts).SetSort(bson.D{{Key: "firstObservedAt", Value: 1}})
+	cur, err := t.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	var docs []*ObservationQuorumDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		alertContext := alert.AlertContext{
+			Details: map[string]string{
+				"messageId":       doc.ID,
+				"phylaxSetIndex":  strconv.FormatUint(uint64(doc.PhylaxSetIndex), 10),
+				"signerCount":     strconv.Itoa(doc.SignerCount),
+				"quorum":          strconv.Itoa(doc.Quorum),
+				"missingSigners":  strings.Join(doc.MissingSigners, ","),
+				"firstObservedAt": doc.FirstObservedAt.Format(time.RFC3339),
+			},
+		}
+		_ = t.alertClient.CreateAndSend(ctx, flyAlert.ObservationQuorumStuck, alertContext)
+
+		_, err := t.collection.UpdateByID(ctx, doc.ID, bson.M{"$set": bson.M{"stuckAlertedAt": time.Now()}})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
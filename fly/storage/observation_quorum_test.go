@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deltaswapio/deltaswap/node/pkg/common"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestTrackPipeline(t *testing.T) {
+	keys := []eth_common.Address{
+		eth_common.HexToAddress("0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5"),
+		eth_common.HexToAddress("0xfF6CB952589BDE862c25Ef4392132fb9D4A42157"),
+		eth_common.HexToAddress("0x114De8460193bdf3A2fCf81f86a09765F4762fD1"),
+	}
+	gs := &common.PhylaxSet{Index: 1, Keys: keys}
+	now := time.Now()
+
+	pipeline := trackPipeline(vaa.ChainIDEthereum, "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585", "1", keys[1].Hex(), gs, now)
+	require.Len(t, pipeline, 4)
+
+	// each stage computes its fields from the fields set by the previous stages.
+	fields := make(map[string]interface{})
+	for i, stage := range pipeline {
+		require.Len(t, stage, 1)
+		assert.Equal(t, "$set", stage[0].Key, "stage %d", i)
+		for _, e := range stage[0].Value.(bson.D) {
+			fields[e.Key] = e.Value
+		}
+	}
+	assert.Equal(t, 3, fields["quorum"])
+	assert.Equal(t, gs.Index, fields["phylaxSetIndex"])
+	assert.Equal(t, bson.M{"$setUnion": bson.A{bson.M{"$ifNull": bson.A{"$signers", bson.A{}}}, bson.A{keys[1].Hex()}}}, fields["signers"])
+	assert.Equal(t, bson.M{"$ifNull": bson.A{"$firstObservedAt", now}}, fields["firstObservedAt"])
+
+	// the missing signers keep the order of the phylax set.
+	missing := fields["missingSigners"].(bson.M)["$filter"].(bson.M)
+	assert.Equal(t, bson.A{keys[0].Hex(), keys[1].Hex(), keys[2].Hex()}, missing["input"])
+	assert.Equal(t, bson.M{"$subtract": bson.A{3, bson.M{"$size": "$missingSigners"}}}, fields["signerCount"])
+	assert.Contains(t, fields, "quorumAt")
+}
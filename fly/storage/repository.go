@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -12,7 +13,6 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	flyAlert "github.com/deltaswapio/deltaswap-explorer/fly/internal/alert"
 	"github.com/deltaswapio/deltaswap-explorer/fly/internal/metrics"
-	"github.com/deltaswapio/deltaswap-explorer/fly/internal/track"
 	"github.com/deltaswapio/deltaswap-explorer/fly/producer"
 	gossipv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/gossip/v1"
 	"github.com/deltaswapio/deltaswap/sdk/vaa"
@@ -32,37 +32,43 @@ type Repository struct {
 	afterUpdate producer.PushFunc
 	log         *zap.Logger
 	collections struct {
-		vaas           *mongo.Collection
-		heartbeats     *mongo.Collection
-		observations   *mongo.Collection
-		governorConfig *mongo.Collection
-		governorStatus *mongo.Collection
-		vaasPythnet    *mongo.Collection
-		vaaCounts      *mongo.Collection
-		vaaIdTxHash    *mongo.Collection
+		vaas                    *mongo.Collection
+		heartbeats              *mongo.Collection
+		observations            *mongo.Collection
+		governorConfig          *mongo.Collection
+		governorStatus          *mongo.Collection
+		vaasPythnet             *mongo.Collection
+		vaaCounts               *mongo.Collection
+		vaaIdTxHash             *mongo.Collection
+		governorEvents          *mongo.Collection
+		governorNotionalHistory *mongo.Collection
 	}
 }
 
 // TODO wrap repository with a service that filters using redis
 func NewRepository(alertService alert.AlertClient, metrics metrics.Metrics, db *mongo.Database, vaaTopicFunc producer.PushFunc, log *zap.Logger) *Repository {
 	return &Repository{alertService, metrics, db, vaaTopicFunc, log, struct {
-		vaas           *mongo.Collection
-		heartbeats     *mongo.Collection
-		observations   *mongo.Collection
-		governorConfig *mongo.Collection
-		governorStatus *mongo.Collection
-		vaasPythnet    *mongo.Collection
-		vaaCounts      *mongo.Collection
-		vaaIdTxHash    *mongo.Collection
+		vaas                    *mongo.Collection
+		heartbeats              *mongo.Collection
+		observations            *mongo.Collection
+		governorConfig          *mongo.Collection
+		governorStatus          *mongo.Collection
+		vaasPythnet             *mongo.Collection
+		vaaCounts               *mongo.Collection
+		vaaIdTxHash             *mongo.Collection
+		governorEvents          *mongo.Collection
+		governorNotionalHistory *mongo.Collection
 	}{
-		vaas:           db.Collection("vaas"),
-		heartbeats:     db.Collection("heartbeats"),
-		observations:   db.Collection("observations"),
-		governorConfig: db.Collection("governorConfig"),
-		governorStatus: db.Collection("governorStatus"),
-		vaasPythnet:    db.Collection("vaasPythnet"),
-		vaaCounts:      db.Collection("vaaCounts"),
-		vaaIdTxHash:    db.Collection("vaaIdTxHash")}}
+		vaas:                    db.Collection("vaas"),
+		heartbeats:              db.Collection("heartbeats"),
+		observations:            db.Collection("observations"),
+		governorConfig:          db.Collection("governorConfig"),
+		governorStatus:          db.Collection("governorStatus"),
+		vaasPythnet:             db.Collection("vaasPythnet"),
+		vaaCounts:               db.Collection("vaaCounts"),
+		vaaIdTxHash:             db.Collection("vaaIdTxHash"),
+		governorEvents:          db.Collection("governorEvents"),
+		governorNotionalHistory: db.Collection("governorNotionalHistory")}}
 }
 
 func (s *Repository) UpsertVaa(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error {
@@ -120,28 +126,41 @@ func (s *Repository) UpsertVaa(ctx context.Context, v *vaa.VAA, serializedVaa []
 		s.updateVAACount(v.EmitterChain)
 
 		// send signedvaa event to topic.
-		event := &producer.NotificationEvent{
-			TrackID: track.GetTrackID(v.MessageID()),
-			Source:  "fly",
-			Type:    domain.SignedVaaType,
-			Payload: producer.SignedVaa{
-				ID:             v.MessageID(),
-				EmitterChain:   uint16(v.EmitterChain),
-				EmitterAddr:    v.EmitterAddress.String(),
-				Sequence:       v.Sequence,
-				PhylaxSetIndex: v.PhylaxSetIndex,
-				Timestamp:      v.Timestamp,
-				Vaa:            serializedVaa,
-				TxHash:         vaaDoc.TxHash,
-				Version:        int(v.Version),
-			},
-		}
-
+		event := newSignedVaaEvent(v, serializedVaa, vaaDoc.TxHash)
 		err = s.afterUpdate(ctx, event)
 	}
 	return err
 }
 
+// IterateVaas calls fn with each VAA of the vaas collection, or of the vaasPythnet collection when pythnet is true,
+// ordered by ID. Only the ID and the serialized VAA of the documents are decoded.
+func (s *Repository) IterateVaas(ctx context.Context, pythnet bool, fn func(*VaaUpdate) error) error {
+	collection := s.collections.vaas
+	if pythnet {
+		collection = s.collections.vaasPythnet
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetProjection(bson.D{{Key: "_id", Value: 1}, {Key: "vaas", Value: 1}})
+	cur, err := collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var doc VaaUpdate
+		if err := cur.Decode(&doc); err != nil {
+			return err
+		}
+		if err := fn(&doc); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
 func (s *Repository) UpsertObservation(o *gossipv1.SignedObservation) error {
 	ctx := context.TODO()
 	vaaID := strings.Split(o.MessageId, "/")
@@ -282,7 +301,8 @@ func (s *Repository) UpsertHeartbeat(hb *gossipv1.Heartbeat) error {
 	return err
 }
 
-func (s *Repository) UpsertGovernorConfig(govC *gossipv1.SignedChainGovernorConfig) error {
+// UpsertGovernorConfig stores a governor config verified by the phylax set with the given index.
+func (s *Repository) UpsertGovernorConfig(govC *gossipv1.SignedChainGovernorConfig, phylaxSetIndex uint32) error {
 	id := hex.EncodeToString(govC.PhylaxAddr)
 	now := time.Now()
 	var gCfg gossipv1.ChainGovernorConfig
@@ -294,7 +314,7 @@ func (s *Repository) UpsertGovernorConfig(govC *gossipv1.SignedChainGovernorConf
 
 	cfg := toGovernorConfigUpdate(&gCfg)
 
-	update := bson.D{{Key: "$set", Value: govC}, {Key: "$set", Value: bson.D{{Key: "parsedConfig", Value: cfg}}}, {Key: "$set", Value: bson.D{{Key: "updatedAt", Value: now}}}, {Key: "$setOnInsert", Value: bson.D{{Key: "createdAt", Value: now}}}}
+	update := bson.D{{Key: "$set", Value: govC}, {Key: "$set", Value: bson.D{{Key: "parsedConfig", Value: cfg}}}, {Key: "$set", Value: bson.D{{Key: "verifiedPhylaxSetIndex", Value: phylaxSetIndex}}}, {Key: "$set", Value: bson.D{{Key: "updatedAt", Value: now}}}, {Key: "$setOnInsert", Value: bson.D{{Key: "createdAt", Value: now}}}}
 
 	opts := options.Update().SetUpsert(true)
 	_, err2 := s.collections.governorConfig.UpdateByID(context.TODO(), id, update, opts)
@@ -313,7 +333,8 @@ func (s *Repository) UpsertGovernorConfig(govC *gossipv1.SignedChainGovernorConf
 	return err2
 }
 
-func (s *Repository) UpsertGovernorStatus(govS *gossipv1.SignedChainGovernorStatus) error {
+// UpsertGovernorStatus stores a governor status verified by the phylax set with the given index.
+func (s *Repository) UpsertGovernorStatus(govS *gossipv1.SignedChainGovernorStatus, phylaxSetIndex uint32) error {
 	id := hex.EncodeToString(govS.PhylaxAddr)
 	now := time.Now()
 	var gStatus gossipv1.ChainGovernorStatus
@@ -325,10 +346,20 @@ func (s *Repository) UpsertGovernorStatus(govS *gossipv1.SignedChainGovernorStat
 
 	status := toGovernorStatusUpdate(&gStatus)
 
-	update := bson.D{{Key: "$set", Value: govS}, {Key: "$set", Value: bson.D{{Key: "parsedStatus", Value: status}}}, {Key: "$set", Value: bson.D{{Key: "updatedAt", Value: now}}}, {Key: "$setOnInsert", Value: bson.D{{Key: "createdAt", Value: now}}}}
+	update := bson.D{{Key: "$set", Value: govS}, {Key: "$set", Value: bson.D{{Key: "parsedStatus", Value: status}}}, {Key: "$set", Value: bson.D{{Key: "verifiedPhylaxSetIndex", Value: phylaxSetIndex}}}, {Key: "$set", Value: bson.D{{Key: "updatedAt", Value: now}}}, {Key: "$setOnInsert", Value: bson.D{{Key: "createdAt", Value: now}}}}
 
-	opts := options.Update().SetUpsert(true)
-	_, err2 := s.collections.governorStatus.UpdateByID(context.TODO(), id, update, opts)
+	// get the previous status to record the changes of the enqueued VAAs and the available notional.
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+	var prev struct {
+		ParsedStatus *GovernorStatusUpdate `bson:"parsedStatus"`
+	}
+	err2 := s.collections.governorStatus.FindOneAndUpdate(context.TODO(), bson.M{"_id": id}, update, opts).Decode(&prev)
+	if err2 == mongo.ErrNoDocuments {
+		err2 = nil
+	}
+	if err2 == nil {
+		s.insertGovernorStatusChanges(context.TODO(), id, prev.ParsedStatus, status, now)
+	}
 
 	if err2 != nil {
 		s.log.Error("Error inserting govr status", zap.Error(err2))
@@ -344,6 +375,38 @@ func (s *Repository) UpsertGovernorStatus(govS *gossipv1.SignedChainGovernorStat
 	return err2
 }
 
+// insertGovernorStatusChanges stores the changes between two governor statuses of a phylax.
+// The changes are a history, so the errors are logged and the status is stored anyway.
+func (s *Repository) insertGovernorStatusChanges(ctx context.Context, phylaxAddr string, prev, curr *GovernorStatusUpdate, now time.Time) {
+	isSigned := func(vaaID string) bool {
+		n, err := s.collections.vaas.CountDocuments(ctx, bson.M{"_id": vaaID})
+		if err != nil {
+			s.log.Warn("Error finding released vaa", zap.String("id", vaaID), zap.Error(err))
+		}
+		return n > 0
+	}
+	events, notionals := governorStatusChanges(phylaxAddr, prev, curr, now, isSigned)
+
+	if len(events) > 0 {
+		docs := make([]interface{}, 0, len(events))
+		for _, e := range events {
+			docs = append(docs, e)
+		}
+		if _, err := s.collections.governorEvents.InsertMany(ctx, docs); err != nil {
+			s.log.Error("Error inserting govr events", zap.String("phylaxAddr", phylaxAddr), zap.Error(err))
+		}
+	}
+	if len(notionals) > 0 {
+		docs := make([]interface{}, 0, len(notionals))
+		for _, n := range notionals {
+			docs = append(docs, n)
+		}
+		if _, err := s.collections.governorNotionalHistory.InsertMany(ctx, docs); err != nil {
+			s.log.Error("Error inserting govr notional history", zap.String("phylaxAddr", phylaxAddr), zap.Error(err))
+		}
+	}
+}
+
 func (s *Repository) updateVAACount(chainID vaa.ChainID) {
 	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "count", Value: uint64(1)}}}}
 	opts := options.Update().SetUpsert(true)
@@ -370,6 +433,26 @@ func (r *Repository) GetMongoStatus(ctx context.Context) (*MongoStatus, error) {
 	return &mongoStatus, nil
 }
 
+// Ready checks the mongo server status and the available connections.
+func (r *Repository) Ready(ctx context.Context) error {
+	mongoStatus, err := r.GetMongoStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	// check mongo server status
+	mongoStatusCheck := (mongoStatus.Ok == 1 && mongoStatus.Pid > 0 && mongoStatus.Uptime > 0)
+	if !mongoStatusCheck {
+		return errors.New("mongo invalid status")
+	}
+
+	// check mongo connections
+	if mongoStatus.Connections.Available <= 0 {
+		return errors.New("mongo hasn't available connections")
+	}
+	return nil
+}
+
 func toGovernorStatusUpdate(s *gossipv1.ChainGovernorStatus) *GovernorStatusUpdate {
 	var chains []*ChainGovernorStatusChain
 	for _, c := range s.Chains {
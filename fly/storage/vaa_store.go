@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/fly/internal/track"
+	"github.com/deltaswapio/deltaswap-explorer/fly/producer"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// VaaStore represents the persistence of the signed VAAs received from the gossip network.
+type VaaStore interface {
+	// UpsertVaa stores a signed VAA. When the VAA was not stored before, a signed VAA event is pushed.
+	UpsertVaa(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error
+	// Ready returns an error when the store can't be used.
+	Ready(ctx context.Context) error
+}
+
+// newSignedVaaEvent creates the event pushed when a VAA is stored for the first time.
+func newSignedVaaEvent(v *vaa.VAA, serializedVaa []byte, txHash string) *producer.NotificationEvent {
+	return &producer.NotificationEvent{
+		TrackID: track.GetTrackID(v.MessageID()),
+		Source:  "fly",
+		Type:    domain.SignedVaaType,
+		Payload: producer.SignedVaa{
+			ID:             v.MessageID(),
+			EmitterChain:   uint16(v.EmitterChain),
+			EmitterAddr:    v.EmitterAddress.String(),
+			Sequence:       v.Sequence,
+			PhylaxSetIndex: v.PhylaxSetIndex,
+			Timestamp:      v.Timestamp,
+			Vaa:            serializedVaa,
+			TxHash:         txHash,
+			Version:        int(v.Version),
+		},
+	}
+}
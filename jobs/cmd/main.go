@@ -4,16 +4,31 @@ import (
 	"context"
 	"log"
 	"os"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	deltaswapscanNotionalCache "github.com/deltaswapio/deltaswap-explorer/common/client/cache/notional"
+	"github.com/deltaswapio/deltaswap-explorer/common/client/sns"
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
 	"github.com/deltaswapio/deltaswap-explorer/common/prices"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/common/tokens"
 	"github.com/deltaswapio/deltaswap-explorer/jobs/config"
-	"github.com/deltaswapio/deltaswap-explorer/jobs/internal/coingecko"
 	"github.com/deltaswapio/deltaswap-explorer/jobs/jobs"
 	"github.com/deltaswapio/deltaswap-explorer/jobs/jobs/notional"
+	"github.com/deltaswapio/deltaswap-explorer/jobs/jobs/reconcile"
 	"github.com/deltaswapio/deltaswap-explorer/jobs/jobs/report"
+	"github.com/deltaswapio/deltaswap-explorer/jobs/jobs/tokenregistry"
+	"github.com/deltaswapio/deltaswap-explorer/jobs/jobs/tvl"
 	"github.com/go-redis/redis"
+	redisv8 "github.com/go-redis/redis/v8"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
@@ -40,7 +55,7 @@ func main() {
 			log.Fatal("error creating config", errCfg)
 		}
 		notionalJob := initNotionalJob(context, nCfg, logger)
-		err = notionalJob.Run()
+		err = notionalJob.Run(context)
 	case jobs.JobIDTransferReport:
 		aCfg, errCfg := config.NewTransferReportConfiguration(context)
 		if errCfg != nil {
@@ -48,6 +63,27 @@ func main() {
 		}
 		transferReport := initTransferReportJob(context, aCfg, logger)
 		err = transferReport.Run(context)
+	case jobs.JobIDReconcile:
+		rCfg, errCfg := config.NewReconcileConfiguration(context)
+		if errCfg != nil {
+			log.Fatal("error creating config", errCfg)
+		}
+		reconcileJob := initReconcileJob(context, rCfg, logger)
+		err = reconcileJob.Run(context)
+	case jobs.JobIDTvl:
+		tCfg, errCfg := config.NewTvlConfiguration(context)
+		if errCfg != nil {
+			log.Fatal("error creating config", errCfg)
+		}
+		tvlJob := initTvlJob(context, tCfg, logger)
+		err = tvlJob.Run(context)
+	case jobs.JobIDTokenRegistry:
+		trCfg, errCfg := config.NewTokenRegistryConfiguration(context)
+		if errCfg != nil {
+			log.Fatal("error creating config", errCfg)
+		}
+		tokenRegistryJob := initTokenRegistryJob(context, trCfg, logger)
+		err = tokenRegistryJob.Run(context)
 
 	default:
 		logger.Fatal("Invalid job id", zap.String("job_id", cfg.JobID))
@@ -63,12 +99,21 @@ func main() {
 
 // initNotionalJob initializes notional job.
 func initNotionalJob(ctx context.Context, cfg *config.NotionalConfiguration, logger *zap.Logger) *notional.NotionalJob {
-	// init coingecko api client.
-	api := coingecko.NewCoingeckoAPI(cfg.CoingeckoURL)
+	// init price sources, coingecko first and the governor config prices as fallback.
+	sources := []prices.PriceSource{prices.NewCoingeckoSource(cfg.CoingeckoURL)}
+	if cfg.MongoURI != "" {
+		db, err := dbutil.Connect(ctx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+		if err != nil {
+			logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+		}
+		sources = append(sources, prices.NewGovernorConfigSource(db.Database))
+		// the attested tokens are priced once their coingecko ID is resolved.
+		loadTokenRegistry(ctx, db.Database, logger)
+	}
 	// init redis client.
 	redisClient := redis.NewClient(&redis.Options{Addr: cfg.CacheURL})
 	// create notional job.
-	notionalJob := notional.NewNotionalJob(api, redisClient, cfg.CachePrefix, cfg.P2pNetwork, cfg.NotionalChannel, logger)
+	notionalJob := notional.NewNotionalJob(prices.NewFallbackSource(sources...), redisClient, cfg.CachePrefix, cfg.P2pNetwork, cfg.NotionalChannel, logger)
 	return notionalJob
 }
 
@@ -79,9 +124,160 @@ func initTransferReportJob(ctx context.Context, cfg *config.TransferReportConfig
 	if err != nil {
 		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
 	}
-	pricesCache := prices.NewCoinPricesCache(cfg.PricesPath)
-	pricesCache.InitCache()
-	return report.NewTransferReportJob(db.Database, cfg.PageSize, pricesCache, cfg.OutputPath, logger)
+	loadTokenRegistry(ctx, db.Database, logger)
+	// the historical prices are read from the database, the prices file is used as fallback.
+	sources := []prices.PriceSource{prices.NewMongoSource(db.Database)}
+	if cfg.PricesPath != "" {
+		staticSource, err := prices.NewStaticSource(cfg.PricesPath)
+		if err != nil {
+			logger.Fatal("Failed to load prices file", zap.Error(err))
+		}
+		sources = append(sources, staticSource)
+	}
+	return report.NewTransferReportJob(db.Database, cfg.PageSize, prices.NewFallbackSource(sources...), cfg.OutputPath, logger)
+}
+
+// initReconcileJob initializes reconcile job.
+func initReconcileJob(ctx context.Context, cfg *config.ReconcileConfiguration, logger *zap.Logger) *reconcile.ReconcileJob {
+	startTime, err := time.Parse(time.RFC3339, cfg.StartTime)
+	if err != nil {
+		logger.Fatal("failed to parse start time", zap.Error(err))
+	}
+	endTime := time.Now()
+	if cfg.EndTime != "" {
+		endTime, err = time.Parse(time.RFC3339, cfg.EndTime)
+		if err != nil {
+			logger.Fatal("failed to parse end time", zap.Error(err))
+		}
+	}
+	if cfg.DryRun && cfg.OutputPath == "" {
+		logger.Fatal("OUTPUT_PATH is required in dry-run mode")
+	}
+
+	//setup DB connection
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+
+	// the vaa_count check is skipped when influx is not configured.
+	var queryAPI api.QueryAPI
+	if cfg.InfluxUrl != "" {
+		influxCli := influxdb2.NewClient(cfg.InfluxUrl, cfg.InfluxToken)
+		queryAPI = influxCli.QueryAPI(cfg.InfluxOrganization)
+	}
+
+	// the missing vaas are republished only when the pipeline topic is configured.
+	var publish reconcile.PublishFunc
+	if !cfg.DryRun && cfg.SNSUrl != "" {
+		awsConfig, err := newAwsConfig(ctx, cfg)
+		if err != nil {
+			logger.Fatal("failed to create aws config", zap.Error(err))
+		}
+		producer, err := sns.NewProducer(awsConfig, cfg.SNSUrl)
+		if err != nil {
+			logger.Fatal("failed to create sns producer", zap.Error(err))
+		}
+		publish = reconcile.NewSNSPublisher(producer, logger).Publish
+	}
+
+	opts := reconcile.Options{
+		StartTime:  startTime,
+		EndTime:    endTime,
+		PageSize:   cfg.PageSize,
+		DryRun:     cfg.DryRun,
+		OutputPath: cfg.OutputPath,
+	}
+	vaaRepository := repository.NewVaaRepository(db.Database, logger)
+	return reconcile.NewReconcileJob(vaaRepository, db.Database, queryAPI, cfg.InfluxBucket, publish, opts, logger)
+}
+
+// initTvlJob initializes tvl job.
+func initTvlJob(ctx context.Context, cfg *config.TvlConfiguration, logger *zap.Logger) *tvl.TvlJob {
+	//setup DB connection
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+
+	loadTokenRegistry(ctx, db.Database, logger)
+
+	// the token prices are read from the notional cache updated by the notional job.
+	redisClient := redisv8.NewClient(&redisv8.Options{Addr: cfg.CacheURL})
+	notionalCache, err := deltaswapscanNotionalCache.NewNotionalCache(ctx, redisClient, cfg.CachePrefix, cfg.NotionalChannel, logger)
+	if err != nil {
+		logger.Fatal("Failed to create notional cache", zap.Error(err))
+	}
+	if err := notionalCache.Init(ctx); err != nil {
+		logger.Fatal("Failed to load notional cache", zap.Error(err))
+	}
+
+	// the wrapped balances are computed from the transfers only when the supply chains are not configured.
+	var supplySource tvl.SupplySource
+	if cfg.SupplyChainsPath != "" {
+		chains, err := tvl.LoadEvmChains(cfg.SupplyChainsPath)
+		if err != nil {
+			logger.Fatal("Failed to load supply chains file", zap.Error(err))
+		}
+		supplySource, err = tvl.NewEvmSupplySource(ctx, chains)
+		if err != nil {
+			logger.Fatal("Failed to create supply source", zap.Error(err))
+		}
+	}
+
+	influxCli := influxdb2.NewClient(cfg.InfluxUrl, cfg.InfluxToken)
+	writeAPI := influxCli.WriteAPIBlocking(cfg.InfluxOrganization, cfg.InfluxBucket)
+	return tvl.NewTvlJob(db.Database, notionalCache, supplySource, writeAPI, logger)
+}
+
+// initTokenRegistryJob initializes token registry job.
+func initTokenRegistryJob(ctx context.Context, cfg *config.TokenRegistryConfiguration, logger *zap.Logger) *tokenregistry.TokenRegistryJob {
+	//setup DB connection
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	tokenRepository := repository.NewTokenRepository(db.Database, logger)
+	return tokenregistry.NewTokenRegistryJob(tokenRepository, prices.NewCoingeckoSource(cfg.CoingeckoURL), cfg.BatchSize,
+		time.Duration(cfg.RequestDelay)*time.Second, logger)
+}
+
+// loadTokenRegistry sets the token registry as the token provider, so the attested tokens are supported.
+func loadTokenRegistry(ctx context.Context, db *mongo.Database, logger *zap.Logger) {
+	tokenRegistry := tokens.NewRegistry(repository.NewTokenRepository(db, logger), logger)
+	if err := tokenRegistry.Init(ctx); err != nil {
+		logger.Fatal("Failed to load token registry", zap.Error(err))
+	}
+	domain.SetTokenProvider(tokenRegistry)
+}
+
+// newAwsConfig creates a new AWS config from the given configuration.
+func newAwsConfig(ctx context.Context, cfg *config.ReconcileConfiguration) (aws.Config, error) {
+	region := cfg.AwsRegion
+
+	if cfg.AwsAccessKeyID != "" && cfg.AwsSecretAccessKey != "" {
+		credentials := credentials.NewStaticCredentialsProvider(cfg.AwsAccessKeyID, cfg.AwsSecretAccessKey, "")
+		customResolver := aws.EndpointResolverFunc(func(service, region string) (aws.Endpoint, error) {
+			if cfg.AwsEndpoint != "" {
+				return aws.Endpoint{
+					PartitionID:   "aws",
+					URL:           cfg.AwsEndpoint,
+					SigningRegion: region,
+				}, nil
+			}
+
+			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+		})
+
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx,
+			awsconfig.WithRegion(region),
+			awsconfig.WithEndpointResolver(customResolver),
+			awsconfig.WithCredentialsProvider(credentials),
+		)
+		return awsCfg, err
+	}
+
+	return awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
 }
 
 func handleExit() {
@@ -22,16 +22,61 @@ type NotionalConfiguration struct {
 	CachePrefix     string `env:"CACHE_PREFIX,required"`
 	NotionalChannel string `env:"NOTIONAL_CHANNEL,required"`
 	P2pNetwork      string `env:"P2P_NETWORK,required"`
+	// the governor config prices are used as fallback when the mongo database is configured.
+	MongoURI      string `env:"MONGODB_URI"`
+	MongoDatabase string `env:"MONGODB_DATABASE"`
 }
 
 type TransferReportConfiguration struct {
 	MongoURI      string `env:"MONGODB_URI,required"`
 	MongoDatabase string `env:"MONGODB_DATABASE,required"`
 	PageSize      int64  `env:"PAGE_SIZE,default=100"`
-	PricesPath    string `env:"PRICES_PATH,required"`
+	PricesPath    string `env:"PRICES_PATH"`
 	OutputPath    string `env:"OUTPUT_PATH,required"`
 }
 
+type ReconcileConfiguration struct {
+	MongoURI           string `env:"MONGODB_URI,required"`
+	MongoDatabase      string `env:"MONGODB_DATABASE,required"`
+	PageSize           int64  `env:"PAGE_SIZE,default=100"`
+	StartTime          string `env:"START_TIME,required"`
+	EndTime            string `env:"END_TIME"`
+	DryRun             bool   `env:"DRY_RUN,default=true"`
+	OutputPath         string `env:"OUTPUT_PATH"`
+	InfluxUrl          string `env:"INFLUX_URL"`
+	InfluxToken        string `env:"INFLUX_TOKEN"`
+	InfluxOrganization string `env:"INFLUX_ORGANIZATION"`
+	InfluxBucket       string `env:"INFLUX_BUCKET_30_DAYS"`
+	AwsEndpoint        string `env:"AWS_ENDPOINT"`
+	AwsAccessKeyID     string `env:"AWS_ACCESS_KEY_ID"`
+	AwsSecretAccessKey string `env:"AWS_SECRET_ACCESS_KEY"`
+	AwsRegion          string `env:"AWS_REGION"`
+	SNSUrl             string `env:"SNS_URL"`
+}
+
+type TvlConfiguration struct {
+	MongoURI           string `env:"MONGODB_URI,required"`
+	MongoDatabase      string `env:"MONGODB_DATABASE,required"`
+	CacheURL           string `env:"CACHE_URL,required"`
+	CachePrefix        string `env:"CACHE_PREFIX,required"`
+	NotionalChannel    string `env:"NOTIONAL_CHANNEL,required"`
+	InfluxUrl          string `env:"INFLUX_URL,required"`
+	InfluxToken        string `env:"INFLUX_TOKEN,required"`
+	InfluxOrganization string `env:"INFLUX_ORGANIZATION,required"`
+	InfluxBucket       string `env:"INFLUX_BUCKET_30_DAYS,required"`
+	// the wrapped balances are read from the token bridge contracts of the chains in this file when it is configured.
+	SupplyChainsPath string `env:"SUPPLY_CHAINS_PATH"`
+}
+
+type TokenRegistryConfiguration struct {
+	MongoURI      string `env:"MONGODB_URI,required"`
+	MongoDatabase string `env:"MONGODB_DATABASE,required"`
+	CoingeckoURL  string `env:"COINGECKO_URL,required"`
+	BatchSize     int64  `env:"BATCH_SIZE,default=50"`
+	// the delay between coingecko requests, in seconds, to stay under the rate limit.
+	RequestDelay int64 `env:"REQUEST_DELAY,default=6"`
+}
+
 // New creates a default configuration with the values from .env file and environment variables.
 func New(ctx context.Context) (*Configuration, error) {
 	_ = godotenv.Load(".env", "../.env")
@@ -67,3 +112,39 @@ func NewTransferReportConfiguration(ctx context.Context) (*TransferReportConfigu
 
 	return &configuration, nil
 }
+
+// New creates a reconcile configuration with the values from .env file and environment variables.
+func NewReconcileConfiguration(ctx context.Context) (*ReconcileConfiguration, error) {
+	_ = godotenv.Load(".env", "../.env")
+
+	var configuration ReconcileConfiguration
+	if err := envconfig.Process(ctx, &configuration); err != nil {
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+// New creates a tvl configuration with the values from .env file and environment variables.
+func NewTvlConfiguration(ctx context.Context) (*TvlConfiguration, error) {
+	_ = godotenv.Load(".env", "../.env")
+
+	var configuration TvlConfiguration
+	if err := envconfig.Process(ctx, &configuration); err != nil {
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+// New creates a token registry configuration with the values from .env file and environment variables.
+func NewTokenRegistryConfiguration(ctx context.Context) (*TokenRegistryConfiguration, error) {
+	_ = godotenv.Load(".env", "../.env")
+
+	var configuration TokenRegistryConfiguration
+	if err := envconfig.Process(ctx, &configuration); err != nil {
+		return nil, err
+	}
+
+	return &configuration, nil
+}
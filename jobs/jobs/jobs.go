@@ -5,6 +5,9 @@ package jobs
 const (
 	JobIDNotional       = "JOB_NOTIONAL_USD"
 	JobIDTransferReport = "JOB_TRANSFER_REPORT"
+	JobIDReconcile      = "JOB_RECONCILE"
+	JobIDTvl            = "JOB_TVL"
+	JobIDTokenRegistry  = "JOB_TOKEN_REGISTRY"
 )
 
 // Job is the interface for jobs.
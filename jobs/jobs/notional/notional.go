@@ -2,19 +2,21 @@
 package notional
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/deltaswapio/deltaswap-explorer/common/client/cache/notional"
 	"github.com/deltaswapio/deltaswap-explorer/common/domain"
-	"github.com/deltaswapio/deltaswap-explorer/jobs/internal/coingecko"
+	"github.com/deltaswapio/deltaswap-explorer/common/prices"
 	"github.com/go-redis/redis"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
 // NotionalJob is the job to get the notional value of assets.
 type NotionalJob struct {
-	coingeckoAPI *coingecko.CoingeckoAPI
+	priceSource  prices.PriceSource
 	cacheClient  *redis.Client
 	cachePrefix  string
 	cacheChannel string
@@ -23,9 +25,9 @@ type NotionalJob struct {
 }
 
 // NewNotionalJob creates a new notional job.
-func NewNotionalJob(api *coingecko.CoingeckoAPI, cacheClient *redis.Client, cachePrefix string, p2pNetwork, cacheChannel string, logger *zap.Logger) *NotionalJob {
+func NewNotionalJob(priceSource prices.PriceSource, cacheClient *redis.Client, cachePrefix string, p2pNetwork, cacheChannel string, logger *zap.Logger) *NotionalJob {
 	return &NotionalJob{
-		coingeckoAPI: api,
+		priceSource:  priceSource,
 		cacheClient:  cacheClient,
 		cachePrefix:  cachePrefix,
 		cacheChannel: formatChannel(cachePrefix, cacheChannel),
@@ -35,16 +37,16 @@ func NewNotionalJob(api *coingecko.CoingeckoAPI, cacheClient *redis.Client, cach
 }
 
 // Run runs the notional job.
-func (j *NotionalJob) Run() error {
+func (j *NotionalJob) Run(ctx context.Context) error {
 
 	// get chains coingecko ids by p2p network.
-	chainIDs := coingecko.GetChainIDs(j.p2pNetwork)
+	chainIDs := getChainIDs(j.p2pNetwork)
 	if len(chainIDs) == 0 {
 		return fmt.Errorf("no chain ids found for p2p network %s", j.p2pNetwork)
 	}
 
 	// get notional value of assets.
-	coingeckoNotionals, err := j.coingeckoAPI.GetNotionalUSD(chainIDs)
+	coingeckoNotionals, err := j.priceSource.GetPrices(ctx, chainIDs)
 	if err != nil {
 		j.logger.Error("failed to get notional value of assets",
 			zap.Error(err))
@@ -93,29 +95,40 @@ func (j *NotionalJob) updateNotionalCache(notionals map[string]notional.PriceDat
 // convertToSymbols converts the coingecko response into a symbol map
 //
 // The returned map has symbols as keys, and price data as the values.
-func (j *NotionalJob) convertToSymbols(m map[string]coingecko.NotionalUSD) map[string]notional.PriceData {
+func (j *NotionalJob) convertToSymbols(m map[string]decimal.Decimal) map[string]notional.PriceData {
 
 	w := make(map[string]notional.PriceData, len(m))
 	now := time.Now()
 
 	for _, v := range domain.GetAllTokens() {
+		// the attested tokens are priced once the token registry job resolves their coingecko ID.
+		if v.CoingeckoID == "" {
+			continue
+		}
+		// the price sources don't return the tokens without price.
 		notionalUSD, ok := m[v.CoingeckoID]
 		if !ok {
 			j.logger.Info("skipping unknown coingecko ID", zap.String("coingeckoID", v.CoingeckoID))
 			continue
 		}
-		// Do not update the dictionary when the token price is nil
-		if notionalUSD.Price == nil {
-			j.logger.Info("skipping nil price", zap.String("coingeckoID", v.CoingeckoID))
-			continue
-		}
 		// Set price data for the current token
-		w[v.GetTokenID()] = notional.PriceData{NotionalUsd: *notionalUSD.Price, UpdatedAt: now}
+		w[v.GetTokenID()] = notional.PriceData{NotionalUsd: notionalUSD, UpdatedAt: now}
 	}
 
 	return w
 }
 
+// getChainIDs returns the coingecko ids for the given p2p network.
+func getChainIDs(p2pNetwork string) []string {
+
+	if p2pNetwork == domain.P2pMainNet {
+		return domain.GetAllCoingeckoIDs()
+	}
+
+	// TODO: define chains ids for testnet.
+	return []string{}
+}
+
 func (j *NotionalJob) renderKey(key string) string {
 	if j.cachePrefix != "" {
 		return fmt.Sprintf("%s:%s", j.cachePrefix, key)
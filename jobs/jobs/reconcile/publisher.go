@@ -0,0 +1,72 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/client/sns"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// VaaEvent is the message published by the pipeline to the VAA topic.
+type VaaEvent struct {
+	ID             string     `json:"id"`
+	ChainID        uint16     `json:"emitterChain"`
+	EmitterAddress string     `json:"emitterAddr"`
+	Sequence       string     `json:"sequence"`
+	PhylaxSetIndex uint32     `json:"phylaxSetIndex"`
+	Vaa            []byte     `json:"vaas"`
+	IndexedAt      time.Time  `json:"indexedAt"`
+	Timestamp      *time.Time `json:"timestamp"`
+	UpdatedAt      *time.Time `json:"updatedAt"`
+	TxHash         string     `json:"txHash"`
+	Version        uint16     `json:"version"`
+	Revision       uint16     `json:"revision"`
+}
+
+// PublishFunc is a function to republish a VAA.
+type PublishFunc func(context.Context, *VaaEvent) error
+
+// SNSPublisher publishes VAAs to the pipeline SNS topic.
+type SNSPublisher struct {
+	producer *sns.Producer
+	logger   *zap.Logger
+}
+
+// NewSNSPublisher creates a new SNS publisher.
+func NewSNSPublisher(producer *sns.Producer, logger *zap.Logger) *SNSPublisher {
+	return &SNSPublisher{producer: producer, logger: logger}
+}
+
+// Publish sends the VAA to the SNS topic with the same group and deduplication ids used by the pipeline.
+func (p *SNSPublisher) Publish(ctx context.Context, e *VaaEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	groupID := fmt.Sprintf("%d/%s", e.ChainID, e.EmitterAddress)
+	p.logger.Debug("Republishing vaa", zap.String("groupID", groupID), zap.String("id", e.ID))
+	return p.producer.SendMessage(ctx, groupID, e.ID, string(body))
+}
+
+func toVaaEvent(doc *repository.VaaDoc, v *sdk.VAA) *VaaEvent {
+	e := VaaEvent{
+		ID:             doc.ID,
+		ChainID:        uint16(v.EmitterChain),
+		EmitterAddress: v.EmitterAddress.String(),
+		Sequence:       fmt.Sprintf("%d", v.Sequence),
+		PhylaxSetIndex: v.PhylaxSetIndex,
+		Vaa:            doc.Vaa,
+		Timestamp:      &v.Timestamp,
+		TxHash:         doc.TxHash,
+		Version:        uint16(v.Version),
+	}
+	if doc.IndexedAt != nil {
+		e.IndexedAt = *doc.IndexedAt
+	}
+	return &e
+}
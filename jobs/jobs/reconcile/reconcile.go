@@ -0,0 +1,347 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// vaaCountRetention is the retention of the 30-day bucket where the analytics service writes the vaa_count points.
+// The VAAs older than the retention are not checked, their points were already deleted.
+const vaaCountRetention = 30 * 24 * time.Hour
+
+// ReconcileJob finds the VAAs that are missing from the downstream collections and measurements.
+type ReconcileJob struct {
+	vaaRepository *repository.VaaRepository
+	database      *mongo.Database
+	influxQuery   api.QueryAPI
+	influxBucket  string
+	publish       PublishFunc
+	startTime     time.Time
+	endTime       time.Time
+	pageSize      int64
+	dryRun        bool
+	outputPath    string
+	logger        *zap.Logger
+}
+
+// Options contains the settings of the reconcile job.
+type Options struct {
+	StartTime  time.Time
+	EndTime    time.Time
+	PageSize   int64
+	DryRun     bool
+	OutputPath string
+}
+
+// chainGaps contains the gaps found for a chain.
+type chainGaps struct {
+	total                    int
+	missingParsedVaa         int
+	missingGlobalTransaction int
+	missingVaaCount          int
+	republished              int
+}
+
+// gap represents a VAA missing in at least one downstream collection or measurement.
+type gap struct {
+	vaa                      *repository.VaaDoc
+	parsed                   *sdk.VAA
+	missingParsedVaa         bool
+	missingGlobalTransaction bool
+	missingVaaCount          bool
+}
+
+// NewReconcileJob creates a new reconcile job.
+// influxQuery can be nil to skip the vaa_count check, and publish can be nil to skip the republication of the missing VAAs.
+func NewReconcileJob(vaaRepository *repository.VaaRepository, database *mongo.Database, influxQuery api.QueryAPI, influxBucket string,
+	publish PublishFunc, opts Options, logger *zap.Logger) *ReconcileJob {
+	return &ReconcileJob{
+		vaaRepository: vaaRepository,
+		database:      database,
+		influxQuery:   influxQuery,
+		influxBucket:  influxBucket,
+		publish:       publish,
+		startTime:     opts.StartTime,
+		endTime:       opts.EndTime,
+		pageSize:      opts.PageSize,
+		dryRun:        opts.DryRun,
+		outputPath:    opts.OutputPath,
+		logger:        logger,
+	}
+}
+
+// Run runs the reconcile job.
+func (j *ReconcileJob) Run(ctx context.Context) error {
+
+	var writer *csv.Writer
+	if j.dryRun {
+		file, err := os.Create(j.outputPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = csv.NewWriter(file)
+		defer writer.Flush()
+		if err := writeHeader(writer); err != nil {
+			return err
+		}
+	}
+
+	gapsByChain := make(map[sdk.ChainID]*chainGaps)
+	page := int64(0)
+	for {
+		j.logger.Info("Processing page", zap.Int64("page", page))
+
+		vaas, err := j.vaaRepository.FindPageByTimeRange(ctx, j.startTime, j.endTime, page, j.pageSize, true)
+		if err != nil {
+			j.logger.Error("Failed to get vaas", zap.Error(err))
+			return err
+		}
+
+		if len(vaas) == 0 {
+			j.logger.Info("Empty page", zap.Int64("page", page))
+			break
+		}
+
+		gaps, err := j.findGaps(ctx, vaas)
+		if err != nil {
+			return err
+		}
+
+		for _, g := range gaps {
+			cg, ok := gapsByChain[g.parsed.EmitterChain]
+			if !ok {
+				cg = &chainGaps{}
+				gapsByChain[g.parsed.EmitterChain] = cg
+			}
+			cg.total++
+			if !g.missingParsedVaa && !g.missingGlobalTransaction && !g.missingVaaCount {
+				continue
+			}
+			if g.missingParsedVaa {
+				cg.missingParsedVaa++
+			}
+			if g.missingGlobalTransaction {
+				cg.missingGlobalTransaction++
+			}
+			if g.missingVaaCount {
+				cg.missingVaaCount++
+			}
+
+			if j.dryRun {
+				if err := writeRecord(writer, g); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if j.publish != nil {
+				if err := j.publish(ctx, toVaaEvent(g.vaa, g.parsed)); err != nil {
+					j.logger.Error("Failed to republish vaa", zap.String("id", g.vaa.ID), zap.Error(err))
+					continue
+				}
+				cg.republished++
+			}
+		}
+		if writer != nil {
+			writer.Flush()
+		}
+		page++
+	}
+
+	j.report(gapsByChain)
+	return nil
+}
+
+// findGaps checks which VAAs of the page are missing in the downstream collections and measurements.
+func (j *ReconcileJob) findGaps(ctx context.Context, vaas []*repository.VaaDoc) ([]*gap, error) {
+
+	ids := make([]string, 0, len(vaas))
+	gaps := make([]*gap, 0, len(vaas))
+	for _, v := range vaas {
+		parsed, err := sdk.Unmarshal(v.Vaa)
+		if err != nil {
+			j.logger.Error("Failed to unmarshal vaa", zap.String("id", v.ID), zap.Error(err))
+			continue
+		}
+		ids = append(ids, v.ID)
+		gaps = append(gaps, &gap{vaa: v, parsed: parsed})
+	}
+
+	parsedVaas, err := j.findExistingIDs(ctx, "parsedVaa", bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	globalTransactions, err := j.findExistingIDs(ctx, "globalTransactions",
+		bson.M{"_id": bson.M{"$in": ids}, "originTx": bson.M{"$exists": true}})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	vaaCounts, err := j.findVaaCountPoints(ctx, gaps, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range gaps {
+		g.missingParsedVaa = !parsedVaas[g.vaa.ID]
+		g.missingGlobalTransaction = !globalTransactions[g.vaa.ID]
+		if vaaCounts != nil && hasVaaCountPoint(g.parsed, now) {
+			g.missingVaaCount = !vaaCounts[vaaCountKey(g.parsed.EmitterChain, vaaCountTime(g.parsed))]
+		}
+	}
+	return gaps, nil
+}
+
+// findExistingIDs returns the ids of the documents of the collection that match the filter.
+func (j *ReconcileJob) findExistingIDs(ctx context.Context, collection string, filter bson.M) (map[string]bool, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cur, err := j.database.Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		j.logger.Error("Failed to find documents", zap.String("collection", collection), zap.Error(err))
+		return nil, err
+	}
+
+	var docs []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		ids[d.ID] = true
+	}
+	return ids, nil
+}
+
+// queryTemplateVaaCount is the query used to get the vaa_count points in a time range.
+const queryTemplateVaaCount = `
+from(bucket: "%s")
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r["_measurement"] == "vaa_count")
+  |> keep(columns: ["_time", "chain_id"])
+`
+
+// findVaaCountPoints returns the vaa_count points written for the time range of the VAAs still in the bucket.
+// It returns nil when the vaa_count check is disabled.
+func (j *ReconcileJob) findVaaCountPoints(ctx context.Context, gaps []*gap, now time.Time) (map[string]bool, error) {
+	if j.influxQuery == nil {
+		return nil, nil
+	}
+
+	var start, stop time.Time
+	for _, g := range gaps {
+		if !hasVaaCountPoint(g.parsed, now) {
+			continue
+		}
+		t := vaaCountTime(g.parsed)
+		if start.IsZero() || t.Before(start) {
+			start = t
+		}
+		if stop.IsZero() || t.After(stop) {
+			stop = t
+		}
+	}
+	if start.IsZero() {
+		return map[string]bool{}, nil
+	}
+
+	query := fmt.Sprintf(queryTemplateVaaCount, j.influxBucket,
+		start.UTC().Format(time.RFC3339Nano), stop.Add(time.Nanosecond).UTC().Format(time.RFC3339Nano))
+	result, err := j.influxQuery.Query(ctx, query)
+	if err != nil {
+		j.logger.Error("Failed to query vaa_count points", zap.Error(err))
+		return nil, err
+	}
+	defer result.Close()
+
+	points := make(map[string]bool)
+	for result.Next() {
+		chainID, err := strconv.Atoi(fmt.Sprintf("%v", result.Record().ValueByKey("chain_id")))
+		if err != nil {
+			continue
+		}
+		points[vaaCountKey(sdk.ChainID(chainID), result.Record().Time())] = true
+	}
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+	return points, nil
+}
+
+// hasVaaCountPoint returns true if the analytics service writes a vaa_count point for the VAA
+// and the point is still in the bucket.
+func hasVaaCountPoint(v *sdk.VAA, now time.Time) bool {
+	if v.EmitterChain == sdk.ChainIDPythNet {
+		return false
+	}
+	return vaaCountTime(v).After(now.Add(-vaaCountRetention))
+}
+
+// vaaCountTime returns the time of the vaa_count point of a VAA.
+// It must match the unique timestamp generated by the analytics service.
+func vaaCountTime(v *sdk.VAA) time.Time {
+	offset := time.Duration(v.Sequence % 1_000_000)
+	return v.Timestamp.Add(time.Nanosecond * offset)
+}
+
+func vaaCountKey(chainID sdk.ChainID, t time.Time) string {
+	return fmt.Sprintf("%d/%d", chainID, t.UnixNano())
+}
+
+// report logs the gaps found by chain.
+func (j *ReconcileJob) report(gapsByChain map[sdk.ChainID]*chainGaps) {
+	chainIDs := make([]sdk.ChainID, 0, len(gapsByChain))
+	for chainID := range gapsByChain {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, k int) bool { return chainIDs[i] < chainIDs[k] })
+
+	for _, chainID := range chainIDs {
+		cg := gapsByChain[chainID]
+		j.logger.Info("Reconciliation result",
+			zap.String("chain", chainID.String()),
+			zap.Int("total", cg.total),
+			zap.Int("missingParsedVaa", cg.missingParsedVaa),
+			zap.Int("missingGlobalTransaction", cg.missingGlobalTransaction),
+			zap.Int("missingVaaCount", cg.missingVaaCount),
+			zap.Int("republished", cg.republished))
+	}
+}
+
+func writeHeader(writer *csv.Writer) error {
+	var record []string
+	record = append(record, "vaaId")
+	record = append(record, "chainId")
+	record = append(record, "timestamp")
+	record = append(record, "missingParsedVaa")
+	record = append(record, "missingGlobalTransaction")
+	record = append(record, "missingVaaCount")
+	return writer.Write(record)
+}
+
+func writeRecord(writer *csv.Writer, g *gap) error {
+	var record []string
+	record = append(record, g.vaa.ID)
+	record = append(record, fmt.Sprintf("%d", g.parsed.EmitterChain))
+	record = append(record, g.parsed.Timestamp.UTC().Format(time.RFC3339))
+	record = append(record, strconv.FormatBool(g.missingParsedVaa))
+	record = append(record, strconv.FormatBool(g.missingGlobalTransaction))
+	record = append(record, strconv.FormatBool(g.missingVaaCount))
+	return writer.Write(record)
+}
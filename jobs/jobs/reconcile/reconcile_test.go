@@ -0,0 +1,91 @@
+package reconcile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testVaa() *sdk.VAA {
+	return &sdk.VAA{
+		Version:        1,
+		PhylaxSetIndex: 3,
+		Timestamp:      time.Date(2023, 5, 10, 12, 30, 0, 0, time.UTC),
+		EmitterChain:   sdk.ChainIDEthereum,
+		EmitterAddress: sdk.Address{0x01},
+		Sequence:       2_000_042,
+	}
+}
+
+func TestVaaCountTime(t *testing.T) {
+	v := testVaa()
+	ts := vaaCountTime(v)
+	assert.Equal(t, v.Timestamp.Add(42*time.Nanosecond), ts)
+	assert.Equal(t, "2/1683721800000000042", vaaCountKey(v.EmitterChain, ts))
+}
+
+func TestHasVaaCountPoint(t *testing.T) {
+	v := testVaa()
+	assert.True(t, hasVaaCountPoint(v, v.Timestamp.Add(24*time.Hour)))
+
+	// the points older than the retention of the bucket were deleted.
+	assert.False(t, hasVaaCountPoint(v, v.Timestamp.Add(vaaCountRetention+time.Hour)))
+
+	// the analytics service doesn't write vaa_count points for pythnet.
+	v.EmitterChain = sdk.ChainIDPythNet
+	assert.False(t, hasVaaCountPoint(v, v.Timestamp.Add(24*time.Hour)))
+}
+
+func TestWriteRecord(t *testing.T) {
+	v := testVaa()
+	g := &gap{
+		vaa:              &repository.VaaDoc{ID: "2/0100000000000000000000000000000000000000000000000000000000000000/2000042"},
+		parsed:           v,
+		missingParsedVaa: true,
+		missingVaaCount:  true,
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	require.NoError(t, writeHeader(writer))
+	require.NoError(t, writeRecord(writer, g))
+	writer.Flush()
+	require.NoError(t, writer.Error())
+
+	expected := "vaaId,chainId,timestamp,missingParsedVaa,missingGlobalTransaction,missingVaaCount\n" +
+		"2/0100000000000000000000000000000000000000000000000000000000000000/2000042,2,2023-05-10T12:30:00Z,true,false,true\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestToVaaEvent(t *testing.T) {
+	v := testVaa()
+	indexedAt := time.Date(2023, 5, 10, 12, 31, 0, 0, time.UTC)
+	doc := &repository.VaaDoc{
+		ID:        "2/0100000000000000000000000000000000000000000000000000000000000000/2000042",
+		Vaa:       []byte{0x01, 0x02},
+		TxHash:    "0xabc",
+		IndexedAt: &indexedAt,
+	}
+
+	e := toVaaEvent(doc, v)
+	assert.Equal(t, doc.ID, e.ID)
+	assert.Equal(t, uint16(sdk.ChainIDEthereum), e.ChainID)
+	assert.Equal(t, v.EmitterAddress.String(), e.EmitterAddress)
+	assert.Equal(t, "2000042", e.Sequence)
+	assert.Equal(t, uint32(3), e.PhylaxSetIndex)
+	assert.Equal(t, doc.Vaa, e.Vaa)
+	assert.Equal(t, indexedAt, e.IndexedAt)
+	assert.Equal(t, v.Timestamp, *e.Timestamp)
+	assert.Equal(t, "0xabc", e.TxHash)
+	assert.Equal(t, uint16(1), e.Version)
+
+	// the VAAs indexed before the indexedAt field was added don't have it.
+	doc.IndexedAt = nil
+	assert.True(t, toVaaEvent(doc, v).IndexedAt.IsZero())
+}
@@ -22,7 +22,7 @@ type TransferReportJob struct {
 	database    *mongo.Database
 	pageSize    int64
 	logger      *zap.Logger
-	pricesCache *prices.CoinPricesCache
+	priceSource prices.PriceSource
 	outputPath  string
 }
 
@@ -44,8 +44,8 @@ type transactionResult struct {
 }
 
 // NewTransferReportJob creates a new transfer report job.
-func NewTransferReportJob(database *mongo.Database, pageSize int64, pricesCache *prices.CoinPricesCache, outputPath string, logger *zap.Logger) *TransferReportJob {
-	return &TransferReportJob{database: database, pageSize: pageSize, pricesCache: pricesCache, outputPath: outputPath, logger: logger}
+func NewTransferReportJob(database *mongo.Database, pageSize int64, priceSource prices.PriceSource, outputPath string, logger *zap.Logger) *TransferReportJob {
+	return &TransferReportJob{database: database, pageSize: pageSize, priceSource: priceSource, outputPath: outputPath, logger: logger}
 }
 
 // Run runs the transfer report job.
@@ -96,7 +96,7 @@ func (j *TransferReportJob) Run(ctx context.Context) error {
 
 			m, ok := domain.GetTokenByAddress(sdk.ChainID(t.TokenChain), tokenAddress.String())
 			if ok {
-				tokenPrice, err := j.pricesCache.GetPriceByTime(m.CoingeckoID, t.Timestamp)
+				tokenPrice, err := j.priceSource.GetPriceByTime(ctx, m.CoingeckoID, t.Timestamp)
 				if err != nil {
 					continue
 				}
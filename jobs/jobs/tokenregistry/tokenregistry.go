@@ -0,0 +1,97 @@
+// Package tokenregistry seeds the token registry and resolves the coingecko IDs of the attested tokens.
+package tokenregistry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/common/prices"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"go.uber.org/zap"
+)
+
+// recheckInterval is the time to wait before looking up again a token without coingecko ID,
+// coingecko lists the new tokens some time after they are created.
+const recheckInterval = 24 * time.Hour
+
+// TokenRegistryJob seeds the token registry with the generated token list and resolves
+// the coingecko IDs of the tokens recorded from the token bridge attestations.
+type TokenRegistryJob struct {
+	repository   *repository.TokenRepository
+	coingecko    *prices.CoingeckoSource
+	batchSize    int64
+	requestDelay time.Duration
+	logger       *zap.Logger
+}
+
+// NewTokenRegistryJob creates a new token registry job.
+// Up to batchSize tokens are looked up on each run, waiting requestDelay between the coingecko requests.
+func NewTokenRegistryJob(repository *repository.TokenRepository, coingecko *prices.CoingeckoSource, batchSize int64,
+	requestDelay time.Duration, logger *zap.Logger) *TokenRegistryJob {
+	return &TokenRegistryJob{
+		repository:   repository,
+		coingecko:    coingecko,
+		batchSize:    batchSize,
+		requestDelay: requestDelay,
+		logger:       logger,
+	}
+}
+
+// Run runs the token registry job.
+func (j *TokenRegistryJob) Run(ctx context.Context) error {
+
+	if err := j.repository.Seed(ctx, domain.SeedTokens()); err != nil {
+		j.logger.Error("Failed to seed token registry", zap.Error(err))
+		return err
+	}
+
+	tokens, err := j.repository.FindWithoutCoingeckoID(ctx, time.Now().Add(-recheckInterval), j.batchSize)
+	if err != nil {
+		j.logger.Error("Failed to get tokens without coingecko id", zap.Error(err))
+		return err
+	}
+
+	var resolved int
+	for i, t := range tokens {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(j.requestDelay):
+			}
+		}
+
+		coingeckoID, err := j.resolveCoingeckoID(ctx, t)
+		if err != nil {
+			// the token is looked up again on the next run.
+			j.logger.Warn("Failed to resolve coingecko id", zap.String("id", t.ID), zap.Error(err))
+			continue
+		}
+		if err := j.repository.UpdateCoingeckoID(ctx, t.ID, coingeckoID); err != nil {
+			return err
+		}
+		if coingeckoID != "" {
+			resolved++
+			j.logger.Info("Coingecko id resolved", zap.String("id", t.ID), zap.String("coingeckoID", coingeckoID))
+		}
+	}
+
+	j.logger.Info("Token registry updated", zap.Int("tokens", len(tokens)), zap.Int("resolved", resolved))
+	return nil
+}
+
+// resolveCoingeckoID returns the coingecko ID of a token, or an empty string if coingecko doesn't list the token.
+func (j *TokenRegistryJob) resolveCoingeckoID(ctx context.Context, t *repository.TokenDoc) (string, error) {
+	nativeAddress, err := domain.TranslateEmitterAddress(t.TokenChain, t.TokenAddress)
+	if err != nil {
+		j.logger.Debug("Token address cannot be translated to the native format", zap.String("id", t.ID), zap.Error(err))
+		return "", nil
+	}
+	coingeckoID, err := j.coingecko.GetCoingeckoIDByContract(ctx, t.TokenChain, nativeAddress)
+	if errors.Is(err, prices.ErrCoinNotFound) {
+		return "", nil
+	}
+	return coingeckoID, err
+}
@@ -0,0 +1,136 @@
+package tvl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrWrappedAssetNotFound is returned when a token is not attested on a chain.
+var ErrWrappedAssetNotFound = errors.New("wrapped asset not found")
+
+var (
+	wrappedAssetSelector = crypto.Keccak256([]byte("wrappedAsset(uint16,bytes32)"))[:4]
+	totalSupplySelector  = crypto.Keccak256([]byte("totalSupply()"))[:4]
+)
+
+// SupplySource returns the supply of the wrapped assets minted by the token bridge.
+type SupplySource interface {
+	// Chains returns the chains supported by the source.
+	Chains() []sdk.ChainID
+	// GetWrappedSupply returns the supply of the token wrapped on the chain, normalized to 8 decimals.
+	GetWrappedSupply(ctx context.Context, chainID sdk.ChainID, token *domain.TokenMetadata) (*big.Int, error)
+}
+
+// EvmChain is the configuration of the token bridge of an EVM chain.
+type EvmChain struct {
+	ChainID     sdk.ChainID `json:"chainId"`
+	RpcURL      string      `json:"rpcUrl"`
+	TokenBridge string      `json:"tokenBridge"`
+}
+
+// LoadEvmChains reads the EVM chains configuration from a json file.
+func LoadEvmChains(path string) ([]EvmChain, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var chains []EvmChain
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("failed to decode supply chains file: %w", err)
+	}
+	return chains, nil
+}
+
+type evmClient struct {
+	client      *ethclient.Client
+	tokenBridge common.Address
+}
+
+// EvmSupplySource reads the wrapped asset supply from the token bridge contracts of EVM chains.
+type EvmSupplySource struct {
+	clients map[sdk.ChainID]*evmClient
+}
+
+// NewEvmSupplySource creates a new EvmSupplySource.
+func NewEvmSupplySource(ctx context.Context, chains []EvmChain) (*EvmSupplySource, error) {
+	clients := make(map[sdk.ChainID]*evmClient, len(chains))
+	for _, c := range chains {
+		if !common.IsHexAddress(c.TokenBridge) {
+			return nil, fmt.Errorf("invalid token bridge address %s for chain %d", c.TokenBridge, c.ChainID)
+		}
+		client, err := ethclient.DialContext(ctx, c.RpcURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to chain %d: %w", c.ChainID, err)
+		}
+		clients[c.ChainID] = &evmClient{client: client, tokenBridge: common.HexToAddress(c.TokenBridge)}
+	}
+	return &EvmSupplySource{clients: clients}, nil
+}
+
+// Chains returns the chains supported by the source.
+func (s *EvmSupplySource) Chains() []sdk.ChainID {
+	chains := make([]sdk.ChainID, 0, len(s.clients))
+	for chainID := range s.clients {
+		chains = append(chains, chainID)
+	}
+	return chains
+}
+
+// GetWrappedSupply returns the total supply of the wrapped token, normalized to 8 decimals.
+func (s *EvmSupplySource) GetWrappedSupply(ctx context.Context, chainID sdk.ChainID, token *domain.TokenMetadata) (*big.Int, error) {
+	c, ok := s.clients[chainID]
+	if !ok {
+		return nil, fmt.Errorf("chain %d is not supported", chainID)
+	}
+	tokenAddress, err := sdk.StringToAddress(token.TokenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// wrappedAsset(uint16 tokenChainId, bytes32 tokenAddress)
+	data := make([]byte, 0, 4+32+32)
+	data = append(data, wrappedAssetSelector...)
+	data = append(data, common.LeftPadBytes(big.NewInt(int64(token.TokenChain)).Bytes(), 32)...)
+	data = append(data, tokenAddress.Bytes()...)
+	out, err := c.client.CallContract(ctx, ethereum.CallMsg{To: &c.tokenBridge, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) < 32 {
+		return nil, fmt.Errorf("invalid wrappedAsset response for chain %d", chainID)
+	}
+	wrapped := common.BytesToAddress(out[12:32])
+	if wrapped == (common.Address{}) {
+		return nil, ErrWrappedAssetNotFound
+	}
+
+	out, err = c.client.CallContract(ctx, ethereum.CallMsg{To: &wrapped, Data: totalSupplySelector}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) < 32 {
+		return nil, fmt.Errorf("invalid totalSupply response for chain %d", chainID)
+	}
+	supply := new(big.Int).SetBytes(out[:32])
+
+	// the wrapped assets have the decimals of the original token, up to 8.
+	return normalizeAmount(supply, token.Decimals), nil
+}
+
+// Close closes the clients.
+func (s *EvmSupplySource) Close() {
+	for _, c := range s.clients {
+		c.client.Close()
+	}
+}
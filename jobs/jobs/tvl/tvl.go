@@ -0,0 +1,273 @@
+// Package tvl computes the total value locked in the token bridge.
+package tvl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/client/cache/notional"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// measurement is the influx measurement of the tvl points.
+const measurement = "tvl"
+
+// TvlJob computes the value locked in the token bridge for each token.
+//
+// The tokens are locked in the token bridge of their native chain while they are wrapped on other chains,
+// so the locked amount of a token is the sum of its wrapped balances. The wrapped balance on a chain is
+// the net inflow of token bridge transfers to the chain, or the wrapped asset supply when a supply source
+// is configured for the chain.
+type TvlJob struct {
+	database      *mongo.Database
+	notionalCache notional.NotionalLocalCacheReadable
+	supplySource  SupplySource
+	writeAPI      api.WriteAPIBlocking
+	logger        *zap.Logger
+}
+
+// TokenTvl is the value locked for a token.
+type TokenTvl struct {
+	Token  *domain.TokenMetadata
+	Amount decimal.Decimal
+	Price  decimal.Decimal
+	TvlUSD decimal.Decimal
+}
+
+// flowResult is the amount transferred between two chains for a token.
+type flowResult struct {
+	ID struct {
+		TokenChain   sdk.ChainID `bson:"tokenChain"`
+		TokenAddress string      `bson:"tokenAddress"`
+		FromChain    sdk.ChainID `bson:"fromChain"`
+		ToChain      sdk.ChainID `bson:"toChain"`
+	} `bson:"_id"`
+	Amount primitive.Decimal128 `bson:"amount"`
+}
+
+// NewTvlJob creates a new tvl job.
+// supplySource can be nil to compute the wrapped balances from the transfers only.
+func NewTvlJob(database *mongo.Database, notionalCache notional.NotionalLocalCacheReadable, supplySource SupplySource,
+	writeAPI api.WriteAPIBlocking, logger *zap.Logger) *TvlJob {
+	return &TvlJob{
+		database:      database,
+		notionalCache: notionalCache,
+		supplySource:  supplySource,
+		writeAPI:      writeAPI,
+		logger:        logger,
+	}
+}
+
+// Run runs the tvl job.
+func (j *TvlJob) Run(ctx context.Context) error {
+
+	balances, err := j.getWrappedBalances(ctx)
+	if err != nil {
+		j.logger.Error("Failed to get wrapped balances", zap.Error(err))
+		return err
+	}
+
+	if j.supplySource != nil {
+		j.applySupplySnapshots(ctx, balances)
+	}
+
+	tvls := j.priceTokens(balances)
+
+	now := time.Now()
+	points := make([]*write.Point, 0, len(tvls))
+	var total decimal.Decimal
+	for _, t := range tvls {
+		amount, _ := t.Amount.Float64()
+		price, _ := t.Price.Float64()
+		tvlUSD, _ := t.TvlUSD.Float64()
+		p := influxdb2.NewPointWithMeasurement(measurement).
+			// Original mint chain, the tokens are locked in its token bridge
+			AddTag("token_chain", fmt.Sprintf("%d", t.Token.TokenChain)).
+			// Original mint address
+			AddTag("token_address", t.Token.TokenAddress).
+			AddTag("symbol", t.Token.Symbol.String()).
+			AddField("amount", amount).
+			AddField("price", price).
+			AddField("tvl_usd", tvlUSD).
+			SetTime(now)
+		points = append(points, p)
+		total = total.Add(t.TvlUSD)
+	}
+
+	if len(points) == 0 {
+		j.logger.Warn("No tokens with value locked")
+		return nil
+	}
+	if err := j.writeAPI.WritePoint(ctx, points...); err != nil {
+		j.logger.Error("Failed to write tvl points", zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Tvl updated", zap.Int("tokens", len(points)), zap.String("tvlUsd", total.StringFixed(2)))
+	return nil
+}
+
+// getWrappedBalances returns the wrapped balance of each token on each chain, normalized to 8 decimals,
+// computed from the token bridge transfers.
+func (j *TvlJob) getWrappedBalances(ctx context.Context) (map[*domain.TokenMetadata]map[sdk.ChainID]*big.Int, error) {
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "standardizedProperties.appIds", Value: domain.AppIdPortalTokenBridge},
+			{Key: "standardizedProperties.amount", Value: bson.D{{Key: "$nin", Value: bson.A{"", nil}}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "tokenChain", Value: "$standardizedProperties.tokenChain"},
+				{Key: "tokenAddress", Value: "$standardizedProperties.tokenAddress"},
+				{Key: "fromChain", Value: "$standardizedProperties.fromChain"},
+				{Key: "toChain", Value: "$standardizedProperties.toChain"},
+			}},
+			{Key: "amount", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$toDecimal", Value: "$standardizedProperties.amount"}}}}},
+		}}},
+	}
+
+	cur, err := j.database.Collection("parsedVaa").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var flows []flowResult
+	if err := cur.All(ctx, &flows); err != nil {
+		return nil, err
+	}
+	return j.sumFlows(flows), nil
+}
+
+// sumFlows computes the wrapped balance of each token on each chain from the amounts transferred between chains.
+func (j *TvlJob) sumFlows(flows []flowResult) map[*domain.TokenMetadata]map[sdk.ChainID]*big.Int {
+	balances := make(map[*domain.TokenMetadata]map[sdk.ChainID]*big.Int)
+	for _, f := range flows {
+		token, ok := j.getToken(f.ID.TokenChain, f.ID.TokenAddress)
+		if !ok {
+			continue
+		}
+		// the amounts are integers with the decimals of the token bridge transfers, at most 8.
+		d, err := decimal.NewFromString(f.Amount.String())
+		if err != nil {
+			j.logger.Warn("Invalid transferred amount",
+				zap.String("token", token.GetTokenID()),
+				zap.String("amount", f.Amount.String()))
+			continue
+		}
+		amount := normalizeAmount(d.BigInt(), token.Decimals)
+
+		if _, ok := balances[token]; !ok {
+			balances[token] = make(map[sdk.ChainID]*big.Int)
+		}
+		add := func(chainID sdk.ChainID, amount *big.Int) {
+			if chainID == token.TokenChain {
+				return
+			}
+			b, ok := balances[token][chainID]
+			if !ok {
+				b = new(big.Int)
+				balances[token][chainID] = b
+			}
+			b.Add(b, amount)
+		}
+		add(f.ID.ToChain, amount)
+		add(f.ID.FromChain, new(big.Int).Neg(amount))
+	}
+	return balances
+}
+
+// normalizeAmount converts an amount of the token bridge to 8 decimals.
+// The token bridge truncates the amounts to 8 decimals, the tokens with less decimals keep theirs.
+func normalizeAmount(amount *big.Int, decimals int64) *big.Int {
+	if decimals >= 8 {
+		return amount
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(8-decimals), nil)
+	return amount.Mul(amount, factor)
+}
+
+// applySupplySnapshots replaces the wrapped balances computed from the transfers with the wrapped asset supply
+// of the chains supported by the supply source.
+func (j *TvlJob) applySupplySnapshots(ctx context.Context, balances map[*domain.TokenMetadata]map[sdk.ChainID]*big.Int) {
+	for token, chains := range balances {
+		for _, chainID := range j.supplySource.Chains() {
+			if chainID == token.TokenChain {
+				continue
+			}
+			supply, err := j.supplySource.GetWrappedSupply(ctx, chainID, token)
+			if errors.Is(err, ErrWrappedAssetNotFound) {
+				delete(chains, chainID)
+				continue
+			}
+			if err != nil {
+				j.logger.Warn("Failed to get wrapped supply, using the transfers balance",
+					zap.String("token", token.GetTokenID()),
+					zap.Uint16("chain", uint16(chainID)),
+					zap.Error(err))
+				continue
+			}
+			chains[chainID] = supply
+		}
+	}
+}
+
+// priceTokens computes the locked amount of each token and its value in USD.
+// The tokens without price are skipped.
+func (j *TvlJob) priceTokens(balances map[*domain.TokenMetadata]map[sdk.ChainID]*big.Int) []*TokenTvl {
+	var tvls []*TokenTvl
+	for token, chains := range balances {
+		locked := new(big.Int)
+		for _, b := range chains {
+			// a negative balance means the transfers history is incomplete.
+			if b.Sign() > 0 {
+				locked.Add(locked, b)
+			}
+		}
+		if locked.Sign() == 0 {
+			continue
+		}
+
+		price, err := j.notionalCache.Get(token.GetTokenID())
+		if err != nil {
+			if !errors.Is(err, notional.ErrNotFound) {
+				j.logger.Warn("Failed to get token price", zap.String("token", token.GetTokenID()), zap.Error(err))
+			}
+			j.logger.Debug("Skipping token without price", zap.String("token", token.GetTokenID()))
+			continue
+		}
+
+		amount := decimal.NewFromBigInt(locked, -8)
+		tvls = append(tvls, &TokenTvl{
+			Token:  token,
+			Amount: amount,
+			Price:  price.NotionalUsd,
+			TvlUSD: amount.Mul(price.NotionalUsd),
+		})
+	}
+	return tvls
+}
+
+// getToken returns the metadata of a token from its native address.
+func (j *TvlJob) getToken(tokenChain sdk.ChainID, nativeAddress string) (*domain.TokenMetadata, bool) {
+	nativeHex, err := domain.DecodeNativeAddressToHex(tokenChain, nativeAddress)
+	if err != nil {
+		return nil, false
+	}
+	addr, err := sdk.StringToAddress(nativeHex)
+	if err != nil {
+		return nil, false
+	}
+	return domain.GetTokenByAddress(tokenChain, addr.String())
+}
@@ -0,0 +1,111 @@
+package tvl
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/client/cache/notional"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	usdcAddress = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	wethAddress = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+)
+
+type fixedNotionalCache map[string]decimal.Decimal
+
+func (c fixedNotionalCache) Get(tokenID string) (notional.PriceData, error) {
+	price, ok := c[tokenID]
+	if !ok {
+		return notional.PriceData{}, notional.ErrNotFound
+	}
+	return notional.PriceData{NotionalUsd: price}, nil
+}
+
+func (c fixedNotionalCache) Close() error {
+	return nil
+}
+
+func newFlow(t *testing.T, tokenAddress string, from, to sdk.ChainID, amount string) flowResult {
+	var f flowResult
+	f.ID.TokenChain = sdk.ChainIDEthereum
+	f.ID.TokenAddress = tokenAddress
+	f.ID.FromChain = from
+	f.ID.ToChain = to
+	d, err := primitive.ParseDecimal128(amount)
+	require.NoError(t, err)
+	f.Amount = d
+	return f
+}
+
+func getToken(t *testing.T, balances map[*domain.TokenMetadata]map[sdk.ChainID]*big.Int, symbol string) map[sdk.ChainID]*big.Int {
+	for token, chains := range balances {
+		if token.Symbol.String() == symbol {
+			return chains
+		}
+	}
+	t.Fatalf("token %s not found", symbol)
+	return nil
+}
+
+func TestNormalizeAmount(t *testing.T) {
+	assert.Equal(t, big.NewInt(1_000_000_00), normalizeAmount(big.NewInt(1_000_000), 6))
+	assert.Equal(t, big.NewInt(1_000_000), normalizeAmount(big.NewInt(1_000_000), 8))
+	// the token bridge truncates the amounts of the tokens with more decimals to 8.
+	assert.Equal(t, big.NewInt(1_000_000), normalizeAmount(big.NewInt(1_000_000), 18))
+}
+
+func TestSumFlows(t *testing.T) {
+	j := &TvlJob{logger: zap.NewNop()}
+	balances := j.sumFlows([]flowResult{
+		// 100 USDC, the token bridge amounts keep the 6 decimals of USDC.
+		newFlow(t, usdcAddress, sdk.ChainIDEthereum, sdk.ChainIDSolana, "100000000"),
+		newFlow(t, usdcAddress, sdk.ChainIDSolana, sdk.ChainIDEthereum, "40000000"),
+		newFlow(t, usdcAddress, sdk.ChainIDSolana, sdk.ChainIDBSC, "10000000"),
+		// 2 WETH, the token bridge amounts are truncated to 8 decimals.
+		newFlow(t, wethAddress, sdk.ChainIDEthereum, sdk.ChainIDPolygon, "200000000"),
+		// the tokens without metadata are skipped.
+		newFlow(t, "0x0000000000000000000000000000000000000001", sdk.ChainIDEthereum, sdk.ChainIDPolygon, "1"),
+	})
+	require.Len(t, balances, 2)
+
+	usdc := getToken(t, balances, "USDC")
+	// the balance of the native chain is not tracked, the tokens are locked there.
+	assert.Len(t, usdc, 2)
+	assert.Equal(t, big.NewInt(50_00000000), usdc[sdk.ChainIDSolana])
+	assert.Equal(t, big.NewInt(10_00000000), usdc[sdk.ChainIDBSC])
+
+	weth := getToken(t, balances, "WETH")
+	assert.Equal(t, big.NewInt(2_00000000), weth[sdk.ChainIDPolygon])
+}
+
+func TestPriceTokens(t *testing.T) {
+	j := &TvlJob{logger: zap.NewNop()}
+	balances := j.sumFlows([]flowResult{
+		newFlow(t, usdcAddress, sdk.ChainIDEthereum, sdk.ChainIDSolana, "100000000"),
+		newFlow(t, usdcAddress, sdk.ChainIDSolana, sdk.ChainIDBSC, "150000000"),
+		newFlow(t, wethAddress, sdk.ChainIDEthereum, sdk.ChainIDPolygon, "200000000"),
+	})
+
+	for token := range balances {
+		if token.Symbol.String() == "USDC" {
+			j.notionalCache = fixedNotionalCache{token.GetTokenID(): decimal.NewFromInt(1)}
+		}
+	}
+	require.NotNil(t, j.notionalCache)
+
+	// the tokens without price are skipped.
+	tvls := j.priceTokens(balances)
+	require.Len(t, tvls, 1)
+	assert.Equal(t, "USDC", tvls[0].Token.Symbol.String())
+	// the negative balance of solana means the history is incomplete, it is not subtracted.
+	assert.Equal(t, "150", tvls[0].Amount.String())
+	assert.Equal(t, "150", tvls[0].TvlUSD.String())
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
 	vaaPayloadParser "github.com/deltaswapio/deltaswap-explorer/common/client/parser"
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	commonRepo "github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/common/tokens"
 	"github.com/deltaswapio/deltaswap-explorer/parser/config"
 	"github.com/deltaswapio/deltaswap-explorer/parser/http/vaa"
 	"github.com/deltaswapio/deltaswap-explorer/parser/internal/metrics"
@@ -49,7 +52,12 @@ func Run(config *config.BackfillerConfiguration) {
 		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
 	}
 
-	parserVAAAPIClient, err := vaaPayloadParser.NewParserVAAAPIClient(config.VaaPayloadParserTimeout, config.VaaPayloadParserURL, logger)
+	relayerEmitters, err := vaaPayloadParser.ParseEmitters(config.GenericRelayerEmitters)
+	if err != nil {
+		logger.Fatal("invalid generic relayer emitters", zap.Error(err))
+	}
+	parseVaaFunc, err := vaaPayloadParser.NewParseVaaFunc(config.P2pNetwork, config.VaaPayloadParserURL, config.VaaPayloadParserTimeout, logger,
+		vaaPayloadParser.WithGenericRelayerEmitters(relayerEmitters))
 	if err != nil {
 		logger.Fatal("Failed to create parse vaa api client")
 	}
@@ -57,8 +65,16 @@ func Run(config *config.BackfillerConfiguration) {
 	parserRepository := parser.NewRepository(db.Database, logger)
 	vaaRepository := vaa.NewRepository(db.Database, logger)
 
+	// load the token registry, the amounts of the attested tokens are normalized with their decimals.
+	tokenRepository := commonRepo.NewTokenRepository(db.Database, logger)
+	tokenRegistry := tokens.NewRegistry(tokenRepository, logger)
+	if err := tokenRegistry.Init(rootCtx); err != nil {
+		logger.Fatal("Failed to load token registry", zap.Error(err))
+	}
+	domain.SetTokenProvider(tokenRegistry)
+
 	//create a processor
-	processor := processor.New(parserVAAAPIClient, parserRepository, alert.NewDummyClient(), metrics.NewDummyMetrics(), logger)
+	processor := processor.New(parseVaaFunc, parserRepository, tokenRepository, alert.NewDummyClient(), metrics.NewDummyMetrics(), logger)
 
 	logger.Info("Started deltaswap-explorer-parser as backfiller")
 
@@ -41,7 +41,7 @@ func addServiceCommand(root *cobra.Command) {
 }
 
 func addBackfiller(root *cobra.Command) {
-	var mongoUri, mongoDb, vaaPayloadParserURL, logLevel, startTime, endTime, sort string
+	var mongoUri, mongoDb, vaaPayloadParserURL, genericRelayerEmitters, logLevel, startTime, endTime, sort string
 	var vaaPayloadParserTimeout, pageSize int64
 
 	sortAsc := false
@@ -58,6 +58,7 @@ func addBackfiller(root *cobra.Command) {
 				MongoDatabase:           mongoDb,
 				VaaPayloadParserURL:     vaaPayloadParserURL,
 				VaaPayloadParserTimeout: vaaPayloadParserTimeout,
+				GenericRelayerEmitters:  genericRelayerEmitters,
 				StartTime:               startTime,
 				EndTime:                 endTime,
 				PageSize:                pageSize,
@@ -71,6 +72,7 @@ func addBackfiller(root *cobra.Command) {
 	backfillerCommand.Flags().StringVar(&mongoDb, "mongo-database", "", "Mongo database")
 	backfillerCommand.Flags().StringVar(&vaaPayloadParserURL, "vaa-payload-parser-url", "", "VAA payload parser service URL")
 	backfillerCommand.Flags().Int64Var(&vaaPayloadParserTimeout, "vaa-payload-parser-timeout", 10, "maximum waiting time in call to VAA payload service in seconds")
+	backfillerCommand.Flags().StringVar(&genericRelayerEmitters, "generic-relayer-emitters", "", "comma separated list of <chain id>/<address> of the generic relayer contracts")
 	backfillerCommand.Flags().StringVar(&startTime, "start-time", "1970-01-01T00:00:00Z", "minimum VAA timestamp to process")
 	backfillerCommand.Flags().StringVar(&endTime, "end-time", "", "maximum VAA timestamp to process (default now)")
 	backfillerCommand.Flags().Int64Var(&pageSize, "page-size", 100, "number of documents retrieved at a time")
@@ -14,7 +14,10 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
 	vaaPayloadParser "github.com/deltaswapio/deltaswap-explorer/common/client/parser"
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	commonRepo "github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/common/tokens"
 	"github.com/deltaswapio/deltaswap-explorer/parser/config"
 	"github.com/deltaswapio/deltaswap-explorer/parser/consumer"
 	"github.com/deltaswapio/deltaswap-explorer/parser/http/infrastructure"
@@ -75,9 +78,13 @@ func Run() {
 	// create a metrics
 	metrics := newMetrics(config)
 
-	// create a parserVAAAPIClient
-	parserVAAAPIClient, err := vaaPayloadParser.NewParserVAAAPIClient(config.VaaPayloadParserTimeout,
-		config.VaaPayloadParserURL, logger)
+	// create a vaa payload parser
+	relayerEmitters, err := vaaPayloadParser.ParseEmitters(config.GenericRelayerEmitters)
+	if err != nil {
+		logger.Fatal("invalid generic relayer emitters", zap.Error(err))
+	}
+	parseVaaFunc, err := vaaPayloadParser.NewParseVaaFunc(config.P2pNetwork, config.VaaPayloadParserURL, config.VaaPayloadParserTimeout, logger,
+		vaaPayloadParser.WithGenericRelayerEmitters(relayerEmitters))
 	if err != nil {
 		logger.Fatal("failed to create parse vaa api client")
 	}
@@ -86,8 +93,17 @@ func Run() {
 	sqsConsumer, vaaConsumeFunc := newVAAConsume(rootCtx, config, metrics, logger)
 	repository := parser.NewRepository(db.Database, logger)
 
+	// load the token registry, the amounts of the attested tokens are normalized with their decimals.
+	tokenRepository := commonRepo.NewTokenRepository(db.Database, logger)
+	tokenRegistry := tokens.NewRegistry(tokenRepository, logger)
+	if err := tokenRegistry.Init(rootCtx); err != nil {
+		logger.Fatal("failed to load token registry", zap.Error(err))
+	}
+	go tokenRegistry.Watch(rootCtx)
+	domain.SetTokenProvider(tokenRegistry)
+
 	//create a processor
-	processor := processor.New(parserVAAAPIClient, repository, alertClient, metrics, logger)
+	processor := processor.New(parseVaaFunc, repository, tokenRepository, alertClient, metrics, logger)
 
 	// create and start a consumer
 	consumer := consumer.New(vaaConsumeFunc, processor.Process, metrics, logger)
@@ -27,8 +27,9 @@ type ServiceConfiguration struct {
 	AwsSecretAccessKey      string `env:"AWS_SECRET_ACCESS_KEY"`
 	AwsRegion               string `env:"AWS_REGION"`
 	SQSUrl                  string `env:"SQS_URL"`
-	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL, required"`
-	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT, required"`
+	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL"`
+	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT,default=10"`
+	GenericRelayerEmitters  string `env:"GENERIC_RELAYER_EMITTERS"`
 	PprofEnabled            bool   `env:"PPROF_ENABLED,default=false"`
 	P2pNetwork              string `env:"P2P_NETWORK,required"`
 	AlertEnabled            bool   `env:"ALERT_ENABLED,default=false"`
@@ -41,8 +42,10 @@ type BackfillerConfiguration struct {
 	LogLevel                string `env:"LOG_LEVEL,default=INFO"`
 	MongoURI                string `env:"MONGODB_URI,required"`
 	MongoDatabase           string `env:"MONGODB_DATABASE,required"`
-	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL, required"`
-	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT, required"`
+	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL"`
+	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT,default=10"`
+	GenericRelayerEmitters  string `env:"GENERIC_RELAYER_EMITTERS"`
+	P2pNetwork              string `env:"P2P_NETWORK,default=mainnet"`
 	StartTime               string `env:"START_TIME"`
 	EndTime                 string `env:"END_TIME"`
 	PageSize                int64  `env:"PAGE_SIZE,default=100"`
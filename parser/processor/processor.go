@@ -11,6 +11,7 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
 	vaaPayloadParser "github.com/deltaswapio/deltaswap-explorer/common/client/parser"
 	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
 	parserAlert "github.com/deltaswapio/deltaswap-explorer/parser/internal/alert"
 	"github.com/deltaswapio/deltaswap-explorer/parser/internal/metrics"
 	"github.com/deltaswapio/deltaswap-explorer/parser/parser"
@@ -19,20 +20,22 @@ import (
 )
 
 type Processor struct {
-	parser     vaaPayloadParser.ParserVAAAPIClient
-	repository *parser.Repository
-	alert      alert.AlertClient
-	metrics    metrics.Metrics
-	logger     *zap.Logger
+	parseVaa        vaaPayloadParser.ParseVaaFunc
+	repository      *parser.Repository
+	tokenRepository *repository.TokenRepository
+	alert           alert.AlertClient
+	metrics         metrics.Metrics
+	logger          *zap.Logger
 }
 
-func New(parser vaaPayloadParser.ParserVAAAPIClient, repository *parser.Repository, alert alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger) *Processor {
+func New(parseVaa vaaPayloadParser.ParseVaaFunc, repository *parser.Repository, tokenRepository *repository.TokenRepository, alert alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger) *Processor {
 	return &Processor{
-		parser:     parser,
-		repository: repository,
-		alert:      alert,
-		metrics:    metrics,
-		logger:     logger,
+		parseVaa:        parseVaa,
+		repository:      repository,
+		tokenRepository: tokenRepository,
+		alert:           alert,
+		metrics:         metrics,
+		logger:          logger,
 	}
 }
 
@@ -43,13 +46,13 @@ func (p *Processor) Process(ctx context.Context, vaaBytes []byte) (*parser.Parse
 		return nil, err
 	}
 
-	// call vaa-payload-parser api to parse a VAA.
+	// parse the VAA payload.
 	chainID := uint16(vaa.EmitterChain)
 	emitterAddress := vaa.EmitterAddress.String()
 	sequence := fmt.Sprintf("%d", vaa.Sequence)
 
 	p.metrics.IncVaaPayloadParserRequestCount(chainID)
-	vaaParseResponse, err := p.parser.ParseVaaWithStandarizedProperties(vaa)
+	vaaParseResponse, err := p.parseVaa(vaa)
 	if err != nil {
 		// split metrics error not found and others errors.
 		if errors.Is(err, vaaPayloadParser.ErrNotFound) {
@@ -118,10 +121,46 @@ func (p *Processor) Process(ctx context.Context, vaaBytes []byte) (*parser.Parse
 	}
 	p.metrics.IncVaaParsedInserted(chainID)
 
+	// record the attested tokens in the token registry.
+	if attestation, ok := vaaParseResponse.ParsedPayload.(vaaPayloadParser.TokenBridgeAttestation); ok {
+		if err := p.registerToken(ctx, vaa, attestation); err != nil {
+			return nil, err
+		}
+	}
+
 	p.logger.Info("parsed VAA was successfully persisted", zap.String("id", vaaParsed.ID))
 	return &vaaParsed, nil
 }
 
+// registerToken inserts the token of a token bridge attestation in the token registry.
+// The tokens already in the registry are not modified.
+func (p *Processor) registerToken(ctx context.Context, vaa *sdk.VAA, attestation vaaPayloadParser.TokenBridgeAttestation) error {
+	addr, err := sdk.StringToAddress(strings.TrimPrefix(attestation.TokenAddress, "0x"))
+	if err != nil {
+		p.logger.Warn("Attested token address cannot be parsed",
+			zap.String("vaaId", vaa.MessageID()),
+			zap.String("tokenAddress", attestation.TokenAddress),
+			zap.Error(err))
+		return nil
+	}
+
+	err = p.tokenRepository.InsertIfNotExists(ctx, &repository.TokenDoc{
+		TokenChain:   attestation.TokenChain,
+		TokenAddress: addr.String(),
+		Symbol:       attestation.Symbol,
+		Name:         attestation.Name,
+		Decimals:     int64(attestation.Decimals),
+		Source:       repository.TokenSourceAttestation,
+		VaaID:        vaa.MessageID(),
+	})
+	if err != nil {
+		p.logger.Error("Error inserting attested token",
+			zap.String("vaaId", vaa.MessageID()),
+			zap.Error(err))
+	}
+	return err
+}
+
 // transformStandarizedProperties transform amount and fee amount.
 func (p *Processor) transformStandarizedProperties(vaaID string, sp vaaPayloadParser.StandardizedProperties) vaaPayloadParser.StandardizedProperties {
 	// transform amount.
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -14,6 +15,7 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/common/client/alert"
 	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
+	commonRepo "github.com/deltaswapio/deltaswap-explorer/common/repository"
 	"github.com/deltaswapio/deltaswap-explorer/pipeline/config"
 	"github.com/deltaswapio/deltaswap-explorer/pipeline/healthcheck"
 	"github.com/deltaswapio/deltaswap-explorer/pipeline/http/infrastructure"
@@ -23,6 +25,7 @@ import (
 	"github.com/deltaswapio/deltaswap-explorer/pipeline/pipeline"
 	"github.com/deltaswapio/deltaswap-explorer/pipeline/topic"
 	"github.com/deltaswapio/deltaswap-explorer/pipeline/watcher"
+	"github.com/deltaswapio/deltaswap-explorer/pipeline/webhook"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
@@ -79,6 +82,15 @@ func main() {
 		logger.Fatal("failed to create health checks", zap.Error(err))
 	}
 
+	// notify the vaas and the confirmed destination txs to the webhooks.
+	if config.WebhooksEnabled {
+		dispatcher, err := newWebhookDispatcher(rootCtx, config, db.Database, metrics, logger)
+		if err != nil {
+			logger.Fatal("failed to start webhook dispatcher", zap.Error(err))
+		}
+		pushFunc = dispatcher.Wrap(pushFunc)
+	}
+
 	// create a new pipeline repository.
 	repository := pipeline.NewRepository(db.Database, logger)
 
@@ -89,7 +101,9 @@ func main() {
 
 	// create a new publisher.
 	publisher := pipeline.NewPublisher(pushFunc, metrics, repository, config.P2pNetwork, txHashHandler, logger)
-	watcher := watcher.NewWatcher(rootCtx, db.Database, config.MongoDatabase, publisher.Publish, alertClient, metrics, logger)
+	checkpoints := watcher.NewCheckpointRepository(db.Database, logger)
+	catchUpWindow := time.Duration(config.CatchUpWindowHours) * time.Hour
+	watcher := watcher.NewWatcher(rootCtx, db.Database, config.MongoDatabase, publisher.Publish, checkpoints, catchUpWindow, alertClient, metrics, logger)
 	err = watcher.Start(rootCtx)
 	if err != nil {
 		logger.Fatal("failed to watch MongoDB", zap.Error(err))
@@ -168,6 +182,25 @@ func newTopicProducer(appCtx context.Context, config *config.Configuration, aler
 	return topic.NewVAASNS(snsProducer, alertClient, metrics, logger).Publish, nil
 }
 
+func newWebhookDispatcher(ctx context.Context, cfg *config.Configuration, db *mongo.Database, metrics metrics.Metrics, logger *zap.Logger) (*webhook.Dispatcher, error) {
+	policy := webhook.RetryPolicy{
+		MaxAttempts:    cfg.WebhooksMaxAttempts,
+		InitialBackoff: time.Duration(cfg.WebhooksInitialBackoffSeconds) * time.Second,
+		MaxBackoff:     time.Duration(cfg.WebhooksMaxBackoffSeconds) * time.Second,
+	}
+	client := &http.Client{Timeout: time.Duration(cfg.WebhooksTimeoutSeconds) * time.Second}
+	repository := commonRepo.NewWebhookRepository(db, logger)
+	dispatcher := webhook.NewDispatcher(repository, client, policy, cfg.WebhooksWorkers, cfg.WebhooksQueueSize, metrics, logger)
+	dispatcher.Start(ctx)
+
+	checkpoints := watcher.NewCheckpointRepository(db, logger)
+	destinationWatcher := webhook.NewDestinationWatcher(db, dispatcher, checkpoints, logger)
+	if err := destinationWatcher.Start(ctx); err != nil {
+		return nil, err
+	}
+	return dispatcher, nil
+}
+
 func newHealthChecks(ctx context.Context, config *config.Configuration, db *mongo.Database) ([]healthcheck.Check, error) {
 	awsConfig, err := newAwsConfig(ctx, config)
 	if err != nil {
@@ -24,6 +24,19 @@ type Configuration struct {
 	AlertEnabled       bool   `env:"ALERT_ENABLED,default=false"`
 	AlertApiKey        string `env:"ALERT_API_KEY"`
 	MetricsEnabled     bool   `env:"METRICS_ENABLED,default=false"`
+	CatchUpWindowHours int64  `env:"CATCH_UP_WINDOW_HOURS,default=24"`
+	WebhooksConfiguration
+}
+
+// WebhooksConfiguration represents the configuration of the webhook deliveries.
+type WebhooksConfiguration struct {
+	WebhooksEnabled               bool  `env:"WEBHOOKS_ENABLED,default=false"`
+	WebhooksWorkers               int   `env:"WEBHOOKS_WORKERS,default=4"`
+	WebhooksQueueSize             int   `env:"WEBHOOKS_QUEUE_SIZE,default=1000"`
+	WebhooksMaxAttempts           int   `env:"WEBHOOKS_MAX_ATTEMPTS,default=8"`
+	WebhooksInitialBackoffSeconds int64 `env:"WEBHOOKS_INITIAL_BACKOFF_SECONDS,default=2"`
+	WebhooksMaxBackoffSeconds     int64 `env:"WEBHOOKS_MAX_BACKOFF_SECONDS,default=600"`
+	WebhooksTimeoutSeconds        int64 `env:"WEBHOOKS_TIMEOUT_SECONDS,default=10"`
 }
 
 // New creates a configuration with the values from .env file and environment variables.
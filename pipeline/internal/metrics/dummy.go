@@ -1,5 +1,7 @@
 package metrics
 
+import "time"
+
 // DummyMetrics is a dummy implementation of Metric interface.
 type DummyMetrics struct {
 }
@@ -20,3 +22,9 @@ func (m *DummyMetrics) IncVaaWithoutTxHash(chainID uint16) {}
 
 // IncVaaWithTxHashFixed increments the vaa received count with tx hash fixed.
 func (m *DummyMetrics) IncVaaWithTxHashFixed(chainID uint16) {}
+
+// SetResumeLag sets the lag between the last vaa handled by the watcher and now.
+func (m *DummyMetrics) SetResumeLag(lag time.Duration) {}
+
+// IncWebhookDelivery increments the webhook deliveries count by event and result.
+func (m *DummyMetrics) IncWebhookDelivery(event, result string) {}
@@ -1,5 +1,7 @@
 package metrics
 
+import "time"
+
 const serviceName = "deltaswapscan-pipeline"
 
 // Metrics is a metrics interface.
@@ -9,4 +11,8 @@ type Metrics interface {
 
 	IncVaaWithoutTxHash(chainID uint16)
 	IncVaaWithTxHashFixed(chainID uint16)
+
+	SetResumeLag(lag time.Duration)
+
+	IncWebhookDelivery(event, result string)
 }
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/deltaswapio/deltaswap/sdk/vaa"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -10,6 +12,8 @@ import (
 type PrometheusMetrics struct {
 	vaaReceivedCount *prometheus.CounterVec
 	vaaTxHashCount   *prometheus.CounterVec
+	resumeLag        prometheus.Gauge
+	webhookCount     *prometheus.CounterVec
 }
 
 // NewPrometheusMetrics creates a new PrometheusMetrics.
@@ -34,9 +38,31 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 			},
 		}, []string{"chain", "type"})
 
+	resumeLag := promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "vaa_watcher_resume_lag_seconds",
+			Help: "Seconds between the indexing of the last vaa handled by the watcher and now",
+			ConstLabels: map[string]string{
+				"environment": environment,
+				"service":     serviceName,
+			},
+		})
+
+	webhookCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_delivery_count",
+			Help: "Total number of webhook deliveries by event and result",
+			ConstLabels: map[string]string{
+				"environment": environment,
+				"service":     serviceName,
+			},
+		}, []string{"event", "result"})
+
 	return &PrometheusMetrics{
 		vaaReceivedCount: vaaReceivedCount,
 		vaaTxHashCount:   vaaTxHashCount,
+		resumeLag:        resumeLag,
+		webhookCount:     webhookCount,
 	}
 }
 
@@ -63,3 +89,13 @@ func (m *PrometheusMetrics) IncVaaWithTxHashFixed(chainID uint16) {
 	chain := vaa.ChainID(chainID).String()
 	m.vaaTxHashCount.WithLabelValues(chain, "vaa-with-txhash-fixed").Inc()
 }
+
+// SetResumeLag sets the lag between the last vaa handled by the watcher and now.
+func (m *PrometheusMetrics) SetResumeLag(lag time.Duration) {
+	m.resumeLag.Set(lag.Seconds())
+}
+
+// IncWebhookDelivery increments the webhook deliveries count by event and result.
+func (m *PrometheusMetrics) IncWebhookDelivery(event, result string) {
+	m.webhookCount.WithLabelValues(event, result).Inc()
+}
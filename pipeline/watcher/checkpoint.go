@@ -0,0 +1,56 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Checkpoint represents the last database change handled by the watcher.
+type Checkpoint struct {
+	ID          string    `bson:"_id"`
+	ResumeToken bson.Raw  `bson:"resumeToken"`
+	IndexedAt   time.Time `bson:"indexedAt"`
+	UpdatedAt   time.Time `bson:"updatedAt"`
+}
+
+// CheckpointRepository persists the watcher checkpoints.
+type CheckpointRepository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	checkpoints *mongo.Collection
+}
+
+// NewCheckpointRepository creates a new checkpoint repository.
+func NewCheckpointRepository(db *mongo.Database, logger *zap.Logger) *CheckpointRepository {
+	return &CheckpointRepository{
+		db:          db,
+		logger:      logger.With(zap.String("module", "CheckpointRepository")),
+		checkpoints: db.Collection("pipelineCheckpoints"),
+	}
+}
+
+// Get returns the checkpoint with the given id, or nil if it doesn't exist.
+func (r *CheckpointRepository) Get(ctx context.Context, id string) (*Checkpoint, error) {
+	var checkpoint Checkpoint
+	err := r.checkpoints.FindOne(ctx, bson.M{"_id": id}).Decode(&checkpoint)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Save upserts a checkpoint.
+func (r *CheckpointRepository) Save(ctx context.Context, checkpoint *Checkpoint) error {
+	checkpoint.UpdatedAt = time.Now()
+	_, err := r.checkpoints.ReplaceOne(ctx, bson.M{"_id": checkpoint.ID}, checkpoint, options.Replace().SetUpsert(true))
+	return err
+}
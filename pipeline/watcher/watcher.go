@@ -2,6 +2,7 @@ package watcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,17 +12,38 @@ import (
 	"github.com/deltaswapio/deltaswap/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
+const (
+	// checkpointID is the id of the watcher checkpoint.
+	checkpointID = "vaas-watcher"
+	// checkpointInterval is the minimum time between two checkpoints, at most the vaas handled
+	// in this interval are published again after a restart.
+	checkpointInterval = 5 * time.Second
+)
+
+// watchedCollections are the collections watched by the change stream query.
+var watchedCollections = []string{"vaasPythnet", "vaas"}
+
+// resume token error codes returned by mongo when the change stream can't be resumed.
+const (
+	errCodeInvalidResumeToken      = 260
+	errCodeChangeStreamFatalError  = 280
+	errCodeChangeStreamHistoryLost = 286
+)
+
 // Watcher represents a listener of database changes.
 type Watcher struct {
-	db          *mongo.Database
-	dbName      string
-	handler     WatcherFunc
-	alertClient alert.AlertClient
-	metrics     metrics.Metrics
-	logger      *zap.Logger
+	db            *mongo.Database
+	dbName        string
+	handler       WatcherFunc
+	checkpoints   *CheckpointRepository
+	catchUpWindow time.Duration
+	alertClient   alert.AlertClient
+	metrics       metrics.Metrics
+	logger        *zap.Logger
 }
 
 // WatcherFunc is a function to send database changes.
@@ -64,18 +86,24 @@ const queryTemplate = `
 `
 
 // NewWatcher creates a new database event watcher.
-func NewWatcher(ctx context.Context, db *mongo.Database, dbName string, handler WatcherFunc, alertClient alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger) *Watcher {
+// catchUpWindow bounds how far back the vaas are republished when the change stream can't be resumed.
+func NewWatcher(ctx context.Context, db *mongo.Database, dbName string, handler WatcherFunc, checkpoints *CheckpointRepository,
+	catchUpWindow time.Duration, alertClient alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger) *Watcher {
 	return &Watcher{
-		db:          db,
-		dbName:      dbName,
-		handler:     handler,
-		metrics:     metrics,
-		alertClient: alertClient,
-		logger:      logger,
+		db:            db,
+		dbName:        dbName,
+		handler:       handler,
+		checkpoints:   checkpoints,
+		catchUpWindow: catchUpWindow,
+		metrics:       metrics,
+		alertClient:   alertClient,
+		logger:        logger,
 	}
 }
 
 // Start executes database event consumption.
+// The change stream is resumed from the last checkpoint. If the resume token has expired, the vaas
+// indexed since the checkpoint are republished before consuming the new change stream.
 func (w *Watcher) Start(ctx context.Context) error {
 	query := fmt.Sprintf(queryTemplate, w.dbName, w.dbName)
 	var steps []bson.D
@@ -84,11 +112,38 @@ func (w *Watcher) Start(ctx context.Context) error {
 		return err
 	}
 
-	stream, err := w.db.Watch(ctx, steps)
+	checkpoint, err := w.checkpoints.Get(ctx, checkpointID)
 	if err != nil {
 		return err
 	}
+
+	var stream *mongo.ChangeStream
+	catchUp := false
+	if checkpoint != nil && len(checkpoint.ResumeToken) > 0 {
+		w.metrics.SetResumeLag(time.Since(checkpoint.IndexedAt))
+		stream, err = w.db.Watch(ctx, steps, options.ChangeStream().SetResumeAfter(checkpoint.ResumeToken))
+		if IsResumeTokenLost(err) {
+			w.logger.Warn("Change stream resume token expired, catching up from last checkpoint",
+				zap.Time("indexedAt", checkpoint.IndexedAt), zap.Error(err))
+			catchUp = true
+			stream, err = w.db.Watch(ctx, steps)
+		}
+	} else {
+		stream, err = w.db.Watch(ctx, steps)
+	}
+	if err != nil {
+		return err
+	}
+
 	go func() {
+		defer stream.Close(context.Background())
+		if catchUp {
+			if err := w.catchUp(ctx, checkpoint.IndexedAt, time.Now()); err != nil {
+				w.logger.Error("Error catching up vaas", zap.Error(err))
+			}
+		}
+		var pending *Checkpoint
+		var lastCheckpoint time.Time
 		for stream.Next(ctx) {
 			var e watchEvent
 			if err := stream.Decode(&e); err != nil {
@@ -102,11 +157,93 @@ func (w *Watcher) Start(ctx context.Context) error {
 			}
 			w.metrics.IncVaaFromMongoStream(e.DbFullDocument.ChainID)
 			w.handler(ctx, &e.DbFullDocument)
+			pending = &Checkpoint{
+				ID:          checkpointID,
+				ResumeToken: stream.ResumeToken(),
+				IndexedAt:   e.DbFullDocument.IndexedAt,
+			}
+			if time.Since(lastCheckpoint) >= checkpointInterval {
+				w.saveCheckpoint(ctx, pending)
+				pending, lastCheckpoint = nil, time.Now()
+			}
+		}
+		if err := stream.Err(); err != nil {
+			w.logger.Error("Change stream closed with error", zap.Error(err))
+		}
+		// the context may be cancelled, so the last checkpoint is saved with a new one.
+		if pending != nil {
+			saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			w.saveCheckpoint(saveCtx, pending)
 		}
 	}()
 	return nil
 }
 
+// catchUp republishes the vaas indexed between from and until, bounded by the catch-up window.
+func (w *Watcher) catchUp(ctx context.Context, from, until time.Time) error {
+	if minFrom := until.Add(-w.catchUpWindow); from.Before(minFrom) {
+		w.logger.Warn("Last checkpoint is older than the catch-up window, some vaas will not be published",
+			zap.Time("checkpoint", from), zap.Time("from", minFrom))
+		from = minFrom
+	}
+
+	for _, collection := range watchedCollections {
+		count, err := w.catchUpCollection(ctx, collection, from, until)
+		if err != nil {
+			return err
+		}
+		w.logger.Info("Finished catching up vaas",
+			zap.String("collection", collection),
+			zap.Time("from", from),
+			zap.Time("until", until),
+			zap.Int("count", count))
+	}
+	return nil
+}
+
+// catchUpCollection republishes the vaas of a collection indexed between from and until.
+func (w *Watcher) catchUpCollection(ctx context.Context, collection string, from, until time.Time) (int, error) {
+	filter := bson.M{"indexedAt": bson.M{"$gte": from, "$lte": until}}
+	opts := options.Find().SetSort(bson.D{{Key: "indexedAt", Value: 1}})
+	cur, err := w.db.Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	count := 0
+	for cur.Next(ctx) {
+		var e Event
+		if err := cur.Decode(&e); err != nil {
+			w.logger.Error("Error decoding vaa to catch up", zap.String("collection", collection), zap.Error(err))
+			continue
+		}
+		w.handler(ctx, &e)
+		count++
+	}
+	return count, cur.Err()
+}
+
+// saveCheckpoint persists the resume token of the last handled event.
+func (w *Watcher) saveCheckpoint(ctx context.Context, checkpoint *Checkpoint) {
+	w.metrics.SetResumeLag(time.Since(checkpoint.IndexedAt))
+	if err := w.checkpoints.Save(ctx, checkpoint); err != nil {
+		w.logger.Error("Error saving watcher checkpoint", zap.Error(err))
+	}
+}
+
+// IsResumeTokenLost returns true if the error means the change stream can't be resumed from the resume token.
+func IsResumeTokenLost(err error) bool {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	return serverErr.HasErrorCode(errCodeInvalidResumeToken) ||
+		serverErr.HasErrorCode(errCodeChangeStreamFatalError) ||
+		serverErr.HasErrorCode(errCodeChangeStreamHistoryLost)
+}
+
 // toAlertDetail returns from the watch event an map with the alert details.
 func (e *watchEvent) toMapAlertDetail() map[string]string {
 	detail := make(map[string]string)
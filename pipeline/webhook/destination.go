@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/domain"
+	"github.com/deltaswapio/deltaswap-explorer/pipeline/watcher"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// destinationCheckpointID is the id of the destination watcher checkpoint.
+const destinationCheckpointID = "webhooks-destination-watcher"
+
+// destinationQuery matches the global transactions whose destination tx was confirmed.
+const destinationQuery = `
+	[
+		{
+			"$match" : {
+				"$or": [
+					{ "operationType": "insert", "fullDocument.destinationTx.status": "%s" },
+					{ "operationType": "update", "updateDescription.updatedFields.destinationTx.status": "%s" }
+				]
+			}
+		}
+	]
+`
+
+type destinationEvent struct {
+	FullDocument struct {
+		ID            string        `bson:"_id"`
+		DestinationTx DestinationTx `bson:"destinationTx"`
+	} `bson:"fullDocument"`
+}
+
+// DestinationWatcher watches the destination updates of the global transactions made by the
+// contract-watcher and notifies the tx.confirmed event to the webhooks.
+type DestinationWatcher struct {
+	db          *mongo.Database
+	dispatcher  *Dispatcher
+	checkpoints *watcher.CheckpointRepository
+	logger      *zap.Logger
+}
+
+// NewDestinationWatcher creates a new destination watcher.
+func NewDestinationWatcher(db *mongo.Database, dispatcher *Dispatcher, checkpoints *watcher.CheckpointRepository, logger *zap.Logger) *DestinationWatcher {
+	return &DestinationWatcher{
+		db:          db,
+		dispatcher:  dispatcher,
+		checkpoints: checkpoints,
+		logger:      logger.With(zap.String("module", "WebhookDestinationWatcher")),
+	}
+}
+
+// Start executes the consumption of the global transactions change stream.
+func (w *DestinationWatcher) Start(ctx context.Context) error {
+	query := fmt.Sprintf(destinationQuery, domain.DstTxStatusConfirmed, domain.DstTxStatusConfirmed)
+	var steps []bson.D
+	if err := bson.UnmarshalExtJSON([]byte(query), true, &steps); err != nil {
+		return err
+	}
+
+	checkpoint, err := w.checkpoints.Get(ctx, destinationCheckpointID)
+	if err != nil {
+		return err
+	}
+
+	collection := w.db.Collection("globalTransactions")
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	var stream *mongo.ChangeStream
+	if checkpoint != nil && len(checkpoint.ResumeToken) > 0 {
+		stream, err = collection.Watch(ctx, steps, opts.SetResumeAfter(checkpoint.ResumeToken))
+		if watcher.IsResumeTokenLost(err) {
+			w.logger.Warn("Change stream resume token expired, some destination updates will not be notified",
+				zap.Time("indexedAt", checkpoint.IndexedAt), zap.Error(err))
+			stream, err = collection.Watch(ctx, steps, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+		}
+	} else {
+		stream, err = collection.Watch(ctx, steps, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer stream.Close(context.Background())
+		for stream.Next(ctx) {
+			var e destinationEvent
+			if err := stream.Decode(&e); err != nil {
+				w.logger.Error("Error unmarshalling destination event", zap.Error(err))
+				continue
+			}
+			w.handle(ctx, &e)
+			w.saveCheckpoint(ctx, stream.ResumeToken())
+		}
+		if err := stream.Err(); err != nil {
+			w.logger.Error("Change stream closed with error", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (w *DestinationWatcher) handle(ctx context.Context, e *destinationEvent) {
+	chainID, emitterAddress, sequence, err := splitVaaID(e.FullDocument.ID)
+	if err != nil {
+		w.logger.Error("Invalid global transaction id", zap.String("id", e.FullDocument.ID), zap.Error(err))
+		return
+	}
+	data := TxConfirmedData{
+		ID:            e.FullDocument.ID,
+		EmitterChain:  chainID,
+		EmitterAddr:   emitterAddress,
+		Sequence:      sequence,
+		DestinationTx: e.FullDocument.DestinationTx,
+	}
+	if err := w.dispatcher.NotifyTxConfirmed(ctx, &data); err != nil {
+		w.logger.Error("Failed to notify confirmed tx to webhooks", zap.String("id", data.ID), zap.Error(err))
+	}
+}
+
+func (w *DestinationWatcher) saveCheckpoint(ctx context.Context, resumeToken bson.Raw) {
+	checkpoint := watcher.Checkpoint{
+		ID:          destinationCheckpointID,
+		ResumeToken: resumeToken,
+		IndexedAt:   time.Now(),
+	}
+	if err := w.checkpoints.Save(ctx, &checkpoint); err != nil {
+		w.logger.Error("Error saving destination watcher checkpoint", zap.Error(err))
+	}
+}
+
+// splitVaaID splits a VAA id into its chain, emitter address and sequence.
+func splitVaaID(id string) (sdk.ChainID, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 {
+		return 0, "", "", fmt.Errorf("expected 3 parts, got %d", len(parts))
+	}
+	chainID, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return 0, "", "", err
+	}
+	return sdk.ChainID(chainID), parts[1], parts[2], nil
+}
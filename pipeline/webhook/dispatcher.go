@@ -0,0 +1,281 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/pipeline/internal/metrics"
+	"github.com/deltaswapio/deltaswap-explorer/pipeline/topic"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Delivery results used in the metrics.
+const (
+	resultDelivered  = "delivered"
+	resultRetried    = "retried"
+	resultDeadLetter = "dead-letter"
+)
+
+// Repository is the storage of the webhooks used by the dispatcher.
+type Repository interface {
+	FindSubscribed(ctx context.Context, chainID sdk.ChainID, emitterAddress, event string) ([]*repository.WebhookDoc, error)
+	InsertDeadLetter(ctx context.Context, doc *repository.WebhookDeadLetterDoc) error
+}
+
+// RetryPolicy defines how the failed deliveries are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts before sending the delivery to the dead-letter collection.
+	MaxAttempts int
+	// InitialBackoff is the wait time after the first failed attempt. It doubles after each attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum wait time between attempts.
+	MaxBackoff time.Duration
+}
+
+// backoff returns the wait time after the given number of failed attempts.
+func (p RetryPolicy) backoff(attempts int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
+}
+
+// delivery is a payload to send to a webhook.
+type delivery struct {
+	id             string
+	webhook        *repository.WebhookDoc
+	event          string
+	body           []byte
+	attempts       int
+	lastStatusCode int
+	lastErr        error
+}
+
+// Dispatcher sends the events to the subscribed webhooks.
+// Failed deliveries are retried with exponential backoff and stored in the dead-letter collection
+// when the attempts are exhausted. Deliveries waiting for a retry are lost if the service stops.
+type Dispatcher struct {
+	repo    Repository
+	client  *http.Client
+	policy  RetryPolicy
+	queue   chan *delivery
+	workers int
+	metrics metrics.Metrics
+	logger  *zap.Logger
+}
+
+// NewDispatcher creates a new webhook dispatcher.
+func NewDispatcher(repo Repository, client *http.Client, policy RetryPolicy, workers, queueSize int, metrics metrics.Metrics, logger *zap.Logger) *Dispatcher {
+	if workers <= 0 {
+		workers = 1
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	return &Dispatcher{
+		repo:    repo,
+		client:  client,
+		policy:  policy,
+		queue:   make(chan *delivery, queueSize),
+		workers: workers,
+		metrics: metrics,
+		logger:  logger.With(zap.String("module", "WebhookDispatcher")),
+	}
+}
+
+// Start starts the workers that send the deliveries.
+func (d *Dispatcher) Start(ctx context.Context) {
+	for i := 0; i < d.workers; i++ {
+		go d.run(ctx)
+	}
+}
+
+// Wrap returns a push function that calls next and notifies the vaa.signed event to the webhooks.
+// The event is only notified when next succeeds, the VAA is notified when it is pushed again otherwise.
+func (d *Dispatcher) Wrap(next topic.PushFunc) topic.PushFunc {
+	return func(ctx context.Context, e *topic.Event) error {
+		if err := next(ctx, e); err != nil {
+			return err
+		}
+		if err := d.NotifyVaa(ctx, e); err != nil {
+			d.logger.Error("failed to notify vaa to webhooks", zap.String("id", e.ID), zap.Error(err))
+		}
+		return nil
+	}
+}
+
+// NotifyVaa sends a vaa.signed event to the webhooks of the emitter.
+func (d *Dispatcher) NotifyVaa(ctx context.Context, e *topic.Event) error {
+	data := VaaSignedData{
+		ID:             e.ID,
+		EmitterChain:   sdk.ChainID(e.ChainID),
+		EmitterAddr:    e.EmitterAddress,
+		Sequence:       e.Sequence,
+		PhylaxSetIndex: e.PhylaxSetIndex,
+		TxHash:         e.TxHash,
+		Timestamp:      e.Timestamp,
+		Vaa:            e.Vaa,
+	}
+	return d.notify(ctx, data.EmitterChain, data.EmitterAddr, repository.WebhookEventVaaSigned, data)
+}
+
+// NotifyTxConfirmed sends a tx.confirmed event to the webhooks of the emitter.
+func (d *Dispatcher) NotifyTxConfirmed(ctx context.Context, data *TxConfirmedData) error {
+	return d.notify(ctx, data.EmitterChain, data.EmitterAddr, repository.WebhookEventTxConfirmed, data)
+}
+
+func (d *Dispatcher) notify(ctx context.Context, chainID sdk.ChainID, emitterAddress, event string, data interface{}) error {
+	webhooks, err := d.repo.FindSubscribed(ctx, chainID, emitterAddress, event)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range webhooks {
+		id, err := newDeliveryID()
+		if err != nil {
+			return err
+		}
+		body, err := json.Marshal(Payload{
+			ID:        id,
+			Event:     event,
+			WebhookID: w.ID,
+			CreatedAt: time.Now().UTC(),
+			Data:      data,
+		})
+		if err != nil {
+			return err
+		}
+		d.enqueue(ctx, &delivery{id: id, webhook: w, event: event, body: body})
+	}
+	return nil
+}
+
+// enqueue adds the delivery to the queue. If the queue is full the delivery goes to the dead-letter collection
+// to avoid blocking the pipeline.
+func (d *Dispatcher) enqueue(ctx context.Context, dl *delivery) {
+	select {
+	case d.queue <- dl:
+	default:
+		dl.lastErr = fmt.Errorf("delivery queue is full")
+		d.deadLetter(ctx, dl)
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case dl := <-d.queue:
+			d.attempt(ctx, dl)
+		}
+	}
+}
+
+// attempt sends the delivery and schedules a retry if it fails.
+func (d *Dispatcher) attempt(ctx context.Context, dl *delivery) {
+	dl.attempts++
+	statusCode, err := d.send(ctx, dl)
+	if err == nil {
+		d.metrics.IncWebhookDelivery(dl.event, resultDelivered)
+		return
+	}
+	dl.lastStatusCode = statusCode
+	dl.lastErr = err
+
+	if !isRetryable(statusCode) || dl.attempts >= d.policy.MaxAttempts {
+		d.deadLetter(ctx, dl)
+		return
+	}
+
+	backoff := d.policy.backoff(dl.attempts)
+	d.logger.Debug("retrying webhook delivery",
+		zap.String("delivery", dl.id),
+		zap.String("webhook", dl.webhook.ID),
+		zap.Int("attempts", dl.attempts),
+		zap.Duration("backoff", backoff),
+		zap.Error(err))
+	d.metrics.IncWebhookDelivery(dl.event, resultRetried)
+	time.AfterFunc(backoff, func() {
+		select {
+		case d.queue <- dl:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// send posts the delivery to the webhook url and returns the response status code.
+func (d *Dispatcher) send(ctx context.Context, dl *delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dl.webhook.URL, bytes.NewReader(dl.body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, dl.event)
+	req.Header.Set(HeaderDelivery, dl.id)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(dl.webhook.Secret, timestamp, dl.body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// deadLetter stores the delivery in the dead-letter collection.
+func (d *Dispatcher) deadLetter(ctx context.Context, dl *delivery) {
+	d.metrics.IncWebhookDelivery(dl.event, resultDeadLetter)
+	d.logger.Warn("webhook delivery failed",
+		zap.String("delivery", dl.id),
+		zap.String("webhook", dl.webhook.ID),
+		zap.Int("attempts", dl.attempts),
+		zap.Error(dl.lastErr))
+
+	doc := repository.WebhookDeadLetterDoc{
+		ID:             dl.id,
+		WebhookID:      dl.webhook.ID,
+		URL:            dl.webhook.URL,
+		Event:          dl.event,
+		Payload:        string(dl.body),
+		Attempts:       dl.attempts,
+		LastStatusCode: dl.lastStatusCode,
+	}
+	if dl.lastErr != nil {
+		doc.LastError = dl.lastErr.Error()
+	}
+	if err := d.repo.InsertDeadLetter(ctx, &doc); err != nil {
+		d.logger.Error("failed to store webhook dead letter", zap.String("delivery", dl.id), zap.Error(err))
+	}
+}
+
+// isRetryable returns true if a delivery that failed with the status code can be retried.
+// A zero status code means that the request failed before getting a response.
+func isRetryable(statusCode int) bool {
+	return statusCode == 0 ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= http.StatusInternalServerError
+}
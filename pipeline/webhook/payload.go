@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// Headers sent with each delivery.
+const (
+	HeaderEvent     = "X-Deltaswap-Event"
+	HeaderDelivery  = "X-Deltaswap-Delivery"
+	HeaderTimestamp = "X-Deltaswap-Timestamp"
+	HeaderSignature = "X-Deltaswap-Signature"
+)
+
+// signaturePrefix is the prefix of the signature header value.
+const signaturePrefix = "sha256="
+
+// Payload is the body sent to the webhook.
+type Payload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	WebhookID string      `json:"webhookId"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+// VaaSignedData is the data of a vaa.signed event.
+type VaaSignedData struct {
+	ID             string      `json:"id"`
+	EmitterChain   sdk.ChainID `json:"emitterChain"`
+	EmitterAddr    string      `json:"emitterAddr"`
+	Sequence       string      `json:"sequence"`
+	PhylaxSetIndex uint32      `json:"phylaxSetIndex"`
+	TxHash         string      `json:"txHash,omitempty"`
+	Timestamp      *time.Time  `json:"timestamp"`
+	Vaa            []byte      `json:"vaa"`
+}
+
+// TxConfirmedData is the data of a tx.confirmed event.
+type TxConfirmedData struct {
+	ID            string        `json:"id"`
+	EmitterChain  sdk.ChainID   `json:"emitterChain"`
+	EmitterAddr   string        `json:"emitterAddr"`
+	Sequence      string        `json:"sequence"`
+	DestinationTx DestinationTx `json:"destinationTx"`
+}
+
+// DestinationTx is the destination transaction of a VAA.
+type DestinationTx struct {
+	ChainID     sdk.ChainID `bson:"chainId" json:"chainId"`
+	Status      string      `bson:"status" json:"status"`
+	Method      string      `bson:"method" json:"method"`
+	TxHash      string      `bson:"txHash" json:"txHash"`
+	From        string      `bson:"from" json:"from"`
+	To          string      `bson:"to" json:"to"`
+	BlockNumber string      `bson:"blockNumber" json:"blockNumber"`
+	Timestamp   *time.Time  `bson:"timestamp" json:"timestamp"`
+	UpdatedAt   *time.Time  `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Sign returns the value of the signature header for a delivery.
+// The signature is the HMAC-SHA256 of "<timestamp>.<body>" using the webhook secret as key.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature header of a delivery. It is meant to be used by the receivers.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// newDeliveryID returns a random id for a delivery.
+func newDeliveryID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate delivery id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
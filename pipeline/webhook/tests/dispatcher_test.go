@@ -0,0 +1,256 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/common/repository"
+	"github.com/deltaswapio/deltaswap-explorer/pipeline/internal/metrics"
+	"github.com/deltaswapio/deltaswap-explorer/pipeline/topic"
+	"github.com/deltaswapio/deltaswap-explorer/pipeline/webhook"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testSecret  = "secret"
+	testEmitter = "ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5"
+)
+
+type fakeRepository struct {
+	sync.Mutex
+	webhooks    []*repository.WebhookDoc
+	deadLetters []*repository.WebhookDeadLetterDoc
+	deadLetterC chan struct{}
+}
+
+func newFakeRepository(url string) *fakeRepository {
+	return &fakeRepository{
+		webhooks: []*repository.WebhookDoc{{
+			ID:             "webhook1",
+			URL:            url,
+			Secret:         testSecret,
+			EmitterChain:   sdk.ChainIDSolana,
+			EmitterAddress: testEmitter,
+			Events:         []string{repository.WebhookEventVaaSigned},
+			Enabled:        true,
+		}},
+		deadLetterC: make(chan struct{}, 10),
+	}
+}
+
+func (r *fakeRepository) FindSubscribed(_ context.Context, chainID sdk.ChainID, emitterAddress, event string) ([]*repository.WebhookDoc, error) {
+	var webhooks []*repository.WebhookDoc
+	for _, w := range r.webhooks {
+		if w.EmitterChain == chainID && w.EmitterAddress == emitterAddress && w.HasEvent(event) {
+			webhooks = append(webhooks, w)
+		}
+	}
+	return webhooks, nil
+}
+
+func (r *fakeRepository) InsertDeadLetter(_ context.Context, doc *repository.WebhookDeadLetterDoc) error {
+	r.Lock()
+	r.deadLetters = append(r.deadLetters, doc)
+	r.Unlock()
+	r.deadLetterC <- struct{}{}
+	return nil
+}
+
+func newTestEvent() *topic.Event {
+	return &topic.Event{
+		ID:             "1/" + testEmitter + "/1",
+		ChainID:        uint16(sdk.ChainIDSolana),
+		EmitterAddress: testEmitter,
+		Sequence:       "1",
+		Vaa:            []byte{1, 2, 3},
+	}
+}
+
+func newTestDispatcher(repo *fakeRepository, maxAttempts int) *webhook.Dispatcher {
+	policy := webhook.RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+	}
+	return webhook.NewDispatcher(repo, http.DefaultClient, policy, 1, 10, metrics.NewDummyMetrics(), zap.NewNop())
+}
+
+func TestDispatcher_DeliversSignedPayload(t *testing.T) {
+	received := make(chan *webhook.Payload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.True(t, webhook.Verify(testSecret, timestamp, body, r.Header.Get(webhook.HeaderSignature)))
+		assert.Equal(t, repository.WebhookEventVaaSigned, r.Header.Get(webhook.HeaderEvent))
+
+		var payload webhook.Payload
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, r.Header.Get(webhook.HeaderDelivery), payload.ID)
+		received <- &payload
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := newFakeRepository(server.URL)
+	dispatcher := newTestDispatcher(repo, 3)
+	dispatcher.Start(ctx)
+
+	var pushed int32
+	push := dispatcher.Wrap(func(context.Context, *topic.Event) error {
+		atomic.AddInt32(&pushed, 1)
+		return nil
+	})
+	require.NoError(t, push(ctx, newTestEvent()))
+
+	select {
+	case payload := <-received:
+		assert.Equal(t, "webhook1", payload.WebhookID)
+		assert.Equal(t, repository.WebhookEventVaaSigned, payload.Event)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not delivered")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&pushed))
+}
+
+func TestDispatcher_WrapSkipsFailedPush(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := newFakeRepository(server.URL)
+	dispatcher := newTestDispatcher(repo, 1)
+	dispatcher.Start(ctx)
+
+	pushErr := errors.New("push failed")
+	push := dispatcher.Wrap(func(context.Context, *topic.Event) error {
+		return pushErr
+	})
+	assert.Equal(t, pushErr, push(ctx, newTestEvent()))
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+}
+
+func TestDispatcher_RetriesUntilSuccess(t *testing.T) {
+	var requests int32
+	delivered := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		delivered <- struct{}{}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := newFakeRepository(server.URL)
+	dispatcher := newTestDispatcher(repo, 5)
+	dispatcher.Start(ctx)
+	require.NoError(t, dispatcher.NotifyVaa(ctx, newTestEvent()))
+
+	select {
+	case <-delivered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not delivered")
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.Empty(t, repo.deadLetters)
+}
+
+func TestDispatcher_DeadLetterAfterMaxAttempts(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := newFakeRepository(server.URL)
+	dispatcher := newTestDispatcher(repo, 3)
+	dispatcher.Start(ctx)
+	require.NoError(t, dispatcher.NotifyVaa(ctx, newTestEvent()))
+
+	select {
+	case <-repo.deadLetterC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("dead letter not stored")
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	repo.Lock()
+	defer repo.Unlock()
+	require.Len(t, repo.deadLetters, 1)
+	assert.Equal(t, "webhook1", repo.deadLetters[0].WebhookID)
+	assert.Equal(t, 3, repo.deadLetters[0].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, repo.deadLetters[0].LastStatusCode)
+}
+
+func TestDispatcher_ClientErrorIsNotRetried(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := newFakeRepository(server.URL)
+	dispatcher := newTestDispatcher(repo, 5)
+	dispatcher.Start(ctx)
+	require.NoError(t, dispatcher.NotifyVaa(ctx, newTestEvent()))
+
+	select {
+	case <-repo.deadLetterC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("dead letter not stored")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestDispatcher_IgnoresOtherEmitters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected delivery")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := newFakeRepository(server.URL)
+	dispatcher := newTestDispatcher(repo, 1)
+	dispatcher.Start(ctx)
+
+	event := newTestEvent()
+	event.ChainID = uint16(sdk.ChainIDEthereum)
+	require.NoError(t, dispatcher.NotifyVaa(ctx, event))
+	time.Sleep(100 * time.Millisecond)
+}
@@ -6,13 +6,17 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/deltaswapio/deltaswap-explorer/common/dbutil"
 	"github.com/deltaswapio/deltaswap-explorer/common/health"
 	"github.com/deltaswapio/deltaswap-explorer/common/logger"
 	"github.com/deltaswapio/deltaswap-explorer/spy/config"
 	"github.com/deltaswapio/deltaswap-explorer/spy/grpc"
 	"github.com/deltaswapio/deltaswap-explorer/spy/http/infraestructure"
+	"github.com/deltaswapio/deltaswap-explorer/spy/internal/metrics"
 	"github.com/deltaswapio/deltaswap-explorer/spy/source"
+	"github.com/deltaswapio/deltaswap-explorer/spy/storage"
 	"github.com/deltaswapio/deltaswap/node/pkg/supervisor"
 	"github.com/go-redis/redis/v8"
 	"go.uber.org/zap"
@@ -40,6 +44,25 @@ func newHealthChecks(
 	return healthChecks, nil
 }
 
+// newSubscribersOptions creates the options of the subscriber queues.
+func newSubscribersOptions(cfg *config.Configuration) ([]grpc.SubscribersOption, error) {
+	policy, err := grpc.ParseOverflowPolicy(cfg.SubscriberOverflowPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	var m metrics.Metrics = metrics.NewDummyMetrics()
+	if cfg.MetricsEnabled {
+		m = metrics.NewPrometheusMetrics(cfg.Env)
+	}
+
+	return []grpc.SubscribersOption{
+		grpc.WithQueueSize(cfg.SubscriberQueueSize),
+		grpc.WithOverflowPolicy(policy, time.Duration(cfg.SubscriberBlockTimeoutMs)*time.Millisecond),
+		grpc.WithMetrics(m),
+	}, nil
+}
+
 func main() {
 
 	defer handleExit()
@@ -55,12 +78,27 @@ func main() {
 
 	logger.Info("Starting deltaswap-explorer-spy ...")
 
-	svs := grpc.NewSignedVaaSubscribers(logger)
-	avs := grpc.NewAllVaaSubscribers(logger)
+	subscribersOpts, err := newSubscribersOptions(config)
+	if err != nil {
+		logger.Fatal("failed to create subscribers options", zap.Error(err))
+	}
+	svs := grpc.NewSignedVaaSubscribers(logger, subscribersOpts...)
+	avs := grpc.NewAllVaaSubscribers(logger, subscribersOpts...)
 	go svs.Start(rootCtx)
 	go avs.Start(rootCtx)
 
-	handler := grpc.NewHandler(svs, avs, logger)
+	// the replay of the stored VAAs is only enabled when the database is configured.
+	var replayer grpc.VaaReplayer
+	var db *dbutil.Session
+	if config.MongoURI != "" {
+		db, err = dbutil.Connect(rootCtx, logger, config.MongoURI, config.MongoDatabase, false)
+		if err != nil {
+			logger.Fatal("failed to connect MongoDB", zap.Error(err))
+		}
+		replayer = storage.NewRepository(db.Database, logger)
+	}
+
+	handler := grpc.NewHandler(svs, avs, replayer, logger)
 
 	grpcServer, err := grpc.NewServer(handler, logger, config.GrpcAddress)
 	if err != nil {
@@ -98,7 +136,7 @@ func main() {
 		logger.Fatal("failed to create health checks", zap.Error(err))
 	}
 
-	server := infraestructure.NewServer(logger, config.Port, config.PprofEnabled, healthChecks...)
+	server := infraestructure.NewServer(logger, config.Port, config.PprofEnabled, config.MetricsEnabled, healthChecks...)
 	server.Start()
 
 	logger.Info("Started deltaswap-explorer-spy")
@@ -127,6 +165,11 @@ func main() {
 		logger.Error("Error closing redis client", zap.Error(err))
 	}
 
+	if db != nil {
+		logger.Info("Closing MongoDB connection...")
+		db.DisconnectWithTimeout(10 * time.Second)
+	}
+
 	logger.Info("Closing Http server ...")
 	server.Stop()
 	logger.Info("Finished deltaswap-explorer-spy")
@@ -17,6 +17,17 @@ type Configuration struct {
 	RedisPrefix  string `env:"REDIS_PREFIX,required"`
 	RedisChannel string `env:"REDIS_VAA_CHANNEL,required"`
 	PprofEnabled bool   `env:"PPROF_ENABLED,default=false"`
+	// SubscriberQueueSize is the maximum number of VAAs queued for each subscriber.
+	SubscriberQueueSize int `env:"SUBSCRIBER_QUEUE_SIZE,default=100"`
+	// SubscriberOverflowPolicy is applied when the queue of a subscriber is full: block, disconnect or drop-oldest.
+	// With drop-oldest the subscribers miss VAAs without notice, so it is opt-in.
+	SubscriberOverflowPolicy string `env:"SUBSCRIBER_OVERFLOW_POLICY,default=block"`
+	// SubscriberBlockTimeoutMs is the time to wait for room in the queue with the block policy.
+	SubscriberBlockTimeoutMs int64 `env:"SUBSCRIBER_BLOCK_TIMEOUT_MS,default=500"`
+	MetricsEnabled           bool  `env:"METRICS_ENABLED,default=false"`
+	// MongoURI and MongoDatabase are optional, the replay of the stored VAAs is disabled when they are not set.
+	MongoURI      string `env:"MONGODB_URI"`
+	MongoDatabase string `env:"MONGODB_DATABASE"`
 }
 
 // New creates a configuration with the values from .env file and environment variables.
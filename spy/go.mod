@@ -18,6 +18,7 @@ require (
 
 require (
 	github.com/go-redis/redis/v8 v8.11.5
+	github.com/prometheus/client_golang v1.14.0
 	github.com/deltaswapio/deltaswap-explorer/common v0.0.0-20231124191152-bbb28b8d69ea
 )
 
@@ -75,7 +76,6 @@ require (
 	github.com/multiformats/go-varint v0.0.6 // indirect
 	github.com/philhofer/fwd v1.1.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/prometheus/client_golang v1.14.0 // indirect
 	github.com/prometheus/client_model v0.3.0 // indirect
 	github.com/prometheus/common v0.37.0 // indirect
 	github.com/prometheus/procfs v0.8.0 // indirect
@@ -3,6 +3,7 @@ package grpc
 import (
 	"fmt"
 
+	"github.com/deltaswapio/deltaswap-explorer/spy/storage"
 	spyv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/spy/v1"
 	"github.com/deltaswapio/deltaswap/sdk/vaa"
 	"go.uber.org/zap"
@@ -10,20 +11,29 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// replayBufferSize is the maximum number of live VAAs buffered while the stored VAAs are replayed.
+const replayBufferSize = 10000
+
+// errQueueOverflow is returned to the subscribers disconnected by the overflow policy.
+var errQueueOverflow = status.Error(codes.ResourceExhausted, "subscriber queue overflowed")
+
 // Handler represents a GRPC subscription service handler.
 type Handler struct {
 	spyv1.UnimplementedSpyRPCServiceServer
-	svs    *SignedVaaSubscribers
-	avs    *AllVaaSubscribers
-	logger *zap.Logger
+	svs      *SignedVaaSubscribers
+	avs      *AllVaaSubscribers
+	replayer VaaReplayer
+	logger   *zap.Logger
 }
 
 // NewHandler creates a new handler of suscriptions.
-func NewHandler(svs *SignedVaaSubscribers, avs *AllVaaSubscribers, logger *zap.Logger) *Handler {
+// replayer can be nil to disable the replay of the stored VAAs.
+func NewHandler(svs *SignedVaaSubscribers, avs *AllVaaSubscribers, replayer VaaReplayer, logger *zap.Logger) *Handler {
 	return &Handler{
-		svs:    svs,
-		avs:    avs,
-		logger: logger,
+		svs:      svs,
+		avs:      avs,
+		replayer: replayer,
+		logger:   logger,
 	}
 }
 
@@ -51,14 +61,29 @@ func (h *Handler) SubscribeSignedVAA(req *spyv1.SubscribeSignedVAARequest, resp
 		}
 	}
 
+	query, err := parseReplayQuery(resp.Context(), fi)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if query != nil && h.replayer == nil {
+		return status.Error(codes.Unimplemented, "replay is not enabled")
+	}
+
 	subscriber := h.svs.Register(fi)
 	defer h.svs.Unregister(subscriber)
 
+	if query != nil {
+		return h.replayAndStream(query, subscriber, resp)
+	}
+
 	for {
 		select {
 		case <-resp.Context().Done():
 			h.logger.Error("Context done", zap.String("id", subscriber.id), zap.Error(resp.Context().Err()))
 			return resp.Context().Err()
+		case <-subscriber.overflow:
+			h.logger.Warn("Subscriber queue overflowed", zap.String("id", subscriber.id))
+			return errQueueOverflow
 		case msg := <-subscriber.ch:
 			if err := resp.Send(&spyv1.SubscribeSignedVAAResponse{
 				VaaBytes: msg.vaaBytes,
@@ -70,6 +95,102 @@ func (h *Handler) SubscribeSignedVAA(req *spyv1.SubscribeSignedVAARequest, resp
 	}
 }
 
+// replayAndStream sends the stored VAAs that match the query and then the live VAAs.
+// The live VAAs received during the replay are buffered, and the ones already replayed are discarded.
+func (h *Handler) replayAndStream(query *storage.ReplayQuery, subscriber *subscriptionSignedVaa, resp spyv1.SpyRPCService_SubscribeSignedVAAServer) error {
+	ctx := resp.Context()
+
+	// wait until the subscriber receives the live VAAs, so no VAA is lost between the replay and the live delivery.
+	select {
+	case <-subscriber.ready:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	live := make(chan message, replayBufferSize)
+	overflow := make(chan struct{})
+	go func() {
+		for msg := range subscriber.ch {
+			select {
+			case live <- msg:
+			default:
+				close(overflow)
+				return
+			}
+		}
+		close(live)
+	}()
+
+	h.logger.Info("Replaying vaas", zap.String("id", subscriber.id))
+	lastSequences := make(map[string]uint64)
+	count := 0
+	err := h.replayer.Replay(ctx, query, func(doc *storage.VaaDoc) error {
+		if err := resp.Send(&spyv1.SubscribeSignedVAAResponse{VaaBytes: doc.Vaa}); err != nil {
+			return err
+		}
+		key := emitterKey(doc.EmitterChain, doc.EmitterAddr)
+		if last, ok := lastSequences[key]; !ok || doc.Sequence > last {
+			lastSequences[key] = doc.Sequence
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		h.logger.Error("Replaying vaas", zap.String("id", subscriber.id), zap.Error(err))
+		return status.Error(codes.Internal, "failed to replay vaas")
+	}
+	h.logger.Info("Finished replaying vaas", zap.String("id", subscriber.id), zap.Int("count", count))
+
+	for {
+		// the buffer overflowed, some live VAAs were lost.
+		select {
+		case <-overflow:
+			return status.Error(codes.ResourceExhausted, "too many live vaas received during the replay")
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			h.logger.Error("Context done", zap.String("id", subscriber.id), zap.Error(ctx.Err()))
+			return ctx.Err()
+		case <-overflow:
+			return status.Error(codes.ResourceExhausted, "too many live vaas received during the replay")
+		case <-subscriber.overflow:
+			h.logger.Warn("Subscriber queue overflowed", zap.String("id", subscriber.id))
+			return errQueueOverflow
+		case msg, ok := <-live:
+			if !ok {
+				return status.Error(codes.Unavailable, "subscription closed")
+			}
+			if isReplayed(msg.vaaBytes, lastSequences) {
+				continue
+			}
+			if err := resp.Send(&spyv1.SubscribeSignedVAAResponse{
+				VaaBytes: msg.vaaBytes,
+			}); err != nil {
+				h.logger.Error("Sending vaas", zap.String("id", subscriber.id), zap.Error(err))
+				return err
+			}
+		}
+	}
+}
+
+// isReplayed returns true if the VAA was already sent by the replay.
+func isReplayed(vaaBytes []byte, lastSequences map[string]uint64) bool {
+	if len(lastSequences) == 0 {
+		return false
+	}
+	v, err := vaa.Unmarshal(vaaBytes)
+	if err != nil {
+		return false
+	}
+	last, ok := lastSequences[emitterKey(v.EmitterChain, v.EmitterAddress.String())]
+	return ok && v.Sequence <= last
+}
+
 // SubscribeSignedVAAByType implements the suscriptions of signed VAA by type.
 func (h *Handler) SubscribeSignedVAAByType(req *spyv1.SubscribeSignedVAAByTypeRequest, resp spyv1.SpyRPCService_SubscribeSignedVAAByTypeServer) error {
 	h.logger.Info("Receiving new subscriber in signed VAA by type")
@@ -102,6 +223,9 @@ func (h *Handler) SubscribeSignedVAAByType(req *spyv1.SubscribeSignedVAAByTypeRe
 		select {
 		case <-resp.Context().Done():
 			return resp.Context().Err()
+		case <-sub.overflow:
+			h.logger.Warn("Subscriber queue overflowed", zap.String("id", sub.id))
+			return errQueueOverflow
 		case msg := <-sub.ch:
 			if err := resp.Send(msg); err != nil {
 				return err
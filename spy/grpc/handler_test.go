@@ -46,7 +46,7 @@ func TestSubscribeSignedVAA_OK(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	svs := NewSignedVaaSubscribers(logger)
 	avs := NewAllVaaSubscribers(logger)
-	handler := NewHandler(svs, avs, logger)
+	handler := NewHandler(svs, avs, nil, logger)
 
 	_, _, client := createGRPCServer(handler, logger)
 
@@ -84,7 +84,7 @@ func TestSubscribeSignedVAA_Failed(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	svs := NewSignedVaaSubscribers(logger)
 	avs := NewAllVaaSubscribers(logger)
-	handler := NewHandler(svs, avs, logger)
+	handler := NewHandler(svs, avs, nil, logger)
 
 	ctx, _, client := createGRPCServer(handler, logger)
 
@@ -130,7 +130,7 @@ func TestSubscribeSignedVAAByType_OK(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	svs := NewSignedVaaSubscribers(logger)
 	avs := NewAllVaaSubscribers(logger)
-	handler := NewHandler(svs, avs, logger)
+	handler := NewHandler(svs, avs, nil, logger)
 
 	_, _, client := createGRPCServer(handler, logger)
 
@@ -169,7 +169,7 @@ func TestSubscribeSignedVAAByType_Failed(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	svs := NewSignedVaaSubscribers(logger)
 	avs := NewAllVaaSubscribers(logger)
-	handler := NewHandler(svs, avs, logger)
+	handler := NewHandler(svs, avs, nil, logger)
 
 	ctx, _, client := createGRPCServer(handler, logger)
 
@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/spy/internal/metrics"
+)
+
+// OverflowPolicy defines what happens when the queue of a subscriber is full.
+type OverflowPolicy string
+
+const (
+	// OverflowDropOldest discards the oldest VAA of the queue to make room for the new one.
+	// The subscriber misses VAAs without notice, so it must be enabled explicitly.
+	OverflowDropOldest OverflowPolicy = "drop-oldest"
+	// OverflowDisconnect closes the subscription with a ResourceExhausted status.
+	OverflowDisconnect OverflowPolicy = "disconnect"
+	// OverflowBlock waits for room in the queue up to the block timeout, then disconnects the subscriber.
+	// The other subscribers are delayed while waiting.
+	OverflowBlock OverflowPolicy = "block"
+)
+
+const (
+	defaultQueueSize    = 100
+	defaultBlockTimeout = 500 * time.Millisecond
+)
+
+// ParseOverflowPolicy parses an overflow policy.
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch p := OverflowPolicy(s); p {
+	case OverflowDropOldest, OverflowDisconnect, OverflowBlock:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown overflow policy %s", s)
+	}
+}
+
+// subscribersConfig contains the settings of the subscriber queues.
+type subscribersConfig struct {
+	queueSize    int
+	policy       OverflowPolicy
+	blockTimeout time.Duration
+	metrics      metrics.Metrics
+}
+
+// SubscribersOption represents a subscribers option function.
+type SubscribersOption func(*subscribersConfig)
+
+// WithQueueSize sets the maximum number of VAAs queued for each subscriber.
+func WithQueueSize(size int) SubscribersOption {
+	return func(c *subscribersConfig) {
+		if size > 0 {
+			c.queueSize = size
+		}
+	}
+}
+
+// WithOverflowPolicy sets the policy applied when the queue of a subscriber is full.
+// blockTimeout is only used by the OverflowBlock policy.
+func WithOverflowPolicy(policy OverflowPolicy, blockTimeout time.Duration) SubscribersOption {
+	return func(c *subscribersConfig) {
+		c.policy = policy
+		if blockTimeout > 0 {
+			c.blockTimeout = blockTimeout
+		}
+	}
+}
+
+// WithMetrics sets the metrics of the subscriber queues.
+func WithMetrics(m metrics.Metrics) SubscribersOption {
+	return func(c *subscribersConfig) {
+		c.metrics = m
+	}
+}
+
+func newSubscribersConfig(opts ...SubscribersOption) *subscribersConfig {
+	c := &subscribersConfig{
+		queueSize:    defaultQueueSize,
+		policy:       OverflowBlock,
+		blockTimeout: defaultBlockTimeout,
+		metrics:      metrics.NewDummyMetrics(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// subscriberQueue contains the overflow state of a subscriber. It is only accessed from the Start loop.
+type subscriberQueue struct {
+	// overflow is closed when the subscriber must be disconnected.
+	overflow     chan struct{}
+	disconnected bool
+}
+
+func newSubscriberQueue() subscriberQueue {
+	return subscriberQueue{overflow: make(chan struct{})}
+}
+
+// deliver sends a VAA to the channel of a subscriber applying the overflow policy.
+func deliver[T any](c *subscribersConfig, subscriberType, id string, q *subscriberQueue, ch chan T, v T) {
+	if q.disconnected {
+		return
+	}
+	defer func() {
+		c.metrics.SetSubscriberQueueDepth(subscriberType, id, len(ch))
+	}()
+
+	select {
+	case ch <- v:
+		return
+	default:
+	}
+
+	switch c.policy {
+	case OverflowDisconnect:
+		disconnect(c, subscriberType, q)
+	case OverflowBlock:
+		timer := time.NewTimer(c.blockTimeout)
+		defer timer.Stop()
+		select {
+		case ch <- v:
+		case <-timer.C:
+			c.metrics.IncSubscriberDrops(subscriberType, id)
+			disconnect(c, subscriberType, q)
+		}
+	default:
+		// discard the oldest VAA to make room, unless the consumer read it meanwhile.
+		select {
+		case <-ch:
+			c.metrics.IncSubscriberDrops(subscriberType, id)
+		default:
+		}
+		// the Start loop is the only sender, so there is room for the VAA and the send doesn't wait.
+		// The timeout only guards against blocking the other subscribers if that ever changes.
+		timer := time.NewTimer(c.blockTimeout)
+		defer timer.Stop()
+		select {
+		case ch <- v:
+		case <-timer.C:
+			c.metrics.IncSubscriberDrops(subscriberType, id)
+		}
+	}
+}
+
+func disconnect(c *subscribersConfig, subscriberType string, q *subscriberQueue) {
+	q.disconnected = true
+	close(q.overflow)
+	c.metrics.IncSubscriberDisconnections(subscriberType)
+}
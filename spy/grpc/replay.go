@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/spy/storage"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"google.golang.org/grpc/metadata"
+)
+
+// gRPC metadata keys to replay the stored VAAs before the live ones.
+// The subscribe request is defined by the node protos, so the starting point is sent as metadata.
+const (
+	// ReplayFromTimestampKey replays the VAAs with a timestamp greater than or equal to the value, in RFC3339 format.
+	ReplayFromTimestampKey = "x-replay-from-timestamp"
+	// ReplayFromSequenceKey replays the VAAs of an emitter from a sequence. The value format is
+	// <chainId>/<emitterAddress>/<sequence>, and the key can be set once for each emitter.
+	ReplayFromSequenceKey = "x-replay-from-sequence"
+)
+
+// VaaReplayer finds the stored VAAs to replay.
+type VaaReplayer interface {
+	Replay(ctx context.Context, q *storage.ReplayQuery, fn func(*storage.VaaDoc) error) error
+}
+
+// parseReplayQuery returns the replay query from the request metadata, or nil if the subscriber didn't request a replay.
+func parseReplayQuery(ctx context.Context, filters []filterSignedVaa) (*storage.ReplayQuery, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	timestamps := md.Get(ReplayFromTimestampKey)
+	sequences := md.Get(ReplayFromSequenceKey)
+	if len(timestamps) == 0 && len(sequences) == 0 {
+		return nil, nil
+	}
+	if len(timestamps) > 0 && len(sequences) > 0 {
+		return nil, fmt.Errorf("%s and %s can not be used together", ReplayFromTimestampKey, ReplayFromSequenceKey)
+	}
+
+	if len(timestamps) > 0 {
+		if len(timestamps) > 1 {
+			return nil, fmt.Errorf("%s must be set once", ReplayFromTimestampKey)
+		}
+		from, err := time.Parse(time.RFC3339, timestamps[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", ReplayFromTimestampKey, err)
+		}
+		q := storage.ReplayQuery{FromTimestamp: &from}
+		for _, f := range filters {
+			q.Emitters = append(q.Emitters, storage.Emitter{ChainID: f.chainId, Address: f.emitterAddr})
+		}
+		return &q, nil
+	}
+
+	var q storage.ReplayQuery
+	for _, s := range sequences {
+		es, err := parseEmitterSequence(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", ReplayFromSequenceKey, err)
+		}
+		if len(filters) > 0 && !matchFilters(filters, es.ChainID, es.Address) {
+			return nil, fmt.Errorf("invalid %s: emitter %d/%s is not in the filters", ReplayFromSequenceKey, es.ChainID, es.Address)
+		}
+		q.FromSequences = append(q.FromSequences, *es)
+	}
+	return &q, nil
+}
+
+func parseEmitterSequence(s string) (*storage.EmitterSequence, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected <chainId>/<emitterAddress>/<sequence>, got %s", s)
+	}
+	chainID, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := vaa.StringToAddress(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	sequence, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &storage.EmitterSequence{
+		Emitter:  storage.Emitter{ChainID: vaa.ChainID(chainID), Address: addr},
+		Sequence: sequence,
+	}, nil
+}
+
+func matchFilters(filters []filterSignedVaa, chainID vaa.ChainID, addr vaa.Address) bool {
+	for _, f := range filters {
+		if f.chainId == chainID && f.emitterAddr == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// emitterKey returns the key of an emitter used to track the last replayed sequence.
+func emitterKey(chainID vaa.ChainID, addr string) string {
+	return fmt.Sprintf("%d/%s", chainID, addr)
+}
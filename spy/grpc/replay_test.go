@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/spy/storage"
+	spyv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/spy/v1"
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeReplayer struct {
+	docs []*storage.VaaDoc
+	// onLast is called before replaying the last VAA.
+	onLast func()
+}
+
+func (r *fakeReplayer) Replay(_ context.Context, _ *storage.ReplayQuery, fn func(*storage.VaaDoc) error) error {
+	for i, doc := range r.docs {
+		if i == len(r.docs)-1 && r.onLast != nil {
+			r.onLast()
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createVAABytes(sequence uint64) []byte {
+	v := createVAA(vaa.ChainIDEthereum, emitterAddr)
+	v.Sequence = sequence
+	b, _ := v.MarshalBinary()
+	return b
+}
+
+func TestSubscribeSignedVAA_Replay(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	svs := NewSignedVaaSubscribers(logger)
+	avs := NewAllVaaSubscribers(logger)
+
+	replayer := &fakeReplayer{}
+	for seq := uint64(1); seq <= 3; seq++ {
+		replayer.docs = append(replayer.docs, &storage.VaaDoc{
+			EmitterChain: vaa.ChainIDEthereum,
+			EmitterAddr:  emitterAddr.String(),
+			Sequence:     seq,
+			Vaa:          createVAABytes(seq),
+		})
+	}
+	// the VAAs 3 and 4 arrive while the stored VAAs are replayed.
+	replayer.onLast = func() {
+		for _, seq := range []uint64{3, 4} {
+			_ = svs.HandleVAA(createVAABytes(seq))
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	handler := NewHandler(svs, avs, replayer, logger)
+	_, _, client := createGRPCServer(handler, logger)
+
+	doneSvs := make(chan bool)
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func(ctx context.Context) {
+		defer close(doneSvs)
+		svs.Start(ctx)
+	}(ctx)
+
+	from := fmt.Sprintf("%d/%s/1", vaa.ChainIDEthereum, emitterAddr.String())
+	reqCtx := metadata.AppendToOutgoingContext(ctx, ReplayFromSequenceKey, from)
+	stream, err := client.SubscribeSignedVAA(reqCtx, &spyv1.SubscribeSignedVAARequest{})
+	assert.Nil(t, err)
+
+	var sequences []uint64
+	for i := 0; i < 4; i++ {
+		resp, err := stream.Recv()
+		if !assert.Nil(t, err) {
+			break
+		}
+		v, err := vaa.Unmarshal(resp.VaaBytes)
+		assert.Nil(t, err)
+		sequences = append(sequences, v.Sequence)
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 4}, sequences)
+
+	cancel()
+	<-doneSvs
+}
+
+func TestSubscribeSignedVAA_ReplayDisabled(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	svs := NewSignedVaaSubscribers(logger)
+	avs := NewAllVaaSubscribers(logger)
+	handler := NewHandler(svs, avs, nil, logger)
+
+	ctx, _, client := createGRPCServer(handler, logger)
+
+	reqCtx := metadata.AppendToOutgoingContext(ctx, ReplayFromTimestampKey, "2023-01-01T00:00:00Z")
+	stream, err := client.SubscribeSignedVAA(reqCtx, &spyv1.SubscribeSignedVAARequest{})
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestParseReplayQuery(t *testing.T) {
+	otherAddr := vaa.Address{5}
+	filters := []filterSignedVaa{{chainId: vaa.ChainIDEthereum, emitterAddr: emitterAddr}}
+	fromEmitter := fmt.Sprintf("%d/%s/10", vaa.ChainIDEthereum, emitterAddr.String())
+	fromOther := fmt.Sprintf("%d/%s/10", vaa.ChainIDEthereum, otherAddr.String())
+
+	t.Run("no replay", func(t *testing.T) {
+		q, err := parseReplayQuery(context.Background(), filters)
+		assert.Nil(t, err)
+		assert.Nil(t, q)
+	})
+
+	t.Run("from timestamp", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ReplayFromTimestampKey, "2023-01-01T00:00:00Z"))
+		q, err := parseReplayQuery(ctx, filters)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), q.FromTimestamp.UTC())
+		assert.Equal(t, []storage.Emitter{{ChainID: vaa.ChainIDEthereum, Address: emitterAddr}}, q.Emitters)
+	})
+
+	t.Run("from sequence", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ReplayFromSequenceKey, fromEmitter))
+		q, err := parseReplayQuery(ctx, filters)
+		assert.Nil(t, err)
+		assert.Len(t, q.FromSequences, 1)
+		assert.Equal(t, uint64(10), q.FromSequences[0].Sequence)
+		assert.Equal(t, emitterAddr, q.FromSequences[0].Address)
+	})
+
+	t.Run("emitter not in filters", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ReplayFromSequenceKey, fromOther))
+		_, err := parseReplayQuery(ctx, filters)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("timestamp and sequence", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			ReplayFromTimestampKey, "2023-01-01T00:00:00Z",
+			ReplayFromSequenceKey, fromEmitter))
+		_, err := parseReplayQuery(ctx, filters)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("invalid sequence", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ReplayFromSequenceKey, "2/abc"))
+		_, err := parseReplayQuery(ctx, filters)
+		assert.NotNil(t, err)
+	})
+}
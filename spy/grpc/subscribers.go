@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/deltaswapio/deltaswap-explorer/spy/internal/metrics"
 	gossipv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/gossip/v1"
 	spyv1 "github.com/deltaswapio/deltaswap/node/pkg/proto/spy/v1"
 	"github.com/deltaswapio/deltaswap/sdk/vaa"
@@ -23,11 +24,17 @@ type subscriptionSignedVaa struct {
 	id      string
 	filters []filterSignedVaa
 	ch      chan message
+	// ready is closed when the subscriber starts receiving VAAs.
+	ready chan struct{}
+	subscriberQueue
 }
 type subscriptionAllVaa struct {
 	id      string
 	filters []*spyv1.FilterEntry
 	ch      chan *spyv1.SubscribeSignedVAAByTypeResponse
+	// ready is closed when the subscriber starts receiving VAAs.
+	ready chan struct{}
+	subscriberQueue
 }
 
 func subscriptionId() string {
@@ -40,16 +47,18 @@ type SignedVaaSubscribers struct {
 	subscribers      map[string]*subscriptionSignedVaa
 	addSubscriber    chan *subscriptionSignedVaa
 	removeSubscriber chan *subscriptionSignedVaa
+	config           *subscribersConfig
 	logger           *zap.Logger
 }
 
 // NewSignedVaaSubscribers creates a signed VAA subscribers.
-func NewSignedVaaSubscribers(logger *zap.Logger) *SignedVaaSubscribers {
+func NewSignedVaaSubscribers(logger *zap.Logger, opts ...SubscribersOption) *SignedVaaSubscribers {
 	return &SignedVaaSubscribers{
 		subscribers:      make(map[string]*subscriptionSignedVaa),
 		addSubscriber:    make(chan *subscriptionSignedVaa, 1),
 		removeSubscriber: make(chan *subscriptionSignedVaa, 1),
 		source:           make(chan []byte, 1),
+		config:           newSubscribersConfig(opts...),
 		logger:           logger,
 	}
 }
@@ -60,16 +69,18 @@ type AllVaaSubscribers struct {
 	subscribers      map[string]*subscriptionAllVaa
 	addSubscriber    chan *subscriptionAllVaa
 	removeSubscriber chan *subscriptionAllVaa
+	config           *subscribersConfig
 	logger           *zap.Logger
 }
 
 // NewAllVaaSubscribers creates all VAA subscribers.
-func NewAllVaaSubscribers(logger *zap.Logger) *AllVaaSubscribers {
+func NewAllVaaSubscribers(logger *zap.Logger, opts ...SubscribersOption) *AllVaaSubscribers {
 	return &AllVaaSubscribers{
 		subscribers:      make(map[string]*subscriptionAllVaa),
 		addSubscriber:    make(chan *subscriptionAllVaa, 1),
 		removeSubscriber: make(chan *subscriptionAllVaa, 1),
 		source:           make(chan []byte, 1),
+		config:           newSubscribersConfig(opts...),
 		logger:           logger,
 	}
 }
@@ -77,9 +88,11 @@ func NewAllVaaSubscribers(logger *zap.Logger) *AllVaaSubscribers {
 // Register registers a new subscriber with a list of filters.
 func (s *SignedVaaSubscribers) Register(fi []filterSignedVaa) *subscriptionSignedVaa {
 	sub := &subscriptionSignedVaa{
-		id:      subscriptionId(),
-		ch:      make(chan message, 1),
-		filters: fi,
+		id:              subscriptionId(),
+		ch:              make(chan message, s.config.queueSize),
+		filters:         fi,
+		ready:           make(chan struct{}),
+		subscriberQueue: newSubscriberQueue(),
 	}
 	s.logger.Info("Registering subscriber in signed VAAs ...", zap.String("id", sub.id))
 	s.addSubscriber <- sub
@@ -113,11 +126,13 @@ func (s *SignedVaaSubscribers) Start(ctx context.Context) {
 			return
 		case newSubscriber := <-s.addSubscriber:
 			s.subscribers[newSubscriber.id] = newSubscriber
+			close(newSubscriber.ready)
 			s.logger.Info("New subscriber registered in signed VAAs", zap.String("id", newSubscriber.id))
 		case subscriberToRemove := <-s.removeSubscriber:
 			if subscriber, exists := s.subscribers[subscriberToRemove.id]; exists {
 				close(subscriber.ch)
 				delete(s.subscribers, subscriberToRemove.id)
+				s.config.metrics.RemoveSubscriber(metrics.SignedVaaSubscriber, subscriber.id)
 				s.logger.Info("Subscriber unregistered in signed VAAs", zap.String("id", subscriber.id))
 			}
 		case vaas, ok := <-s.source:
@@ -128,10 +143,7 @@ func (s *SignedVaaSubscribers) Start(ctx context.Context) {
 
 			for _, sub := range s.subscribers {
 				if len(sub.filters) == 0 {
-					select {
-					case sub.ch <- message{vaaBytes: vaas}:
-					default:
-					}
+					deliver(s.config, metrics.SignedVaaSubscriber, sub.id, &sub.subscriberQueue, sub.ch, message{vaaBytes: vaas})
 					continue
 				}
 
@@ -146,10 +158,7 @@ func (s *SignedVaaSubscribers) Start(ctx context.Context) {
 
 				for _, fi := range sub.filters {
 					if fi.chainId == v.EmitterChain && fi.emitterAddr == v.EmitterAddress {
-						select {
-						case sub.ch <- message{vaaBytes: vaas}:
-						default:
-						}
+						deliver(s.config, metrics.SignedVaaSubscriber, sub.id, &sub.subscriberQueue, sub.ch, message{vaaBytes: vaas})
 					}
 				}
 
@@ -161,9 +170,11 @@ func (s *SignedVaaSubscribers) Start(ctx context.Context) {
 // Register registers a new subscriber with a list of filters.
 func (s *AllVaaSubscribers) Register(fi []*spyv1.FilterEntry) *subscriptionAllVaa {
 	sub := &subscriptionAllVaa{
-		id:      subscriptionId(),
-		ch:      make(chan *spyv1.SubscribeSignedVAAByTypeResponse, 1),
-		filters: fi,
+		id:              subscriptionId(),
+		ch:              make(chan *spyv1.SubscribeSignedVAAByTypeResponse, s.config.queueSize),
+		filters:         fi,
+		ready:           make(chan struct{}),
+		subscriberQueue: newSubscriberQueue(),
 	}
 	s.logger.Info("Registering subscriber in all VAAs ...", zap.String("id", sub.id))
 	s.addSubscriber <- sub
@@ -197,11 +208,13 @@ func (s *AllVaaSubscribers) Start(ctx context.Context) {
 			return
 		case newSubscriber := <-s.addSubscriber:
 			s.subscribers[newSubscriber.id] = newSubscriber
+			close(newSubscriber.ready)
 			s.logger.Info("New subscriber registered in all VAAs", zap.String("id", newSubscriber.id))
 		case subscriberToRemove := <-s.removeSubscriber:
 			if subscriber, exists := s.subscribers[subscriberToRemove.id]; exists {
 				close(subscriber.ch)
 				delete(s.subscribers, subscriberToRemove.id)
+				s.config.metrics.RemoveSubscriber(metrics.AllVaaSubscriber, subscriber.id)
 				s.logger.Info("Subscriber unregistered in all VAAs", zap.String("id", subscriber.id))
 			}
 		case vaaBytes, ok := <-s.source:
@@ -228,10 +241,7 @@ func (s *AllVaaSubscribers) Start(ctx context.Context) {
 			for _, sub := range s.subscribers {
 				if len(sub.filters) == 0 {
 					// this subscription has no filters, send them the VAA.
-					select {
-					case sub.ch <- envelope:
-					default:
-					}
+					deliver(s.config, metrics.AllVaaSubscriber, sub.id, &sub.subscriberQueue, sub.ch, envelope)
 					continue
 				}
 
@@ -245,10 +255,7 @@ func (s *AllVaaSubscribers) Start(ctx context.Context) {
 
 						if v.EmitterChain == filterChain && v.EmitterAddress.String() == filterAddr {
 							// it is a match, send the response
-							select {
-							case sub.ch <- envelope:
-							default:
-							}
+							deliver(s.config, metrics.AllVaaSubscriber, sub.id, &sub.subscriberQueue, sub.ch, envelope)
 						}
 					default:
 						s.logger.Error(fmt.Sprintf("Unsupported filter type in subscriptions: %T", filter))
@@ -1,6 +1,8 @@
 package grpc
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -170,3 +172,182 @@ func TestAllVaaSubscribers_HandleVAA(t *testing.T) {
 	})
 
 }
+
+// fakeMetrics records the drops and disconnections of the subscribers.
+type fakeMetrics struct {
+	mu             sync.Mutex
+	drops          map[string]int
+	disconnections int
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{drops: make(map[string]int)}
+}
+
+func (m *fakeMetrics) SetSubscriberQueueDepth(subscriberType, id string, depth int) {}
+
+func (m *fakeMetrics) IncSubscriberDrops(subscriberType, id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.drops[id]++
+}
+
+func (m *fakeMetrics) IncSubscriberDisconnections(subscriberType string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.disconnections++
+}
+
+func (m *fakeMetrics) RemoveSubscriber(subscriberType, id string) {}
+
+func (m *fakeMetrics) dropsOf(id string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.drops[id]
+}
+
+func (m *fakeMetrics) disconnectionCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.disconnections
+}
+
+// receive reads n messages from ch and returns the time it took, or the timeout if they don't arrive in time.
+func receive[T any](t *testing.T, ch chan T, n int, timeout time.Duration) time.Duration {
+	start := time.Now()
+	deadline := time.After(timeout)
+	for i := 0; i < n; i++ {
+		select {
+		case <-ch:
+		case <-deadline:
+			t.Errorf("received %d of %d vaas before the timeout", i, n)
+			return timeout
+		}
+	}
+	return time.Since(start)
+}
+
+func TestSignedVaaSubscribers_SlowConsumer(t *testing.T) {
+	const (
+		queueSize    = 1000
+		batchSize    = queueSize / 2
+		total        = 2000
+		blockTimeout = 50 * time.Millisecond
+	)
+
+	tests := []struct {
+		name   string
+		policy OverflowPolicy
+	}{
+		{name: "drop oldest", policy: OverflowDropOldest},
+		{name: "disconnect", policy: OverflowDisconnect},
+		{name: "block", policy: OverflowBlock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zaptest.NewLogger(t)
+			m := newFakeMetrics()
+			svs := NewSignedVaaSubscribers(logger, WithQueueSize(queueSize), WithOverflowPolicy(tt.policy, blockTimeout), WithMetrics(m))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				svs.Start(ctx)
+			}()
+			defer func() {
+				cancel()
+				<-done
+			}()
+
+			stalled := svs.Register(nil)
+			fast := svs.Register(nil)
+			<-stalled.ready
+			<-fast.ready
+
+			// the producer is paced by the fast subscriber, so only the stalled subscriber overflows.
+			start := time.Now()
+			for i := 0; i < total; i++ {
+				_ = svs.HandleVAA(createVAABytes(uint64(i)))
+				if (i+1)%batchSize == 0 {
+					receive(t, fast.ch, batchSize, 5*time.Second)
+				}
+			}
+
+			// the stalled subscriber delays the others at most once, by the block timeout.
+			assert.Less(t, time.Since(start), time.Second)
+
+			switch tt.policy {
+			case OverflowDropOldest:
+				assert.Eventually(t, func() bool { return m.dropsOf(stalled.id) == total-queueSize }, time.Second, 10*time.Millisecond)
+				assert.Equal(t, queueSize, len(stalled.ch))
+				msg := <-stalled.ch
+				assert.Equal(t, createVAABytes(total-queueSize), msg.vaaBytes)
+			case OverflowDisconnect, OverflowBlock:
+				select {
+				case <-stalled.overflow:
+				case <-time.After(time.Second):
+					t.Fatal("stalled subscriber was not disconnected")
+				}
+				assert.Equal(t, 1, m.disconnectionCount())
+			}
+			assert.Equal(t, 0, m.dropsOf(fast.id))
+		})
+	}
+}
+
+func TestAllVaaSubscribers_SlowConsumer(t *testing.T) {
+	const (
+		queueSize = 100
+		batchSize = queueSize / 2
+		total     = 1000
+	)
+
+	logger := zaptest.NewLogger(t)
+	m := newFakeMetrics()
+	avs := NewAllVaaSubscribers(logger, WithQueueSize(queueSize), WithOverflowPolicy(OverflowDisconnect, 0), WithMetrics(m))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		avs.Start(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	stalled := avs.Register(nil)
+	fast := avs.Register(nil)
+	<-stalled.ready
+	<-fast.ready
+
+	// the producer is paced by the fast subscriber, so only the stalled subscriber overflows.
+	start := time.Now()
+	for i := 0; i < total; i++ {
+		_ = avs.HandleVAA(createVAABytes(uint64(i)))
+		if (i+1)%batchSize == 0 {
+			receive(t, fast.ch, batchSize, 5*time.Second)
+		}
+	}
+
+	assert.Less(t, time.Since(start), time.Second)
+	select {
+	case <-stalled.overflow:
+	case <-time.After(time.Second):
+		t.Fatal("stalled subscriber was not disconnected")
+	}
+	assert.Equal(t, queueSize, len(stalled.ch))
+}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	for _, s := range []string{"drop-oldest", "disconnect", "block"} {
+		p, err := ParseOverflowPolicy(s)
+		assert.Nil(t, err)
+		assert.Equal(t, OverflowPolicy(s), p)
+	}
+	_, err := ParseOverflowPolicy("unknown")
+	assert.NotNil(t, err)
+}
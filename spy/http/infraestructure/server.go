@@ -3,7 +3,9 @@ package infraestructure
 import (
 	"github.com/deltaswapio/deltaswap-explorer/common/health"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 )
 
@@ -13,12 +15,15 @@ type Server struct {
 	logger *zap.Logger
 }
 
-func NewServer(logger *zap.Logger, port string, pprofEnabled bool, checks ...health.Check) *Server {
+func NewServer(logger *zap.Logger, port string, pprofEnabled bool, metricsEnabled bool, checks ...health.Check) *Server {
 	ctrl := NewController(checks, logger)
 	app := fiber.New(fiber.Config{DisableStartupMessage: true})
 	if pprofEnabled {
 		app.Use(pprof.New())
 	}
+	if metricsEnabled {
+		app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+	}
 
 	api := app.Group("/api")
 	api.Get("/health", ctrl.HealthCheck)
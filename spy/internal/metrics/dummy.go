@@ -0,0 +1,22 @@
+package metrics
+
+// DummyMetrics is a dummy implementation of Metric interface.
+type DummyMetrics struct {
+}
+
+// NewDummyMetrics returns a new instance of DummyMetrics.
+func NewDummyMetrics() *DummyMetrics {
+	return &DummyMetrics{}
+}
+
+// SetSubscriberQueueDepth sets the number of VAAs waiting in the queue of a subscriber.
+func (m *DummyMetrics) SetSubscriberQueueDepth(subscriberType, id string, depth int) {}
+
+// IncSubscriberDrops increments the VAAs dropped because the queue of a subscriber was full.
+func (m *DummyMetrics) IncSubscriberDrops(subscriberType, id string) {}
+
+// IncSubscriberDisconnections increments the subscribers disconnected because their queue overflowed.
+func (m *DummyMetrics) IncSubscriberDisconnections(subscriberType string) {}
+
+// RemoveSubscriber removes the metrics of a subscriber.
+func (m *DummyMetrics) RemoveSubscriber(subscriberType, id string) {}
@@ -0,0 +1,17 @@
+package metrics
+
+const serviceName = "deltaswapscan-spy"
+
+// Subscriber types used as label in the metrics.
+const (
+	SignedVaaSubscriber = "signed-vaa"
+	AllVaaSubscriber    = "all-vaa"
+)
+
+// Metrics is a metrics interface.
+type Metrics interface {
+	SetSubscriberQueueDepth(subscriberType, id string, depth int)
+	IncSubscriberDrops(subscriberType, id string)
+	IncSubscriberDisconnections(subscriberType string)
+	RemoveSubscriber(subscriberType, id string)
+}
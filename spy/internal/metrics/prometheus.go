@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PrometheusMetrics is a metrics implementation for Prometheus.
+type PrometheusMetrics struct {
+	queueDepth     *prometheus.GaugeVec
+	drops          *prometheus.CounterVec
+	disconnections *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates a new PrometheusMetrics.
+func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+	queueDepth := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "spy_subscriber_queue_depth",
+			Help: "Number of vaas waiting in the queue of a subscriber",
+			ConstLabels: map[string]string{
+				"environment": environment,
+				"service":     serviceName,
+			},
+		}, []string{"type", "subscription"})
+
+	drops := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spy_subscriber_dropped_vaas_count",
+			Help: "Total number of vaas dropped because the queue of a subscriber was full",
+			ConstLabels: map[string]string{
+				"environment": environment,
+				"service":     serviceName,
+			},
+		}, []string{"type", "subscription"})
+
+	disconnections := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "spy_subscriber_disconnections_count",
+			Help: "Total number of subscribers disconnected because their queue overflowed",
+			ConstLabels: map[string]string{
+				"environment": environment,
+				"service":     serviceName,
+			},
+		}, []string{"type"})
+
+	return &PrometheusMetrics{
+		queueDepth:     queueDepth,
+		drops:          drops,
+		disconnections: disconnections,
+	}
+}
+
+// SetSubscriberQueueDepth sets the number of VAAs waiting in the queue of a subscriber.
+func (m *PrometheusMetrics) SetSubscriberQueueDepth(subscriberType, id string, depth int) {
+	m.queueDepth.WithLabelValues(subscriberType, id).Set(float64(depth))
+}
+
+// IncSubscriberDrops increments the VAAs dropped because the queue of a subscriber was full.
+func (m *PrometheusMetrics) IncSubscriberDrops(subscriberType, id string) {
+	m.drops.WithLabelValues(subscriberType, id).Inc()
+}
+
+// IncSubscriberDisconnections increments the subscribers disconnected because their queue overflowed.
+func (m *PrometheusMetrics) IncSubscriberDisconnections(subscriberType string) {
+	m.disconnections.WithLabelValues(subscriberType).Inc()
+}
+
+// RemoveSubscriber removes the metrics of a subscriber.
+func (m *PrometheusMetrics) RemoveSubscriber(subscriberType, id string) {
+	m.queueDepth.DeleteLabelValues(subscriberType, id)
+	m.drops.DeleteLabelValues(subscriberType, id)
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/deltaswapio/deltaswap/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Emitter identifies the VAAs of an emitter.
+type Emitter struct {
+	ChainID vaa.ChainID
+	Address vaa.Address
+}
+
+// EmitterSequence is the first sequence of an emitter to replay.
+type EmitterSequence struct {
+	Emitter
+	Sequence uint64
+}
+
+// ReplayQuery defines the VAAs to replay. Either FromTimestamp or FromSequences must be set.
+type ReplayQuery struct {
+	// FromTimestamp replays the VAAs of the emitters with a timestamp greater than or equal to it.
+	// If Emitters is empty, the VAAs of all the emitters are replayed.
+	FromTimestamp *time.Time
+	Emitters      []Emitter
+	// FromSequences replays the VAAs of each emitter from its sequence.
+	FromSequences []EmitterSequence
+}
+
+// VaaDoc is a stored VAA to replay.
+type VaaDoc struct {
+	ID           string
+	EmitterChain vaa.ChainID
+	EmitterAddr  string
+	Sequence     uint64
+	Vaa          []byte
+}
+
+// storedVaa is the projection of a document of the vaas collection.
+type storedVaa struct {
+	ID           string      `bson:"_id"`
+	EmitterChain vaa.ChainID `bson:"emitterChain"`
+	EmitterAddr  string      `bson:"emitterAddr"`
+	Sequence     string      `bson:"sequence"`
+	Timestamp    time.Time   `bson:"timestamp"`
+	Vaa          []byte      `bson:"vaas"`
+}
+
+// Repository reads the stored VAAs.
+type Repository struct {
+	db     *mongo.Database
+	vaas   *mongo.Collection
+	logger *zap.Logger
+}
+
+// NewRepository creates a new VAA repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		vaas:   db.Collection("vaas"),
+		logger: logger.With(zap.String("module", "VaaRepository")),
+	}
+}
+
+// Replay calls fn for each VAA that matches the query, in sequence order for each emitter.
+func (r *Repository) Replay(ctx context.Context, q *ReplayQuery, fn func(*VaaDoc) error) error {
+	if q.FromTimestamp != nil {
+		filter := bson.M{"timestamp": bson.M{"$gte": q.FromTimestamp}}
+		if len(q.Emitters) > 0 {
+			emitters := make(bson.A, 0, len(q.Emitters))
+			for _, e := range q.Emitters {
+				emitters = append(emitters, bson.M{"emitterChain": e.ChainID, "emitterAddr": e.Address.String()})
+			}
+			filter["$or"] = emitters
+		}
+		return r.iterate(ctx, filter, 0, fn)
+	}
+
+	for _, s := range q.FromSequences {
+		filter, err := r.sequenceFilter(ctx, s)
+		if err != nil {
+			return err
+		}
+		if err := r.iterate(ctx, filter, s.Sequence, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sequenceFilter returns the filter of the VAAs of an emitter from a sequence.
+// The sequence is stored as a string, so the VAAs are searched from the timestamp of the VAA with that sequence.
+// If the VAA is not found, all the VAAs of the emitter are searched.
+func (r *Repository) sequenceFilter(ctx context.Context, s EmitterSequence) (bson.M, error) {
+	filter := bson.M{"emitterChain": s.ChainID, "emitterAddr": s.Address.String()}
+
+	var first storedVaa
+	id := fmt.Sprintf("%d/%s/%d", s.ChainID, s.Address.String(), s.Sequence)
+	opts := options.FindOne().SetProjection(bson.M{"timestamp": 1})
+	err := r.vaas.FindOne(ctx, bson.M{"_id": id}, opts).Decode(&first)
+	switch {
+	case err == nil:
+		filter["timestamp"] = bson.M{"$gte": first.Timestamp}
+	case !errors.Is(err, mongo.ErrNoDocuments):
+		r.logger.Error("failed to find first vaa to replay", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return filter, nil
+}
+
+// iterate finds the VAAs that match the filter with a sequence greater than or equal to fromSequence.
+// The VAAs are read in timestamp order, using the indexes of the collection, and the VAAs with the same
+// timestamp are sorted by emitter and sequence. The timestamps of the VAAs of an emitter don't decrease
+// with the sequence, so each emitter is replayed in sequence order.
+func (r *Repository) iterate(ctx context.Context, filter bson.M, fromSequence uint64, fn func(*VaaDoc) error) error {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}}).
+		SetProjection(bson.M{"emitterChain": 1, "emitterAddr": 1, "sequence": 1, "timestamp": 1, "vaas": 1})
+	cur, err := r.vaas.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to find vaas to replay", zap.Error(err))
+		return err
+	}
+	defer cur.Close(ctx)
+
+	var batch []*VaaDoc
+	var batchTimestamp time.Time
+	flush := func() error {
+		sort.Slice(batch, func(i, j int) bool {
+			if batch[i].EmitterChain != batch[j].EmitterChain {
+				return batch[i].EmitterChain < batch[j].EmitterChain
+			}
+			if batch[i].EmitterAddr != batch[j].EmitterAddr {
+				return batch[i].EmitterAddr < batch[j].EmitterAddr
+			}
+			return batch[i].Sequence < batch[j].Sequence
+		})
+		for _, doc := range batch {
+			if err := fn(doc); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for cur.Next(ctx) {
+		var stored storedVaa
+		if err := cur.Decode(&stored); err != nil {
+			r.logger.Error("failed to decode vaa to replay", zap.Error(err))
+			return err
+		}
+		sequence, err := strconv.ParseUint(stored.Sequence, 10, 64)
+		if err != nil {
+			r.logger.Warn("skipping vaa with invalid sequence", zap.String("id", stored.ID), zap.Error(err))
+			continue
+		}
+		if sequence < fromSequence {
+			continue
+		}
+		if !stored.Timestamp.Equal(batchTimestamp) {
+			if err := flush(); err != nil {
+				return err
+			}
+			batchTimestamp = stored.Timestamp
+		}
+		batch = append(batch, &VaaDoc{
+			ID:           stored.ID,
+			EmitterChain: stored.EmitterChain,
+			EmitterAddr:  stored.EmitterAddr,
+			Sequence:     sequence,
+			Vaa:          stored.Vaa,
+		})
+	}
+	if err := cur.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
@@ -388,6 +388,9 @@ func (a *apiDeltachain) fetchDeltachainTx(
 
 	// Verify if this transaction is from osmosis by deltachain
 	if a.isOsmosisTx(deltachainTx) {
+		if a.osmosisRateLimiter == nil {
+			return nil, errChainNotConfigured(ChainIDOsmosis)
+		}
 		osmosisTx, err := fetchOsmosisDetail(ctx, a.osmosisUrl, a.osmosisRateLimiter, deltachainTx.sequence, deltachainTx.timestamp, deltachainTx.srcChannel, deltachainTx.dstChannel)
 		if err != nil {
 			return nil, err
@@ -408,6 +411,9 @@ func (a *apiDeltachain) fetchDeltachainTx(
 
 	// Verify if this transaction is from kujira by deltachain
 	if a.isKujiraTx(deltachainTx) {
+		if a.kujiraRateLimiter == nil {
+			return nil, errChainNotConfigured(ChainIDKujira)
+		}
 		kujiraTx, err := fetchKujiraDetail(ctx, a.kujiraUrl, a.kujiraRateLimiter, deltachainTx.sequence, deltachainTx.timestamp, deltachainTx.srcChannel, deltachainTx.dstChannel)
 		if err != nil {
 			return nil, err
@@ -428,6 +434,9 @@ func (a *apiDeltachain) fetchDeltachainTx(
 
 	// Verify if this transaction is from evmos by deltachain
 	if a.isEvmosTx(deltachainTx) {
+		if a.evmosRateLimiter == nil {
+			return nil, errChainNotConfigured(ChainIDEvmos)
+		}
 		evmosTx, err := fetchEvmosDetail(ctx, a.evmosUrl, a.evmosRateLimiter, deltachainTx.sequence, deltachainTx.timestamp, deltachainTx.srcChannel, deltachainTx.dstChannel)
 		if err != nil {
 			return nil, err
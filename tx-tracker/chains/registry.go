@@ -0,0 +1,173 @@
+package chains
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/deltaswapio/deltaswap-explorer/txtracker/config"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+)
+
+// FetcherKind defines how the transactions of a chain are fetched.
+type FetcherKind string
+
+const (
+	FetcherKindEvm        FetcherKind = "evm"
+	FetcherKindCosmos     FetcherKind = "cosmos"
+	FetcherKindSolana     FetcherKind = "solana"
+	FetcherKindAptos      FetcherKind = "aptos"
+	FetcherKindSui        FetcherKind = "sui"
+	FetcherKindAlgorand   FetcherKind = "algorand"
+	FetcherKindDeltachain FetcherKind = "deltachain"
+	// FetcherKindSei fetches the transactions of Sei through the deltachain gateway.
+	// It requires the deltachain provider.
+	FetcherKindSei FetcherKind = "sei"
+	// FetcherKindIbc is used by the chains connected to the deltachain gateway (e.g.: osmosis).
+	// They don't have a fetcher, their provider is used to find the origin of the deltachain transactions.
+	FetcherKindIbc FetcherKind = "ibc"
+)
+
+// ChainFetcher fetches the details of a transaction from the RPC/API service of a chain.
+type ChainFetcher interface {
+	FetchTx(ctx context.Context, txHash string) (*TxDetail, error)
+}
+
+// fetchFunc is the signature of the functions that fetch a transaction from a RPC/API service.
+type fetchFunc func(ctx context.Context, rateLimiter *time.Ticker, baseUrl string, txHash string) (*TxDetail, error)
+
+// endpoint contains the base URL of the RPC/API service of a chain and its request rate limiter.
+type endpoint struct {
+	baseUrl     string
+	rateLimiter *time.Ticker
+}
+
+// rpcFetcher is a ChainFetcher that calls a fetch function with the endpoint of the chain.
+type rpcFetcher struct {
+	fetch    fetchFunc
+	endpoint *endpoint
+}
+
+// FetchTx fetches the details of a transaction.
+func (f *rpcFetcher) FetchTx(ctx context.Context, txHash string) (*TxDetail, error) {
+	return f.fetch(ctx, f.endpoint.rateLimiter, f.endpoint.baseUrl, txHash)
+}
+
+// Registry contains the fetchers of the configured chains.
+type Registry struct {
+	fetchers map[sdk.ChainID]ChainFetcher
+}
+
+// NewRegistry creates a registry with a fetcher for each provider.
+func NewRegistry(providers []config.RpcProvider, p2pNetwork string) (*Registry, error) {
+
+	// Initialize the endpoints first, some fetchers use the endpoints of other chains.
+	endpoints := make(map[sdk.ChainID]*endpoint, len(providers))
+	for _, p := range providers {
+		chainID := sdk.ChainID(p.ChainID)
+		if _, ok := endpoints[chainID]; ok {
+			return nil, fmt.Errorf("duplicated rpc provider for chain %s", chainID)
+		}
+		if p.BaseUrl == "" {
+			return nil, fmt.Errorf("rpc provider for chain %s has no base URL", chainID)
+		}
+		if p.RequestsPerMinute == 0 {
+			return nil, fmt.Errorf("rpc provider for chain %s has no requests per minute", chainID)
+		}
+		endpoints[chainID] = &endpoint{
+			baseUrl:     p.BaseUrl,
+			rateLimiter: newRateLimiter(p.RequestsPerMinute),
+		}
+	}
+
+	r := &Registry{fetchers: make(map[sdk.ChainID]ChainFetcher, len(providers))}
+	for _, p := range providers {
+		chainID := sdk.ChainID(p.ChainID)
+		e := endpoints[chainID]
+
+		var fetch fetchFunc
+		switch FetcherKind(p.Kind) {
+		case FetcherKindEvm:
+			fetch = fetchEthTx
+		case FetcherKindCosmos:
+			fetch = fetchCosmosTx
+		case FetcherKindSolana:
+			fetch = fetchSolanaTx
+		case FetcherKindAptos:
+			fetch = fetchAptosTx
+		case FetcherKindSui:
+			fetch = fetchSuiTx
+		case FetcherKindAlgorand:
+			fetch = fetchAlgorandTx
+		case FetcherKindDeltachain:
+			api := &apiDeltachain{p2pNetwork: p2pNetwork}
+			if osmosis, ok := endpoints[ChainIDOsmosis]; ok {
+				api.osmosisUrl, api.osmosisRateLimiter = osmosis.baseUrl, osmosis.rateLimiter
+			}
+			if kujira, ok := endpoints[ChainIDKujira]; ok {
+				api.kujiraUrl, api.kujiraRateLimiter = kujira.baseUrl, kujira.rateLimiter
+			}
+			if evmos, ok := endpoints[ChainIDEvmos]; ok {
+				api.evmosUrl, api.evmosRateLimiter = evmos.baseUrl, evmos.rateLimiter
+			}
+			fetch = api.fetchDeltachainTx
+		case FetcherKindSei:
+			deltachain, ok := endpoints[sdk.ChainIDDeltachain]
+			if !ok {
+				return nil, fmt.Errorf("rpc provider for chain %s requires the provider for chain %s", chainID, sdk.ChainIDDeltachain)
+			}
+			api := &apiSei{
+				deltachainUrl:         deltachain.baseUrl,
+				deltachainRateLimiter: deltachain.rateLimiter,
+				p2pNetwork:            p2pNetwork,
+			}
+			fetch = api.fetchSeiTx
+		case FetcherKindIbc:
+			continue
+		default:
+			return nil, fmt.Errorf("rpc provider for chain %s has an unknown kind %q", chainID, p.Kind)
+		}
+
+		r.Register(chainID, &rpcFetcher{fetch: fetch, endpoint: e})
+	}
+
+	return r, nil
+}
+
+// Register sets the fetcher of a chain, replacing the previous one.
+func (r *Registry) Register(chainID sdk.ChainID, fetcher ChainFetcher) {
+	r.fetchers[chainID] = fetcher
+}
+
+// FetchTx fetches the details of a transaction from the RPC/API service of the chain.
+func (r *Registry) FetchTx(ctx context.Context, chainID sdk.ChainID, txHash string) (*TxDetail, error) {
+
+	fetcher, ok := r.fetchers[chainID]
+	if !ok {
+		return nil, errChainNotConfigured(chainID)
+	}
+
+	txDetail, err := fetcher.FetchTx(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tx information: %w", err)
+	}
+
+	return txDetail, nil
+}
+
+// errChainNotConfigured returns the error for a chain without rpc provider.
+func errChainNotConfigured(chainID sdk.ChainID) error {
+	return fmt.Errorf("%w: no rpc provider configured for chain %s", ErrChainNotSupported, chainID)
+}
+
+// newRateLimiter converts "requests per minute" into the associated *time.Ticker.
+func newRateLimiter(requestsPerMinute uint16) *time.Ticker {
+
+	division := float64(time.Minute) / float64(time.Duration(requestsPerMinute))
+	roundedUp := math.Ceil(division)
+
+	duration := time.Duration(roundedUp)
+
+	return time.NewTicker(duration)
+}
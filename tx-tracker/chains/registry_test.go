@@ -0,0 +1,318 @@
+package chains
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/deltaswapio/deltaswap-explorer/txtracker/config"
+	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStubServer creates a server that replies with recorded responses.
+// JSON-RPC requests are matched by method and the other requests by URI.
+func newStubServer(t *testing.T, responses map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			var req struct {
+				ID     json.RawMessage `json:"id"`
+				Method string          `json:"method"`
+			}
+			if err := json.Unmarshal(body, &req); err == nil && req.Method != "" {
+				result, ok := responses[req.Method]
+				if !ok {
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":` + result + `}`))
+				return
+			}
+		}
+		response, ok := responses[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestRegistry(t *testing.T, chainID sdk.ChainID, kind FetcherKind, baseUrl string) *Registry {
+	providers := []config.RpcProvider{
+		{ChainID: uint16(chainID), Kind: string(kind), BaseUrl: baseUrl, RequestsPerMinute: 60000},
+	}
+	registry, err := NewRegistry(providers, "mainnet")
+	assert.Nil(t, err)
+	return registry
+}
+
+func TestRegistry_FetchTx(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		chainID   sdk.ChainID
+		kind      FetcherKind
+		txHash    string
+		responses map[string]string
+		expected  *TxDetail
+	}{
+		{
+			name:    "evm",
+			chainID: sdk.ChainIDBSC,
+			kind:    FetcherKindEvm,
+			txHash:  "6C2E9BCE3BB1F4B9E3C5D5A1B7E1A4F3A2B1C0D9E8F7A6B5C4D3E2F1A0B9C8D7",
+			responses: map[string]string{
+				"eth_getTransactionByHash": `{"blockHash":"0x3b4e0b7a1a5b3a0f6f1e2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6e5f4a3b2","blockNumber":"0x1c9c380","from":"0x5A58505a96D1dbf8dF91cB21B54419FC36e93fdE","to":"0xB6F6D86a8f9879A9c87f643768d9efc38c1Da6E7"}`,
+			},
+			expected: &TxDetail{
+				From:         "0x5a58505a96d1dbf8df91cb21b54419fc36e93fde",
+				NativeTxHash: "0x6c2e9bce3bb1f4b9e3c5d5a1b7e1a4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7",
+			},
+		},
+		{
+			name:    "cosmos",
+			chainID: sdk.ChainIDTerra2,
+			kind:    FetcherKindCosmos,
+			txHash:  "A7D5A5B4E1F6C2D3B4A5968778695A4B3C2D1E0F9A8B7C6D5E4F3A2B1C0D9E8F",
+			responses: map[string]string{
+				"/cosmos/tx/v1beta1/txs/A7D5A5B4E1F6C2D3B4A5968778695A4B3C2D1E0F9A8B7C6D5E4F3A2B1C0D9E8F": `{"tx_response":{"txhash":"A7D5A5B4E1F6C2D3B4A5968778695A4B3C2D1E0F9A8B7C6D5E4F3A2B1C0D9E8F","timestamp":"2023-05-02T14:21:33Z","tx":{"body":{"messages":[{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","sender":"terra1x46rqay4d3cssq8gxxvqz8xt6nwlz4td20k38v"}]}}}}`,
+			},
+			expected: &TxDetail{
+				From:         "terra1x46rqay4d3cssq8gxxvqz8xt6nwlz4td20k38v",
+				NativeTxHash: "A7D5A5B4E1F6C2D3B4A5968778695A4B3C2D1E0F9A8B7C6D5E4F3A2B1C0D9E8F",
+			},
+		},
+		{
+			name:    "algorand",
+			chainID: sdk.ChainIDAlgorand,
+			kind:    FetcherKindAlgorand,
+			txHash:  "QXB2F4ZLJH6TZX5NYG5C6SH6N2NWHDMZOMGWFFL3RGRVK4ZXHCVA",
+			responses: map[string]string{
+				"/v2/transactions/QXB2F4ZLJH6TZX5NYG5C6SH6N2NWHDMZOMGWFFL3RGRVK4ZXHCVA": `{"current-round":29284567,"transaction":{"id":"QXB2F4ZLJH6TZX5NYG5C6SH6N2NWHDMZOMGWFFL3RGRVK4ZXHCVA","sender":"TPFKQBFJ7PSPUK5ZKDBFZ2HTSHSKVVFTFVJTG3IWCXAN2RGY6G6KC5DI6A","round-time":1683037293}}`,
+			},
+			expected: &TxDetail{
+				From:         "TPFKQBFJ7PSPUK5ZKDBFZ2HTSHSKVVFTFVJTG3IWCXAN2RGY6G6KC5DI6A",
+				NativeTxHash: "QXB2F4ZLJH6TZX5NYG5C6SH6N2NWHDMZOMGWFFL3RGRVK4ZXHCVA",
+			},
+		},
+		{
+			name:    "aptos",
+			chainID: sdk.ChainIDAptos,
+			kind:    FetcherKindAptos,
+			txHash:  "000000000000000000000000000000000000000000000000000000000000002a",
+			responses: map[string]string{
+				"/accounts/" + aptosCoreContractAddress + "/events/" + aptosCoreContractAddress + "::state::DeltaswapMessageHandle/event?start=42&limit=1": `[{"version":"151290546","sequence_number":"42"}]`,
+				"/transactions/by_version/151290546": `{"version":"151290546","hash":"0x9b1b2a3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8","sender":"0x2f6bd3ea0ae9a9ac3ce0f2e1dbb0f5e59e8c5b4f5b0fa84d3f4b8c7b5d7a5c49","timestamp":"1683037293000000"}`,
+			},
+			expected: &TxDetail{
+				From:         "0x2f6bd3ea0ae9a9ac3ce0f2e1dbb0f5e59e8c5b4f5b0fa84d3f4b8c7b5d7a5c49",
+				NativeTxHash: "0x9b1b2a3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8",
+			},
+		},
+		{
+			name:    "sui",
+			chainID: sdk.ChainIDSui,
+			kind:    FetcherKindSui,
+			txHash:  "6aTNBNzCvJ9dbnVGWgLbxBKqfNUc1VBDTxHzHgfSj3Fp",
+			responses: map[string]string{
+				"sui_getTransactionBlock": `{"digest":"6aTNBNzCvJ9dbnVGWgLbxBKqfNUc1VBDTxHzHgfSj3Fp","timestampMs":"1683037293000","transaction":{"data":{"sender":"0x0b3b2e3ab5d8b5e6c1b0a9a0f7e2d6c5b4a39281706f5e4d3c2b1a0f9e8d7c6b"}}}`,
+			},
+			expected: &TxDetail{
+				From:         "0x0b3b2e3ab5d8b5e6c1b0a9a0f7e2d6c5b4a39281706f5e4d3c2b1a0f9e8d7c6b",
+				NativeTxHash: "6aTNBNzCvJ9dbnVGWgLbxBKqfNUc1VBDTxHzHgfSj3Fp",
+			},
+		},
+		{
+			name:    "solana",
+			chainID: sdk.ChainIDSolana,
+			kind:    FetcherKindSolana,
+			txHash:  "0e9b5a1c3f2d4e6b8a7c9d0f1e2a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c",
+			responses: map[string]string{
+				"getSignaturesForAddress": `[{"signature":"3xKXuGzRMMB8nWZpn8ULMzbDjLmMq8hSQK6Yv3tjrQnZ7yXhbTgGgBwPq5wqYTBfMZ5PfXHaDr8Vbfn3rQ4ZGfAE"}]`,
+				"getTransaction":          `{"blockTime":1683037293,"meta":{"err":null,"innerInstructions":[{"instructions":[{"parsed":{"type":"transfer","info":{"amount":"1000","authority":"7RmuCM2LMd9bDoA8P3UCTG9K4Vbth4TfkvJxRMKp6YTq","destination":"GwrYPLnP1Shi8Mk8RKAcbvvMwNuGAEnPcVVqwx6a2GqA","source":"8y9xkQ8YBUVtDvSMrEPrHSLNfTZbcWqZFhVcm5ZUh5nT"}}}]}]},"transaction":{"message":{"accountKeys":[{"pubkey":"7RmuCM2LMd9bDoA8P3UCTG9K4Vbth4TfkvJxRMKp6YTq","signer":true},{"pubkey":"GwrYPLnP1Shi8Mk8RKAcbvvMwNuGAEnPcVVqwx6a2GqA","signer":false}]},"signatures":["3xKXuGzRMMB8nWZpn8ULMzbDjLmMq8hSQK6Yv3tjrQnZ7yXhbTgGgBwPq5wqYTBfMZ5PfXHaDr8Vbfn3rQ4ZGfAE"]}}`,
+			},
+			expected: &TxDetail{
+				From:         "7RmuCM2LMd9bDoA8P3UCTG9K4Vbth4TfkvJxRMKp6YTq",
+				NativeTxHash: "3xKXuGzRMMB8nWZpn8ULMzbDjLmMq8hSQK6Yv3tjrQnZ7yXhbTgGgBwPq5wqYTBfMZ5PfXHaDr8Vbfn3rQ4ZGfAE",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStubServer(t, tt.responses)
+			registry := newTestRegistry(t, tt.chainID, tt.kind, server.URL)
+
+			txDetail, err := registry.FetchTx(context.Background(), tt.chainID, tt.txHash)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, txDetail)
+		})
+	}
+}
+
+func TestRegistry_FetchTx_NotFound(t *testing.T) {
+	server := newStubServer(t, map[string]string{"eth_getTransactionByHash": `null`})
+	registry := newTestRegistry(t, sdk.ChainIDEthereum, FetcherKindEvm, server.URL)
+
+	_, err := registry.FetchTx(context.Background(), sdk.ChainIDEthereum, "6c2e9bce3bb1f4b9e3c5d5a1b7e1a4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7")
+	assert.True(t, errors.Is(err, ErrTransactionNotFound))
+}
+
+func TestRegistry_FetchTx_ChainNotConfigured(t *testing.T) {
+	registry := newTestRegistry(t, sdk.ChainIDEthereum, FetcherKindEvm, "http://localhost")
+
+	_, err := registry.FetchTx(context.Background(), sdk.ChainIDPolygon, "6c2e9bce3bb1f4b9e3c5d5a1b7e1a4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7")
+	assert.True(t, errors.Is(err, ErrChainNotSupported))
+	assert.Contains(t, err.Error(), "no rpc provider configured")
+}
+
+func TestRegistry_FetchTx_IbcChain(t *testing.T) {
+	registry := newTestRegistry(t, ChainIDOsmosis, FetcherKindIbc, "http://localhost")
+
+	_, err := registry.FetchTx(context.Background(), ChainIDOsmosis, "6c2e9bce3bb1f4b9e3c5d5a1b7e1a4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7")
+	assert.True(t, errors.Is(err, ErrChainNotSupported))
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry, err := NewRegistry(nil, "mainnet")
+	assert.Nil(t, err)
+
+	expected := &TxDetail{From: "sender", NativeTxHash: "hash"}
+	registry.Register(sdk.ChainIDEthereum, fetcherFunc(func(ctx context.Context, txHash string) (*TxDetail, error) {
+		return expected, nil
+	}))
+
+	txDetail, err := registry.FetchTx(context.Background(), sdk.ChainIDEthereum, "hash")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, txDetail)
+}
+
+func TestNewRegistry_InvalidProviders(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		providers []config.RpcProvider
+	}{
+		{
+			name:      "unknown kind",
+			providers: []config.RpcProvider{{ChainID: 2, Kind: "bitcoin", BaseUrl: "http://localhost", RequestsPerMinute: 1}},
+		},
+		{
+			name:      "missing base url",
+			providers: []config.RpcProvider{{ChainID: 2, Kind: "evm", RequestsPerMinute: 1}},
+		},
+		{
+			name:      "missing requests per minute",
+			providers: []config.RpcProvider{{ChainID: 2, Kind: "evm", BaseUrl: "http://localhost"}},
+		},
+		{
+			name: "duplicated chain",
+			providers: []config.RpcProvider{
+				{ChainID: 2, Kind: "evm", BaseUrl: "http://localhost", RequestsPerMinute: 1},
+				{ChainID: 2, Kind: "evm", BaseUrl: "http://localhost", RequestsPerMinute: 1},
+			},
+		},
+		{
+			name:      "sei without deltachain",
+			providers: []config.RpcProvider{{ChainID: uint16(sdk.ChainIDSei), Kind: "sei", BaseUrl: "http://localhost", RequestsPerMinute: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistry(tt.providers, "mainnet")
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+// templateVarRegexp matches the variables of the deployment templates, e.g. {{ .ETHEREUM_BASE_URL }}.
+var templateVarRegexp = regexp.MustCompile(`{{ \.\w+ }}`)
+
+// loadDeployedProviders loads the rpc providers of a deployment config map.
+// The template variables are replaced by test values.
+func loadDeployedProviders(t *testing.T, configMapPath string) []config.RpcProvider {
+	b, err := os.ReadFile(configMapPath)
+	require.Nil(t, err)
+
+	// the json file is the indented block of the rpc-providers.json key.
+	var doc strings.Builder
+	inDoc := false
+	for _, line := range strings.Split(string(b), "\n") {
+		if strings.TrimSpace(line) == "rpc-providers.json: |" {
+			inDoc = true
+			continue
+		}
+		if inDoc {
+			if !strings.HasPrefix(line, "    ") {
+				break
+			}
+			doc.WriteString(line + "\n")
+		}
+	}
+	require.NotEmpty(t, doc.String(), "rpc-providers.json not found in %s", configMapPath)
+
+	data := templateVarRegexp.ReplaceAllStringFunc(doc.String(), func(v string) string {
+		if strings.HasSuffix(v, "_REQUESTS_PER_MINUTE }}") {
+			return "60"
+		}
+		return "http://localhost"
+	})
+	path := filepath.Join(t.TempDir(), "rpc-providers.json")
+	require.Nil(t, os.WriteFile(path, []byte(data), 0o600))
+
+	providers, err := config.LoadRpcProviders(path)
+	require.Nil(t, err)
+	return providers
+}
+
+func TestNewRegistry_DeployedProviders(t *testing.T) {
+
+	configMaps := []string{
+		"../../deploy/tx-tracker/configmap.yaml",
+		"../../deploy/tx-tracker-backfiller/tx-tracker-backfiller-job.yaml",
+	}
+
+	for _, configMap := range configMaps {
+		t.Run(configMap, func(t *testing.T) {
+			providers := loadDeployedProviders(t, configMap)
+			registry, err := NewRegistry(providers, "mainnet")
+			require.Nil(t, err)
+
+			// every provider that is not an ibc chain has a fetcher, keyed by a known chain.
+			for _, p := range providers {
+				chainID := sdk.ChainID(p.ChainID)
+				if FetcherKind(p.Kind) == FetcherKindIbc {
+					continue
+				}
+				_, ok := registry.fetchers[chainID]
+				assert.True(t, ok, "chain %d has no fetcher", p.ChainID)
+				if FetcherKind(p.Kind) == FetcherKindDeltachain {
+					assert.Equal(t, sdk.ChainIDDeltachain, chainID)
+				}
+			}
+		})
+	}
+}
+
+// fetcherFunc is a ChainFetcher implemented by a function.
+type fetcherFunc func(ctx context.Context, txHash string) (*TxDetail, error)
+
+func (f fetcherFunc) FetchTx(ctx context.Context, txHash string) (*TxDetail, error) {
+	return f(ctx, txHash)
+}
@@ -41,8 +41,15 @@ func main() {
 		log.Fatal("Failed to load config: ", err)
 	}
 
-	// Initialize rate limiters
-	chains.Initialize(&cfg.RpcProviderSettings)
+	// Initialize the fetchers of the configured chains
+	providers, err := config.LoadRpcProviders(cfg.RpcProvidersPath)
+	if err != nil {
+		log.Fatal("Failed to load rpc providers: ", err)
+	}
+	registry, err := chains.NewRegistry(providers, cfg.P2pNetwork)
+	if err != nil {
+		log.Fatal("Failed to initialize chain registry: ", err)
+	}
 
 	// Initialize logger
 	rootLogger := logger.New("backfiller", logger.WithLevel(cfg.LogLevel))
@@ -102,14 +109,13 @@ func main() {
 	for i := uint(0); i < cfg.NumWorkers; i++ {
 		name := fmt.Sprintf("worker-%d", i)
 		p := consumerParams{
-			logger:              makeLogger(rootLogger, name),
-			rpcProviderSettings: &cfg.RpcProviderSettings,
-			repository:          repository,
-			queueRx:             queue,
-			wg:                  &wg,
-			totalDocuments:      totalDocuments,
-			processedDocuments:  &processedDocuments,
-			p2pNetwork:          cfg.P2pNetwork,
+			logger:             makeLogger(rootLogger, name),
+			registry:           registry,
+			repository:         repository,
+			queueRx:            queue,
+			wg:                 &wg,
+			totalDocuments:     totalDocuments,
+			processedDocuments: &processedDocuments,
 		}
 		go consume(rootCtx, &p)
 	}
@@ -236,14 +242,13 @@ func produce(ctx context.Context, params *producerParams) {
 
 // consumerParams contains the parameters for the consumer goroutine.
 type consumerParams struct {
-	logger              *zap.Logger
-	rpcProviderSettings *config.RpcProviderSettings
-	repository          *consumer.Repository
-	queueRx             <-chan consumer.GlobalTransaction
-	wg                  *sync.WaitGroup
-	totalDocuments      uint64
-	processedDocuments  *atomic.Uint64
-	p2pNetwork          string
+	logger             *zap.Logger
+	registry           *chains.Registry
+	repository         *consumer.Repository
+	queueRx            <-chan consumer.GlobalTransaction
+	wg                 *sync.WaitGroup
+	totalDocuments     uint64
+	processedDocuments *atomic.Uint64
 }
 
 // consume reads VAA IDs from a channel, processes them, and updates the database accordingly.
@@ -305,7 +310,7 @@ func consume(ctx context.Context, params *consumerParams) {
 				TxHash:    *v.TxHash,
 				Overwrite: true, // Overwrite old contents
 			}
-			_, err := consumer.ProcessSourceTx(ctx, params.logger, params.rpcProviderSettings, params.repository, &p, params.p2pNetwork)
+			_, err := consumer.ProcessSourceTx(ctx, params.logger, params.registry, params.repository, &p)
 			if err != nil {
 				params.logger.Error("Failed to track source tx",
 					zap.String("vaaId", globalTx.Id),
@@ -29,9 +29,18 @@ func main() {
 		log.Fatalf("Failed to convert chain name to chain ID: %v", err)
 	}
 
+	// initialize the fetchers of the configured chains
+	providers, err := config.LoadRpcProviders(cfg.RpcProvidersPath)
+	if err != nil {
+		log.Fatalf("Failed to load rpc providers: %v", err)
+	}
+	registry, err := chains.NewRegistry(providers, os.Args[3])
+	if err != nil {
+		log.Fatalf("Failed to initialize chain registry: %v", err)
+	}
+
 	// fetch tx data
-	chains.Initialize(cfg)
-	txDetail, err := chains.FetchTx(context.Background(), cfg, chainId, os.Args[2], os.Args[3])
+	txDetail, err := registry.FetchTx(context.Background(), chainId, os.Args[2])
 	if err != nil {
 		log.Fatalf("Failed to get transaction data: %v", err)
 	}
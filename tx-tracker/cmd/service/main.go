@@ -44,8 +44,11 @@ func main() {
 
 	logger.Info("Starting deltaswap-explorer-tx-tracker ...")
 
-	// initialize rate limiters
-	chains.Initialize(&cfg.RpcProviderSettings)
+	// initialize the fetchers of the configured chains
+	registry, err := newChainRegistry(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize chain registry", zap.Error(err))
+	}
 
 	// initialize the database client
 	db, err := dbutil.Connect(rootCtx, logger, cfg.MongodbUri, cfg.MongodbDatabase, false)
@@ -58,7 +61,7 @@ func main() {
 	vaaRepository := vaa.NewRepository(db.Database, logger)
 
 	// create controller
-	vaaController := vaa.NewController(vaaRepository, repository, &cfg.RpcProviderSettings, cfg.P2pNetwork, logger)
+	vaaController := vaa.NewController(vaaRepository, repository, registry, logger)
 
 	// start serving /health and /ready endpoints
 	healthChecks, err := makeHealthChecks(rootCtx, cfg, db.Database)
@@ -70,7 +73,7 @@ func main() {
 
 	// create and start a consumer.
 	vaaConsumeFunc := newVAAConsumeFunc(rootCtx, cfg, metrics, logger)
-	consumer := consumer.New(vaaConsumeFunc, &cfg.RpcProviderSettings, rootCtx, logger, repository, metrics, cfg.P2pNetwork)
+	consumer := consumer.New(vaaConsumeFunc, registry, rootCtx, logger, repository, metrics)
 	consumer.Start(rootCtx)
 
 	logger.Info("Started deltaswap-explorer-tx-tracker")
@@ -180,6 +183,15 @@ func makeHealthChecks(
 	return plugins, nil
 }
 
+// newChainRegistry creates the chain registry from the RPC providers file.
+func newChainRegistry(cfg *config.ServiceSettings) (*chains.Registry, error) {
+	providers, err := config.LoadRpcProviders(cfg.RpcProvidersPath)
+	if err != nil {
+		return nil, err
+	}
+	return chains.NewRegistry(providers, cfg.P2pNetwork)
+}
+
 func newMetrics(cfg *config.ServiceSettings) metrics.Metrics {
 	if !cfg.MetricsEnabled {
 		return metrics.NewDummyMetrics()
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -62,62 +64,22 @@ type MongodbSettings struct {
 }
 
 type RpcProviderSettings struct {
-	AcalaBaseUrl                string `split_words:"true" required:"true"`
-	AcalaRequestsPerMinute      uint16 `split_words:"true" required:"true"`
-	AlgorandBaseUrl             string `split_words:"true" required:"true"`
-	AlgorandRequestsPerMinute   uint16 `split_words:"true" required:"true"`
-	AptosBaseUrl                string `split_words:"true" required:"true"`
-	AptosRequestsPerMinute      uint16 `split_words:"true" required:"true"`
-	ArbitrumBaseUrl             string `split_words:"true" required:"true"`
-	ArbitrumRequestsPerMinute   uint16 `split_words:"true" required:"true"`
-	AvalancheBaseUrl            string `split_words:"true" required:"true"`
-	AvalancheRequestsPerMinute  uint16 `split_words:"true" required:"true"`
-	BaseBaseUrl                 string `split_words:"true" required:"true"`
-	BaseRequestsPerMinute       uint16 `split_words:"true" required:"true"`
-	BscBaseUrl                  string `split_words:"true" required:"true"`
-	BscRequestsPerMinute        uint16 `split_words:"true" required:"true"`
-	CeloBaseUrl                 string `split_words:"true" required:"true"`
-	CeloRequestsPerMinute       uint16 `split_words:"true" required:"true"`
-	EthereumBaseUrl             string `split_words:"true" required:"true"`
-	EthereumRequestsPerMinute   uint16 `split_words:"true" required:"true"`
-	EvmosBaseUrl                string `split_words:"true" required:"true"`
-	EvmosRequestsPerMinute      uint16 `split_words:"true" required:"true"`
-	FantomBaseUrl               string `split_words:"true" required:"true"`
-	FantomRequestsPerMinute     uint16 `split_words:"true" required:"true"`
-	InjectiveBaseUrl            string `split_words:"true" required:"true"`
-	InjectiveRequestsPerMinute  uint16 `split_words:"true" required:"true"`
-	KaruraBaseUrl               string `split_words:"true" required:"true"`
-	KaruraRequestsPerMinute     uint16 `split_words:"true" required:"true"`
-	KlaytnBaseUrl               string `split_words:"true" required:"true"`
-	KlaytnRequestsPerMinute     uint16 `split_words:"true" required:"true"`
-	KujiraBaseUrl               string `split_words:"true" required:"true"`
-	KujiraRequestsPerMinute     uint16 `split_words:"true" required:"true"`
-	MoonbeamBaseUrl             string `split_words:"true" required:"true"`
-	MoonbeamRequestsPerMinute   uint16 `split_words:"true" required:"true"`
-	OasisBaseUrl                string `split_words:"true" required:"true"`
-	OasisRequestsPerMinute      uint16 `split_words:"true" required:"true"`
-	OptimismBaseUrl             string `split_words:"true" required:"true"`
-	OptimismRequestsPerMinute   uint16 `split_words:"true" required:"true"`
-	OsmosisBaseUrl              string `split_words:"true" required:"true"`
-	OsmosisRequestsPerMinute    uint16 `split_words:"true" required:"true"`
-	PlanqBaseUrl                string `split_words:"true" required:"true"`
-	PlanqRequestsPerMinute      uint16 `split_words:"true" required:"true"`
-	PolygonBaseUrl              string `split_words:"true" required:"true"`
-	PolygonRequestsPerMinute    uint16 `split_words:"true" required:"true"`
-	SeiBaseUrl                  string `split_words:"true" required:"true"`
-	SeiRequestsPerMinute        uint16 `split_words:"true" required:"true"`
-	SolanaBaseUrl               string `split_words:"true" required:"true"`
-	SolanaRequestsPerMinute     uint16 `split_words:"true" required:"true"`
-	SuiBaseUrl                  string `split_words:"true" required:"true"`
-	SuiRequestsPerMinute        uint16 `split_words:"true" required:"true"`
-	TerraBaseUrl                string `split_words:"true" required:"true"`
-	TerraRequestsPerMinute      uint16 `split_words:"true" required:"true"`
-	Terra2BaseUrl               string `split_words:"true" required:"true"`
-	Terra2RequestsPerMinute     uint16 `split_words:"true" required:"true"`
-	XplaBaseUrl                 string `split_words:"true" required:"true"`
-	XplaRequestsPerMinute       uint16 `split_words:"true" required:"true"`
-	DeltachainBaseUrl           string `split_words:"true" required:"true"`
-	DeltachainRequestsPerMinute uint16 `split_words:"true" required:"true"`
+	// RpcProvidersPath is the path of the JSON file with the RPC/API provider of each chain.
+	RpcProvidersPath string `split_words:"true" required:"true"`
+}
+
+// RpcProvider defines the RPC/API service used to fetch the transactions of a chain.
+type RpcProvider struct {
+	ChainID uint16 `json:"chainId"`
+	// Kind is the fetcher used for the chain: evm, cosmos, solana, aptos, sui, algorand, deltachain, sei or ibc.
+	Kind              string `json:"kind"`
+	BaseUrl           string `json:"baseUrl"`
+	RequestsPerMinute uint16 `json:"requestsPerMinute"`
+}
+
+// rpcProvidersFile is the content of the RPC providers file.
+type rpcProvidersFile struct {
+	Providers []RpcProvider `json:"providers"`
 }
 
 func LoadFromEnv[T any]() (*T, error) {
@@ -133,3 +95,19 @@ func LoadFromEnv[T any]() (*T, error) {
 
 	return &settings, nil
 }
+
+// LoadRpcProviders reads the RPC/API providers from a JSON file.
+func LoadRpcProviders(path string) ([]RpcProvider, error) {
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rpc providers file: %w", err)
+	}
+
+	var f rpcProvidersFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode rpc providers file: %w", err)
+	}
+
+	return f.Providers, nil
+}
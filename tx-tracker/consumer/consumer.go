@@ -5,7 +5,6 @@ import (
 	"errors"
 
 	"github.com/deltaswapio/deltaswap-explorer/txtracker/chains"
-	"github.com/deltaswapio/deltaswap-explorer/txtracker/config"
 	"github.com/deltaswapio/deltaswap-explorer/txtracker/internal/metrics"
 	"github.com/deltaswapio/deltaswap-explorer/txtracker/queue"
 	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
@@ -14,32 +13,29 @@ import (
 
 // Consumer consumer struct definition.
 type Consumer struct {
-	consumeFunc         queue.VAAConsumeFunc
-	rpcProviderSettings *config.RpcProviderSettings
-	logger              *zap.Logger
-	repository          *Repository
-	metrics             metrics.Metrics
-	p2pNetwork          string
+	consumeFunc queue.VAAConsumeFunc
+	registry    *chains.Registry
+	logger      *zap.Logger
+	repository  *Repository
+	metrics     metrics.Metrics
 }
 
 // New creates a new vaa consumer.
 func New(
 	consumeFunc queue.VAAConsumeFunc,
-	rpcProviderSettings *config.RpcProviderSettings,
+	registry *chains.Registry,
 	ctx context.Context,
 	logger *zap.Logger,
 	repository *Repository,
 	metrics metrics.Metrics,
-	p2pNetwork string,
 ) *Consumer {
 
 	c := Consumer{
-		consumeFunc:         consumeFunc,
-		rpcProviderSettings: rpcProviderSettings,
-		logger:              logger,
-		repository:          repository,
-		metrics:             metrics,
-		p2pNetwork:          p2pNetwork,
+		consumeFunc: consumeFunc,
+		registry:    registry,
+		logger:      logger,
+		repository:  repository,
+		metrics:     metrics,
 	}
 
 	return &c
@@ -84,12 +80,13 @@ func (c *Consumer) process(ctx context.Context, msg queue.ConsumerMessage) {
 		TxHash:    event.TxHash,
 		Overwrite: false, // avoid processing the same transaction twice
 	}
-	_, err := ProcessSourceTx(ctx, c.logger, c.rpcProviderSettings, c.repository, &p, c.p2pNetwork)
+	_, err := ProcessSourceTx(ctx, c.logger, c.registry, c.repository, &p)
 
 	// Log a message informing the processing status
 	if errors.Is(err, chains.ErrChainNotSupported) {
 		c.logger.Info("Skipping VAA - chain not supported",
 			zap.String("vaaId", event.ID),
+			zap.Error(err),
 		)
 	} else if errors.Is(err, ErrAlreadyProcessed) {
 		c.logger.Warn("Message already processed - skipping",
@@ -8,7 +8,6 @@ import (
 
 	"github.com/deltaswapio/deltaswap-explorer/common/domain"
 	"github.com/deltaswapio/deltaswap-explorer/txtracker/chains"
-	"github.com/deltaswapio/deltaswap-explorer/txtracker/config"
 	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
 	"go.uber.org/zap"
 )
@@ -41,10 +40,9 @@ type ProcessSourceTxParams struct {
 func ProcessSourceTx(
 	ctx context.Context,
 	logger *zap.Logger,
-	rpcServiceProviderSettings *config.RpcProviderSettings,
+	registry *chains.Registry,
 	repository *Repository,
 	params *ProcessSourceTxParams,
-	p2pNetwork string,
 ) (*chains.TxDetail, error) {
 
 	if !params.Overwrite {
@@ -73,11 +71,16 @@ func ProcessSourceTx(
 	for retries := 0; ; retries++ {
 
 		// Get transaction details from the emitter blockchain
-		txDetail, err = chains.FetchTx(ctx, rpcServiceProviderSettings, params.ChainId, params.TxHash, p2pNetwork)
+		txDetail, err = registry.FetchTx(ctx, params.ChainId, params.TxHash)
 		if err == nil {
 			break
 		}
 
+		// Retrying won't help if there is no RPC provider for the chain
+		if errors.Is(err, chains.ErrChainNotSupported) {
+			return nil, err
+		}
+
 		// Keep retrying?
 		if params.Timestamp == nil && retries > minRetries {
 			return nil, fmt.Errorf("failed to process transaction: %w", err)
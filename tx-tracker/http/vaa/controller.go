@@ -3,7 +3,7 @@ package vaa
 import (
 	"strconv"
 
-	"github.com/deltaswapio/deltaswap-explorer/txtracker/config"
+	"github.com/deltaswapio/deltaswap-explorer/txtracker/chains"
 	"github.com/deltaswapio/deltaswap-explorer/txtracker/consumer"
 	sdk "github.com/deltaswapio/deltaswap/sdk/vaa"
 	"github.com/gofiber/fiber/v2"
@@ -12,16 +12,15 @@ import (
 
 // Controller definition.
 type Controller struct {
-	logger              *zap.Logger
-	vaaRepository       *Repository
-	repository          *consumer.Repository
-	rpcProviderSettings *config.RpcProviderSettings
-	p2pNetwork          string
+	logger        *zap.Logger
+	vaaRepository *Repository
+	repository    *consumer.Repository
+	registry      *chains.Registry
 }
 
 // NewController creates a Controller instance.
-func NewController(vaaRepository *Repository, repository *consumer.Repository, rpcProviderSettings *config.RpcProviderSettings, p2pNetwork string, logger *zap.Logger) *Controller {
-	return &Controller{vaaRepository: vaaRepository, repository: repository, rpcProviderSettings: rpcProviderSettings, p2pNetwork: p2pNetwork, logger: logger}
+func NewController(vaaRepository *Repository, repository *consumer.Repository, registry *chains.Registry, logger *zap.Logger) *Controller {
+	return &Controller{vaaRepository: vaaRepository, repository: repository, registry: registry, logger: logger}
 }
 
 func (c *Controller) Process(ctx *fiber.Ctx) error {
@@ -55,7 +54,7 @@ func (c *Controller) Process(ctx *fiber.Ctx) error {
 		Overwrite: true,
 	}
 
-	result, err := consumer.ProcessSourceTx(ctx.Context(), c.logger, c.rpcProviderSettings, c.repository, p, c.p2pNetwork)
+	result, err := consumer.ProcessSourceTx(ctx.Context(), c.logger, c.registry, c.repository, p)
 	if err != nil {
 		return err
 	}